@@ -11,11 +11,11 @@ import (
 	"strings"
 )
 
-func Build(project *workspace.Project, entrypoint *ir.Module, optimizationLevel uint8, outputName string) (string, error) {
-	_ = os.Mkdir("build", 0750)
+func Build(project *workspace.Project, entrypoint *ir.Module, optimizationLevel uint8, safetyChecks bool, outputName string) (string, error) {
+	_ = os.Mkdir(filepath.Join(project.Path, "build"), 0750)
 
 	// Emit project IR
-	ctx := codegen.Context{}
+	ctx := codegen.Context{SafetyChecks: safetyChecks}
 	irPaths := make([]string, 0, len(project.Files))
 
 	for _, file := range project.Files {
@@ -14,6 +14,7 @@ import (
 )
 
 var opt uint8
+var safetyChecks bool
 
 func GetBuildCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -23,16 +24,17 @@ func GetBuildCmd() *cobra.Command {
 	}
 
 	cmd.Flags().Uint8VarP(&opt, "opt", "O", 0, "Optimization level. [-O0, -O1, -O2, or -O3] (default = '-O0')")
+	addSafetyChecksFlag(cmd)
 
 	return cmd
 }
 
-func buildCmd(_ *cobra.Command, _ []string) {
-	buildProject()
+func buildCmd(cmd *cobra.Command, _ []string) {
+	buildProject(cmd)
 }
 
 //goland:noinspection GoBoolExpressions
-func buildProject() string {
+func buildProject(cmd *cobra.Command) string {
 	start := time.Now()
 
 	// Create project
@@ -51,7 +53,7 @@ func buildProject() string {
 	build.Report(project)
 
 	// Build
-	output, err := build.Build(project, generateEntrypoint(project), opt, project.Config.Name)
+	output, err := build.Build(project, generateEntrypoint(project), opt, getSafetyChecks(cmd, project, opt), project.Config.Name)
 	if err != nil {
 		log.Fatalln(err.Error())
 	}
@@ -67,6 +69,23 @@ func buildProject() string {
 	return output
 }
 
+func addSafetyChecksFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&safetyChecks, "safety-checks", false, "Emit runtime checks for overflow, bounds, division by zero and nil dereferences. (default = on at '-O0')")
+}
+
+// getSafetyChecks returns if runtime safety checks are enabled, the flag takes precedence over the project config.
+func getSafetyChecks(cmd *cobra.Command, project *workspace.Project, opt uint8) bool {
+	if cmd.Flags().Changed("safety-checks") {
+		return safetyChecks
+	}
+
+	if project.Config.SafetyChecks != nil {
+		return *project.Config.SafetyChecks
+	}
+
+	return opt == 0
+}
+
 func generateEntrypoint(project *workspace.Project) *ir.Module {
 	m := &ir.Module{Path: "__entrypoint"}
 
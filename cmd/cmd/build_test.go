@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fireball/core/workspace"
+	"testing"
+)
+
+func TestGetSafetyChecks(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	tests := []struct {
+		name     string
+		flag     string
+		config   *bool
+		opt      uint8
+		expected bool
+	}{
+		{name: "default O0", opt: 0, expected: true},
+		{name: "default O2", opt: 2, expected: false},
+		{name: "config enables", config: &enabled, opt: 2, expected: true},
+		{name: "config disables", config: &disabled, opt: 0, expected: false},
+		{name: "flag enables", flag: "true", opt: 2, expected: true},
+		{name: "flag disables", flag: "false", opt: 0, expected: false},
+		{name: "flag over config", flag: "false", config: &enabled, opt: 0, expected: false},
+		{name: "flag over config disabled", flag: "true", config: &disabled, opt: 2, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := GetBuildCmd()
+
+			if test.flag != "" {
+				if err := cmd.Flags().Set("safety-checks", test.flag); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			project := &workspace.Project{Config: workspace.Config{SafetyChecks: test.config}}
+
+			if actual := getSafetyChecks(cmd, project, test.opt); actual != test.expected {
+				t.Errorf("expected %t but got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -15,13 +15,14 @@ func GetRunCmd() *cobra.Command {
 	}
 
 	cmd.Flags().Uint8VarP(&opt, "opt", "O", 0, "Optimization level. [-O0, -O1, -O2, or -O3] (default = '-O0')")
+	addSafetyChecksFlag(cmd)
 
 	return cmd
 }
 
-func runCmd(_ *cobra.Command, _ []string) {
+func runCmd(command *cobra.Command, _ []string) {
 	// Build
-	output := buildProject()
+	output := buildProject(command)
 
 	// Run
 	cmd := exec.Command(output)
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fireball/cmd/build"
+	"fireball/core/workspace"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestSafetyChecksTrap(t *testing.T) {
+	for _, tool := range []string{"llc", "ld.lld"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("'%s' is not available", tool)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{
+			name:    "overflow",
+			body:    "var a = 2147483647;\n    a += 1;",
+			message: "main.fb:5:7] Integer overflow",
+		},
+		{
+			name:    "negation",
+			body:    "var a = -2147483647 - 1;\n    a = -a;",
+			message: "main.fb:5:9] Integer overflow",
+		},
+		{
+			name:    "division by zero",
+			body:    "var a = 0;\n    a = 5 / a;",
+			message: "main.fb:5:11] Division by zero",
+		},
+		{
+			name:    "remainder by zero",
+			body:    "var a = 0u;\n    a = 5u % a;",
+			message: "main.fb:5:12] Division by zero",
+		},
+		{
+			name:    "division overflow",
+			body:    "var a = -2147483647 - 1;\n    var b = -1;\n    a = a / b;",
+			message: "main.fb:6:11] Integer overflow",
+		},
+		{
+			name:    "remainder overflow",
+			body:    "var a = 1 as i64 << 63;\n    var b = -1 as i64;\n    a = a % b;",
+			message: "main.fb:6:11] Integer overflow",
+		},
+		{
+			name:    "array index",
+			body:    "var a = [ 1, 2, 3 ];\n    var i = 3;\n    a[i] = 0;",
+			message: "main.fb:6:7] Index out of bounds",
+		},
+		{
+			name:    "negative index",
+			body:    "var a = [ 1, 2, 3 ];\n    var i = -1;\n    a[i] = 0;",
+			message: "main.fb:6:7] Index out of bounds",
+		},
+		{
+			name:    "slice index",
+			body:    "var a = [ 1, 2, 3 ];\n    var s = a[1:];\n    s[2] = 0;",
+			message: "main.fb:6:7] Index out of bounds",
+		},
+		{
+			name:    "slice high bound",
+			body:    "var a = [ 1, 2, 3 ];\n    var high = 4;\n    var _s = a[1:high];",
+			message: "main.fb:6:18] Slice bounds out of range",
+		},
+		{
+			name:    "slice low bound",
+			body:    "var a = [ 1, 2, 3 ];\n    var s = a[:];\n    var low = 3;\n    var _s2 = s[low:2];",
+			message: "main.fb:7:17] Slice bounds out of range",
+		},
+		{
+			name:    "pointer slice bounds",
+			body:    "var a = [ 1, 2, 3 ];\n    var p = &a[0];\n    var low = 2;\n    var _s = p[low:1];",
+			message: "main.fb:7:16] Slice bounds out of range",
+		},
+		{
+			name:    "nil dereference",
+			body:    "var p *i32;\n    *p = 5;",
+			message: "main.fb:5:6] Nil pointer dereference",
+		},
+		{
+			name:    "nil member",
+			body:    "var p *Point;\n    p.x = 5;",
+			message: "main.fb:5:5] Nil pointer dereference",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := runTrapping(t, "namespace Tests;\n\nfunc main() {\n    "+test.body+"\n}\n\nstruct Point {\n    x i32,\n}\n")
+
+			if !strings.HasPrefix(output, "PANIC   [") || !strings.Contains(output, test.message) {
+				t.Errorf("expected a panic with '%s' but got %q", test.message, output)
+			}
+		})
+	}
+}
+
+// runTrapping builds the text as a project with safety checks and runs it, the program is expected to trap.
+func runTrapping(t *testing.T, text string) string {
+	project := workspace.NewEmptyProject(t.TempDir(), "Tests")
+	project.Config.Namespace = workspace.ConfigNamespace{"Tests"}
+
+	file := project.GetOrCreateFile("main.fb")
+	file.SetText(text, true)
+	file.EnsureChecked()
+
+	for _, diagnostic := range file.Diagnostics() {
+		t.Fatalf("unexpected diagnostic: %s", diagnostic.Message)
+	}
+
+	executable, err := build.Build(project, generateEntrypoint(project), 0, true, "safety")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(executable).CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected the program to trap but it exited normally with %q", output)
+	}
+
+	return string(output)
+}
@@ -24,10 +24,12 @@ func GetTestCmd() *cobra.Command {
 		Run:   testCmd,
 	}
 
+	addSafetyChecksFlag(cmd)
+
 	return cmd
 }
 
-func testCmd(_ *cobra.Command, _ []string) {
+func testCmd(cmd *cobra.Command, _ []string) {
 	start := time.Now()
 
 	// Create project
@@ -48,7 +50,7 @@ func testCmd(_ *cobra.Command, _ []string) {
 	// Build
 	tests := getTests(project)
 
-	output, err := build.Build(project, generateTestsEntrypoint(tests), 0, fmt.Sprintf("%s_tests", project.Config.Name))
+	output, err := build.Build(project, generateTestsEntrypoint(tests), 0, getSafetyChecks(cmd, project, 0), fmt.Sprintf("%s_tests", project.Config.Name))
 	if err != nil {
 		log.Fatalln(err.Error())
 	}
@@ -37,7 +37,13 @@ func (a *annotator) visitEnum(decl *ast.Enum) {
 	}
 
 	for _, case_ := range decl.Cases {
-		if case_.Value == nil {
+		if case_.Value != nil {
+			continue
+		}
+
+		if decl.IsFlags() {
+			a.addToken(case_.Name, fmt.Sprintf(" = 0x%X (0b%b)", case_.ActualValue, case_.ActualValue), protocol.InlayHintKindParameter)
+		} else {
 			a.addToken(case_.Name, fmt.Sprintf(" = %d", case_.ActualValue), protocol.InlayHintKindParameter)
 		}
 	}
@@ -55,6 +61,12 @@ func (a *annotator) visitVar(stmt *ast.Var) {
 	stmt.AcceptChildren(a)
 }
 
+func (a *annotator) visitForIn(stmt *ast.ForIn) {
+	a.addToken(stmt.Name, " "+ast.PrintType(stmt.ActualType), protocol.InlayHintKindType)
+
+	stmt.AcceptChildren(a)
+}
+
 // Expressions
 
 func (a *annotator) visitCall(expr *ast.Call) {
@@ -87,6 +99,9 @@ func (a *annotator) VisitNode(node ast.Node) {
 	case *ast.Var:
 		a.visitVar(node)
 
+	case *ast.ForIn:
+		a.visitForIn(node)
+
 	case *ast.Call:
 		a.visitCall(node)
 
@@ -16,7 +16,7 @@ func getCompletions(project *workspace.Project, file *ast.File, pos core.Pos) *p
 		return nil
 	}
 
-	c := completions{}
+	c := completions{file: file}
 	combinedResolver := ast.NewCombinedResolver(baseResolver)
 
 	for _, decl := range file.Decls {
@@ -170,6 +170,11 @@ func getTypeCompletions(root ast.RootResolver, resolver ast.Resolver, c *complet
 			getGlobalCompletions(resolver, c, true)
 		}
 
+	case *ast.Const:
+		if isAfterNode(pos, node.Name) {
+			getGlobalCompletions(resolver, c, true)
+		}
+
 	case *ast.Resolvable:
 		getResolvableCompletions(resolver, c, pos, node)
 
@@ -228,14 +233,28 @@ func getMemberCompletions(resolver ast.Resolver, c *completions, member *ast.Mem
 			for _, case_ := range e.Cases {
 				c.addNode(protocol.CompletionItemKindEnumMember, case_.Name, strconv.FormatInt(case_.ActualValue, 10))
 			}
-		} else if i, ok := asThroughPointer[*ast.Interface](member.Value.Result().Type); ok && member.Value.Result().Kind == ast.ValueResultKind {
-			for _, method := range i.Methods {
-				c.addNode(protocol.CompletionItemKindMethod, method.Name, printType(method))
+		} else if i, ok := asThroughPointer[ast.InterfaceType](member.Value.Result().Type); ok && member.Value.Result().Kind == ast.ValueResultKind {
+			addInterfaceMethods(c, i)
+		} else if g, ok := asThroughPointer[*ast.Generic](member.Value.Result().Type); ok && member.Value.Result().Kind == ast.ValueResultKind {
+			if i, ok := ast.As[ast.InterfaceType](g.Bound); ok {
+				addInterfaceMethods(c, i)
 			}
+		} else if s, ok := ast.As[*ast.Slice](member.Value.Result().Type); ok && member.Value.Result().Kind == ast.ValueResultKind {
+			c.add(protocol.CompletionItemKindField, "ptr", printType(&ast.Pointer{Pointee: s.Base}), false)
+			c.add(protocol.CompletionItemKindField, "len", "u64", false)
+		} else if e, ok := ast.As[*ast.Enum](member.Value.Result().Type); ok && e.IsFlags() && member.Value.Result().Kind == ast.ValueResultKind {
+			c.add(protocol.CompletionItemKindMethod, "has", "(flag "+printType(e)+") bool", false)
 		}
 	}
 }
 
+func addInterfaceMethods(c *completions, inter ast.InterfaceType) {
+	for i := 0; i < inter.MethodCount(); i++ {
+		method := inter.MethodIndex(i)
+		c.addNode(protocol.CompletionItemKindMethod, method.Underlying().Name, printType(method))
+	}
+}
+
 func getIdentifierCompletions(resolver ast.Resolver, c *completions, pos core.Pos, node ast.Node) {
 	// Types and global functions
 	getGlobalCompletions(resolver, c, false)
@@ -333,7 +352,12 @@ func getGlobalCompletions(resolver ast.Resolver, c *completions, symbolsOnlyType
 
 		c.add(protocol.CompletionItemKindFunction, "sizeof", "(<type>) u32", false)
 		c.add(protocol.CompletionItemKindFunction, "alignof", "(<type>) u32", false)
-		c.add(protocol.CompletionItemKindFunction, "typeof", "(<expression>) u32", false)
+		c.add(protocol.CompletionItemKindFunction, "typeof", "(<expression>) *TypeInfo", false)
+		c.add(protocol.CompletionItemKindFunction, "dyntypeof", "(<interface value>) *TypeInfo", false)
+		c.add(protocol.CompletionItemKindFunction, "nameof", "(<enum value>) *u8", false)
+		c.add(protocol.CompletionItemKindFunction, "countof", "(<enum>) u32", false)
+		c.add(protocol.CompletionItemKindFunction, "casesof", "(<enum>) []<enum>", false)
+		c.add(protocol.CompletionItemKindFunction, "asm", "(<template> : <outputs> : <inputs> : <clobbers>)", false)
 	}
 
 	// Language defined types and functions
@@ -372,6 +396,11 @@ func (c *completions) VisitSymbol(node ast.Node) {
 		if !c.symbolsOnlyTypes {
 			c.addNode(protocol.CompletionItemKindVariable, node.Name, printType(node.Type))
 		}
+
+	case *ast.Const:
+		if !c.symbolsOnlyTypes {
+			c.addNode(protocol.CompletionItemKindConstant, node.Name, printType(node.Type))
+		}
 	}
 }
 
@@ -435,6 +464,7 @@ func isInFunctionBody(pos core.Pos, node ast.Node) bool {
 // Completions
 
 type completions struct {
+	file             *ast.File
 	symbolsOnlyTypes bool
 
 	items []protocol.CompletionItem
@@ -444,6 +474,11 @@ var commitCharacters = []string{".", ";"}
 
 func (c *completions) addNode(kind protocol.CompletionItemKind, name ast.Node, detail string) {
 	if !ast.IsNil(name) {
+		// Skip declarations which are private to another namespace
+		if decl, ok := name.Parent().(ast.Visible); ok && c.file != nil && !ast.IsAccessible(decl, c.file) {
+			return
+		}
+
 		generics := false
 
 		switch node := name.Parent().(type) {
@@ -42,6 +42,11 @@ func getDefinitionToken(token *ast.Token) []protocol.Location {
 				return newDefinition(field)
 			}
 		}
+
+	case *ast.NamedArg:
+		if param, ok := getNamedArgParam(parent); ok {
+			return newDefinition(param.Param)
+		}
 	}
 
 	return nil
@@ -377,7 +377,7 @@ func (h *handler) DocumentSymbol(_ context.Context, params *protocol.DocumentSym
 
 	// Get symbols
 	symbols := documentSymbolConsumer{symbols: make([]any, 0, 16)}
-	getSymbols(&symbols, []*workspace.File{file})
+	getSymbols(&symbols, []*workspace.File{file}, nil)
 
 	return symbols.symbols, nil
 }
@@ -442,7 +442,7 @@ func (h *handler) Symbols(_ context.Context, _ *protocol.WorkspaceSymbolParams)
 			i++
 		}
 
-		getSymbols(&symbols, files)
+		getSymbols(&symbols, files, project)
 	}
 
 	return symbols.symbols, nil
@@ -73,6 +73,25 @@ func nodeCst(node ast.Node) *cst.Node {
 	return node.Cst()
 }
 
+func getNamedArgParam(arg *ast.NamedArg) (ast.SpecializedParam, bool) {
+	call, ok := arg.Parent().(*ast.Call)
+	if !ok || arg.Name == nil || call.Callee == nil {
+		return ast.SpecializedParam{}, false
+	}
+
+	if function, ok := ast.As[ast.FuncType](call.Callee.Result().Type); ok {
+		for i := 0; i < function.ParameterCount(); i++ {
+			param := function.ParameterIndex(i)
+
+			if param.Param.Name != nil && param.Param.Name.String() == arg.Name.String() {
+				return param, true
+			}
+		}
+	}
+
+	return ast.SpecializedParam{}, false
+}
+
 // Variable resolver
 
 type scope struct {
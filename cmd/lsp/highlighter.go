@@ -53,6 +53,10 @@ func (h *highlighter) VisitEnum(decl *ast.Enum) {
 
 	for _, case_ := range decl.Cases {
 		h.add(case_.Name, enumMemberKind)
+
+		for _, field := range case_.Fields {
+			h.add(field.Name(), propertyKind)
+		}
 	}
 
 	decl.AcceptChildren(h)
@@ -88,6 +92,12 @@ func (h *highlighter) VisitGlobalVar(decl *ast.GlobalVar) {
 	decl.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitConst(decl *ast.Const) {
+	h.add(decl.Name, variableKind)
+
+	decl.AcceptChildren(h)
+}
+
 // Statements
 
 func (h *highlighter) VisitBlock(stmt *ast.Block) {
@@ -116,6 +126,25 @@ func (h *highlighter) VisitFor(stmt *ast.For) {
 	stmt.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitForIn(stmt *ast.ForIn) {
+	h.add(stmt.Name, variableKind)
+	stmt.AcceptChildren(h)
+}
+
+func (h *highlighter) VisitMatch(stmt *ast.Match) {
+	for _, arm := range stmt.Arms {
+		for _, binding := range arm.Bindings {
+			h.add(binding, variableKind)
+		}
+	}
+
+	stmt.AcceptChildren(h)
+}
+
+func (h *highlighter) VisitDefer(stmt *ast.Defer) {
+	stmt.AcceptChildren(h)
+}
+
 func (h *highlighter) VisitReturn(stmt *ast.Return) {
 	stmt.AcceptChildren(h)
 }
@@ -134,6 +163,11 @@ func (h *highlighter) VisitParen(expr *ast.Paren) {
 	expr.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitNamedArg(expr *ast.NamedArg) {
+	h.add(expr.Name, parameterKind)
+	expr.AcceptChildren(h)
+}
+
 func (h *highlighter) VisitLiteral(expr *ast.Literal) {
 	expr.AcceptChildren(h)
 }
@@ -150,6 +184,14 @@ func (h *highlighter) VisitAllocateArray(expr *ast.AllocateArray) {
 	expr.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitClosure(expr *ast.Closure) {
+	for _, capture := range expr.Captures {
+		h.add(capture.Name, variableKind)
+	}
+
+	expr.AcceptChildren(h)
+}
+
 func (h *highlighter) VisitUnary(expr *ast.Unary) {
 	expr.AcceptChildren(h)
 }
@@ -188,6 +230,10 @@ func (h *highlighter) VisitTypeof(expr *ast.Typeof) {
 	expr.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitAsm(expr *ast.Asm) {
+	expr.AcceptChildren(h)
+}
+
 func (h *highlighter) VisitCall(expr *ast.Call) {
 	expr.AcceptChildren(h)
 }
@@ -196,6 +242,10 @@ func (h *highlighter) VisitIndex(expr *ast.Index) {
 	expr.AcceptChildren(h)
 }
 
+func (h *highlighter) VisitSlicing(expr *ast.Slicing) {
+	expr.AcceptChildren(h)
+}
+
 func (h *highlighter) VisitMember(expr *ast.Member) {
 	h.visitExprResult(expr.Name, expr.Result())
 
@@ -213,7 +263,7 @@ func (h *highlighter) visitExprResult(node ast.Node, result *ast.ExprResult) {
 			h.add(node, classKind)
 		case *ast.Enum:
 			h.add(node, enumKind)
-		case *ast.Interface:
+		case ast.InterfaceType:
 			h.add(node, interfaceKind)
 		}
 
@@ -226,10 +276,12 @@ func (h *highlighter) visitExprResult(node ast.Node, result *ast.ExprResult) {
 			h.add(node, propertyKind)
 		case *ast.Param:
 			h.add(node, parameterKind)
-		case *ast.Var:
+		case *ast.Var, *ast.Token, *ast.Capture, *ast.Const:
 			h.add(node, variableKind)
 		case *ast.EnumCase:
 			h.add(node, enumMemberKind)
+		case *ast.Enum:
+			h.add(node, functionKind)
 		}
 
 	case ast.CallableResultKind:
@@ -259,7 +311,7 @@ func (h *highlighter) visitType(type_ ast.Type) {
 				h.add(last, classKind)
 			case *ast.Enum:
 				h.add(last, enumKind)
-			case *ast.Interface:
+			case ast.InterfaceType:
 				h.add(last, interfaceKind)
 			}
 
@@ -4,7 +4,9 @@ import (
 	"fireball/core"
 	"fireball/core/abi"
 	"fireball/core/ast"
+	"fmt"
 	"github.com/MineGame159/protocol"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -47,12 +49,32 @@ func getHoverToken(token *ast.Token) *protocol.Hover {
 	case *ast.EnumCase:
 		return newHover(token, strconv.FormatInt(parent.ActualValue, 10))
 
+	case *ast.Const:
+		return newHover(token, fmt.Sprintf("%s = %s", printType(parent.Type), parent.ActualValue))
+
 	case *ast.Param:
 		return newHover(token, printType(parent.Type))
 
 	case *ast.Var:
 		return newHover(token, printType(parent.ActualType))
 
+	case *ast.ForIn:
+		return newHover(token, printType(parent.ActualType))
+
+	case *ast.Capture:
+		if parent.ActualType != nil {
+			return newHover(token, printType(parent.ActualType))
+		}
+
+	case *ast.MatchArm:
+		if len(parent.Values) == 1 && parent.Values[0].Result().Kind == ast.ValueResultKind {
+			if case_, ok := parent.Values[0].Result().Value().(*ast.EnumCase); ok {
+				if i := slices.Index(parent.Bindings, token); i >= 0 && i < len(case_.Fields) {
+					return newHover(token, printType(case_.Fields[i].Type()))
+				}
+			}
+		}
+
 	case *ast.Identifier:
 		return getHoverExprResult(parent.Result(), token)
 
@@ -66,10 +88,18 @@ func getHoverToken(token *ast.Token) *protocol.Hover {
 
 		value := uint32(0)
 
-		if parent.Callee.String() == "sizeof" {
+		switch parent.Callee.String() {
+		case "sizeof":
 			value = abi.GetTargetAbi().Size(parent.Arg)
-		} else {
+		case "alignof":
 			value = abi.GetTargetAbi().Align(parent.Arg)
+		case "countof":
+			if enum, ok := ast.As[*ast.Enum](parent.Arg); ok {
+				value = uint32(len(enum.Cases))
+			}
+
+		default:
+			return nil
 		}
 
 		return newHover(token, strconv.FormatUint(uint64(value), 10))
@@ -80,6 +110,11 @@ func getHoverToken(token *ast.Token) *protocol.Hover {
 				return newHover(token, ast.PrintType(field.Type()))
 			}
 		}
+
+	case *ast.NamedArg:
+		if param, ok := getNamedArgParam(parent); ok {
+			return newHover(token, ast.PrintType(param.Type))
+		}
 	}
 
 	return nil
@@ -90,6 +125,10 @@ func getHoverExprResult(result *ast.ExprResult, node ast.Node) *protocol.Hover {
 		if case_, ok := result.Value().(*ast.EnumCase); ok {
 			return newHover(node, strconv.FormatInt(case_.ActualValue, 10))
 		}
+
+		if constant, ok := result.Value().(*ast.Const); ok {
+			return newHover(node, fmt.Sprintf("%s = %s", printType(constant.Type), constant.ActualValue))
+		}
 	}
 
 	return newHover(node, printType(result.Type))
@@ -21,8 +21,8 @@ func getImplementations(node ast.Node, pos core.Pos, resolver ast.Resolver) []pr
 		builder.visit(resolver)
 
 	case *ast.Resolvable:
-		if inter, ok := ast.As[*ast.Interface](parent); ok {
-			builder.target = inter
+		if inter, ok := ast.As[ast.InterfaceType](parent); ok {
+			builder.target = inter.Underlying()
 			builder.visit(resolver)
 		}
 	}
@@ -44,7 +44,7 @@ func (i *implementationBuilder) visit(resolver ast.Resolver) {
 }
 
 func (i *implementationBuilder) VisitSymbol(node ast.Node) {
-	if impl, ok := node.(*ast.Impl); ok && i.target.Equals(impl.Implements) {
+	if impl, ok := node.(*ast.Impl); ok && implementsTarget(impl, i.target) {
 		file := ast.GetParent[*ast.File](node)
 		if file == nil {
 			return
@@ -56,3 +56,8 @@ func (i *implementationBuilder) VisitSymbol(node ast.Node) {
 		})
 	}
 }
+
+func implementsTarget(impl *ast.Impl, target *ast.Interface) bool {
+	inter, ok := ast.As[ast.InterfaceType](impl.Implements)
+	return ok && (inter.Underlying() == target || ast.Inherits(inter, target))
+}
@@ -3,6 +3,7 @@ package lsp
 import (
 	"fireball/core"
 	"fireball/core/ast"
+	"fireball/core/cst"
 	"fireball/core/scanner"
 	"fmt"
 	"github.com/MineGame159/protocol"
@@ -74,6 +75,27 @@ func getCallSignature(pos core.Pos, call *ast.Call) (protocol.SignatureInformati
 		return protocol.SignatureInformation{}, false
 	}
 
+	// Get argument under position
+	activeArg := -1
+
+	for i, arg := range call.Args {
+		if arg.Cst() == nil {
+			continue
+		}
+
+		range_ := arg.Cst().Range
+
+		if i > 0 && call.Args[i-1].Cst() != nil {
+			range_.Start = call.Args[i-1].Cst().Range.End
+			range_.Start.Column++
+		}
+
+		if range_.Contains(pos) {
+			activeArg = i
+			break
+		}
+	}
+
 	// Get label and parameters
 	label := strings.Builder{}
 	parameters := make([]protocol.ParameterInformation, 0, function.ParameterCount()+1)
@@ -91,6 +113,10 @@ func getCallSignature(pos core.Pos, call *ast.Call) (protocol.SignatureInformati
 		// Label
 		paramLabel := fmt.Sprintf("%s %s", param.Param.Name, ast.PrintType(param.Type))
 
+		if param.Param.Value != nil && param.Param.Value.Cst() != nil {
+			paramLabel += " = " + cstText(*param.Param.Value.Cst())
+		}
+
 		if len(parameters) > 0 {
 			label.WriteString(", ")
 		}
@@ -99,15 +125,12 @@ func getCallSignature(pos core.Pos, call *ast.Call) (protocol.SignatureInformati
 		parameters = append(parameters, protocol.ParameterInformation{Label: paramLabel})
 
 		// Active parameter
-		if i < len(call.Args) && call.Args[i].Cst() != nil {
-			range_ := call.Args[i].Cst().Range
-
-			if i > 0 && call.Args[i-1].Cst() != nil {
-				range_.Start = call.Args[i-1].Cst().Range.End
-				range_.Start.Column++
-			}
-
-			if range_.Contains(pos) {
+		if activeArg != -1 {
+			if named, ok := call.Args[activeArg].(*ast.NamedArg); ok {
+				if named.Name != nil && param.Param.Name != nil && named.Name.String() == param.Param.Name.String() {
+					activeParameter = len(parameters) - 1
+				}
+			} else if activeArg == i {
 				activeParameter = len(parameters) - 1
 			}
 		}
@@ -152,11 +175,53 @@ func getTypeCallSignature(pos core.Pos, call *ast.TypeCall) (protocol.SignatureI
 	}
 
 	// Return
+	param := "<type>"
+	returns := "u32"
+
+	switch call.Callee.String() {
+	case "countof":
+		param = "<enum>"
+	case "casesof":
+		param = "<enum>"
+		returns = "[]<enum>"
+	}
+
 	return protocol.SignatureInformation{
-		Label: call.Callee.String() + "(<type>) u32",
+		Label: call.Callee.String() + "(" + param + ") " + returns,
 		Parameters: []protocol.ParameterInformation{{
-			Label: "<type>",
+			Label: param,
 		}},
 		ActiveParameter: 0,
 	}, true
 }
+
+// cstText reconstructs the source text of a node from its tokens, separating tokens that were not adjacent with a space.
+func cstText(node cst.Node) string {
+	text := strings.Builder{}
+	var prev *cst.Node
+
+	var visit func(node *cst.Node)
+	visit = func(node *cst.Node) {
+		if !node.Leaf() {
+			for i := range node.Children {
+				visit(&node.Children[i])
+			}
+
+			return
+		}
+
+		if node.Kind == cst.CommentNode {
+			return
+		}
+
+		if prev != nil && (prev.Range.End.Line != node.Range.Start.Line || prev.Range.End.Column != node.Range.Start.Column) {
+			text.WriteRune(' ')
+		}
+
+		text.WriteString(node.Token.Lexeme)
+		prev = node
+	}
+
+	visit(&node)
+	return text.String()
+}
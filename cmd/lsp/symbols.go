@@ -28,7 +28,9 @@ type symbolConsumer interface {
 	supportsDetail() bool
 }
 
-func getSymbols(symbols symbolConsumer, files []*workspace.File) {
+// getSymbols adds symbols of all declarations in the files, when a project is given declarations which are private
+// to a namespace that none of the project files can access are skipped.
+func getSymbols(symbols symbolConsumer, files []*workspace.File, project *workspace.Project) {
 	// Find method count per struct
 	methodCount := make(map[ast.Type]int)
 
@@ -46,7 +48,7 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 	for _, file := range files {
 		for _, decl := range file.Ast.Decls {
 			if struct_, ok := decl.(*ast.Struct); ok {
-				if struct_.Cst() == nil || nodeCst(struct_.Name) == nil {
+				if struct_.Cst() == nil || nodeCst(struct_.Name) == nil || !isVisible(project, file, struct_) {
 					continue
 				}
 
@@ -59,7 +61,7 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 				}, len(struct_.StaticFields)+len(struct_.Fields)+methodCount[struct_])
 
 				for _, field := range struct_.StaticFields {
-					if nodeCst(field.Name()) == nil {
+					if nodeCst(field.Name()) == nil || !isVisible(project, file, field) {
 						continue
 					}
 
@@ -74,7 +76,7 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 				}
 
 				for _, field := range struct_.Fields {
-					if nodeCst(field.Name()) == nil {
+					if nodeCst(field.Name()) == nil || !isVisible(project, file, field) {
 						continue
 					}
 
@@ -97,6 +99,10 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 	for _, file := range files {
 		for _, decl := range file.Ast.Decls {
 			if impl, ok := decl.(*ast.Impl); ok && impl.Type != nil {
+				if !isVisible(project, file, impl.Type) {
+					continue
+				}
+
 				// Methods
 				id, ok := structs[impl.Type]
 
@@ -110,7 +116,7 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 				}
 
 				for _, function := range impl.Methods {
-					if nodeCst(function) != nil && nodeCst(function.Name) != nil {
+					if nodeCst(function) != nil && nodeCst(function.Name) != nil && isVisible(project, file, function) {
 						detail := ""
 
 						if symbols.supportsDetail() {
@@ -127,6 +133,8 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 						})
 					}
 				}
+			} else if !isVisible(project, file, decl) {
+				continue
 			} else if enum, ok := decl.(*ast.Enum); ok && nodeCst(enum) != nil && nodeCst(enum.Name) != nil {
 				// Enum
 				id := symbols.add(symbol{
@@ -202,11 +210,38 @@ func getSymbols(symbols symbolConsumer, files []*workspace.File) {
 					range_:         nodeCst(variable).Range,
 					selectionRange: nodeCst(variable.Name).Range,
 				}, 0)
+			} else if constant, ok := decl.(*ast.Const); ok && nodeCst(constant) != nil && nodeCst(constant.Name) != nil {
+				symbols.add(symbol{
+					file:           file,
+					kind:           protocol.SymbolKindConstant,
+					name:           constant.Name.String(),
+					detail:         printType(constant.Type),
+					range_:         nodeCst(constant).Range,
+					selectionRange: nodeCst(constant.Name).Range,
+				}, 0)
 			}
 		}
 	}
 }
 
+// isVisible returns true if the declaration can be accessed from one of the project's own files.
+func isVisible(project *workspace.Project, file *workspace.File, node ast.Node) bool {
+	decl, ok := node.(ast.Visible)
+
+	// Declarations of project files are accessible at least from the file they are declared in
+	if !ok || project == nil || !file.Builtin || decl.IsPublic() {
+		return true
+	}
+
+	for _, other := range project.Files {
+		if !other.Builtin && other.Ast != nil && ast.IsAccessible(decl, other.Ast) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Document symbols
 
 type documentSymbolConsumer struct {
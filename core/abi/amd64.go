@@ -13,7 +13,7 @@ func (a *amd64) Size(type_ ast.Type) uint32 {
 }
 
 func (a *amd64) Align(type_ ast.Type) uint32 {
-	return getX64Align(type_)
+	return getX64Align(a, type_)
 }
 
 func (a *amd64) Classify(type_ ast.Type, args []Arg) []Arg {
@@ -49,35 +49,25 @@ func (a *amd64) Classify(type_ ast.Type, args []Arg) []Arg {
 		return append(args, ptr)
 
 	case ast.StructType, *ast.Array:
-		if a.Size(type_) > 64 {
-			return append(args, memory)
-		}
-
-		args = a.flatten(type_, getSize(args), args)
-		size := getSize(args)
-
-		if size > 16 {
-			args = args[0:0]
-			return append(args, memory)
-		}
+		return a.classifyAggregate(type_, args)
 
-		for _, arg := range args {
-			if arg.Class == Memory {
-				args = args[0:0]
-				return append(args, memory)
-			}
+	case *ast.Enum:
+		if type_.IsTagged() {
+			return a.classifyAggregate(type_, args)
 		}
 
-		return args
-
-	case *ast.Enum:
 		return a.Classify(type_.ActualType, args)
 
-	case *ast.Interface:
+	case ast.InterfaceType:
 		args = append(args, ptr)
 		return append(args, ptr)
 
+	case *ast.Slice:
+		args = append(args, ptr)
+		return append(args, i64)
+
 	case ast.FuncType:
+		args = append(args, ptr)
 		return append(args, ptr)
 
 	default:
@@ -85,6 +75,29 @@ func (a *amd64) Classify(type_ ast.Type, args []Arg) []Arg {
 	}
 }
 
+func (a *amd64) classifyAggregate(type_ ast.Type, args []Arg) []Arg {
+	if a.Size(type_) > 64 {
+		return append(args, memory)
+	}
+
+	args = a.flatten(type_, getSize(args), args)
+	size := getSize(args)
+
+	if size > 16 {
+		args = args[0:0]
+		return append(args, memory)
+	}
+
+	for _, arg := range args {
+		if arg.Class == Memory {
+			args = args[0:0]
+			return append(args, memory)
+		}
+	}
+
+	return args
+}
+
 func (a *amd64) flatten(type_ ast.Type, baseOffset uint32, args []Arg) []Arg {
 	switch type_ := ast.Resolved(type_).(type) {
 	case *ast.Array:
@@ -101,20 +114,75 @@ func (a *amd64) flatten(type_ ast.Type, baseOffset uint32, args []Arg) []Arg {
 	case ast.StructType:
 		fields, offsets := GetStructLayout(type_.Underlying()).Fields(a, type_)
 
+		if type_.Underlying().IsUnion() {
+			// All fields share the same memory so their classes need to be merged
+			for _, field := range fields {
+				fieldArgs := a.flatten(field.Type(), baseOffset, nil)
+
+				for i, fieldArg := range fieldArgs {
+					var finalArg *Arg
+					args = getArg(args, uint32(i)*8, &finalArg)
+
+					mergeArg(finalArg, fieldArg)
+				}
+			}
+
+			return args
+		}
+
 		for i, field := range fields {
 			offset := baseOffset + offsets[i]
+
+			// Structs with unaligned fields are always passed in memory
+			if align := a.Align(field.Type()); align != 0 && offset%align != 0 {
+				return append(args, memory)
+			}
+
 			args = a.flatten(field.Type(), offset, args)
 		}
 
 		return args
 
-	case *ast.Interface:
+	case ast.InterfaceType, ast.FuncType:
 		void := ast.Primitive{Kind: ast.Void}
 		ptr := ast.Pointer{Pointee: &void}
 
 		args = a.flatten(&ptr, baseOffset, args)
 		return a.flatten(&ptr, baseOffset+8, args)
 
+	case *ast.Slice:
+		ptr := ast.Pointer{Pointee: type_.Base}
+		len_ := ast.Primitive{Kind: ast.U64}
+
+		args = a.flatten(&ptr, baseOffset, args)
+		return a.flatten(&len_, baseOffset+8, args)
+
+	case *ast.Enum:
+		if !type_.IsTagged() {
+			return a.flatten(type_.ActualType, baseOffset, args)
+		}
+
+		// All cases share the same memory so their classes need to be merged
+		layout := GetEnumLayout(a, type_)
+
+		for _, case_ := range type_.Cases {
+			caseArgs := a.flatten(type_.ActualType, baseOffset, nil)
+			fields, offsets := GetEnumCaseFields(a, case_)
+
+			for i, field := range fields {
+				caseArgs = a.flatten(field.Type(), baseOffset+layout.PayloadOffset+offsets[i], caseArgs)
+			}
+
+			for i, caseArg := range caseArgs {
+				var finalArg *Arg
+				args = getArg(args, uint32(i)*8, &finalArg)
+
+				mergeArg(finalArg, caseArg)
+			}
+		}
+
+		return args
+
 	default:
 		typeArgs := a.Classify(type_, nil)
 		if len(typeArgs) != 1 {
@@ -145,3 +213,22 @@ func (a *amd64) flatten(type_ ast.Type, baseOffset uint32, args []Arg) []Arg {
 		return args
 	}
 }
+
+func mergeArg(finalArg *Arg, arg Arg) {
+	if arg.Class == None {
+		return
+	}
+
+	if finalArg.Class == None {
+		*finalArg = arg
+		return
+	}
+
+	if finalArg.Class == Memory || arg.Class == Memory {
+		finalArg.Class = Memory
+	} else if finalArg.Class == Integer || arg.Class == Integer {
+		finalArg.Class = Integer
+	}
+
+	finalArg.Bits = max(finalArg.Bits, arg.Bits)
+}
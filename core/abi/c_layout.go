@@ -9,18 +9,18 @@ var CLayout Layout = &cLayout{}
 type cLayout struct{}
 
 func (c *cLayout) Size(abi Abi, decl ast.StructType) uint32 {
-	layout := cFieldAligner{}
+	layout := newFieldAligner(decl)
 
 	for i := 0; i < decl.FieldCount(); i++ {
-		type_ := decl.FieldIndex(i).Type()
-		layout.add(abi.Size(type_), abi.Align(type_))
+		field := decl.FieldIndex(i)
+		layout.add(abi.Size(field.Type()), getFieldAlign(abi, decl, field))
 	}
 
 	return layout.size()
 }
 
 func (c *cLayout) Fields(abi Abi, decl ast.StructType) ([]ast.FieldLike, []uint32) {
-	layout := cFieldAligner{}
+	layout := newFieldAligner(decl)
 
 	fields := make([]ast.FieldLike, decl.FieldCount())
 	offsets := make([]uint32, len(fields))
@@ -29,7 +29,7 @@ func (c *cLayout) Fields(abi Abi, decl ast.StructType) ([]ast.FieldLike, []uint3
 		field := decl.FieldIndex(i)
 
 		fields[i] = field
-		offsets[i] = layout.add(abi.Size(field.Type()), abi.Align(field.Type()))
+		offsets[i] = layout.add(abi.Size(field.Type()), getFieldAlign(abi, decl, field))
 	}
 
 	return fields, offsets
@@ -40,6 +40,10 @@ type cFieldAligner struct {
 	offset       uint32
 }
 
+func newFieldAligner(decl ast.StructType) cFieldAligner {
+	return cFieldAligner{biggestAlign: getAlignAttribute(decl.Underlying().Attributes)}
+}
+
 func (l *cFieldAligner) add(size, align uint32) uint32 {
 	l.biggestAlign = max(l.biggestAlign, align)
 
@@ -0,0 +1,83 @@
+package abi
+
+import (
+	"fireball/core/ast"
+	"slices"
+)
+
+// EnumLayout describes the memory layout of an enum whose cases carry payload fields. The tag is stored first and is
+// followed by a payload that is big enough to hold the fields of every case.
+type EnumLayout struct {
+	PayloadOffset uint32
+	PayloadSize   uint32
+	PayloadAlign  uint32
+
+	Size  uint32
+	Align uint32
+}
+
+func GetEnumLayout(abi Abi, decl *ast.Enum) EnumLayout {
+	payloadSize := uint32(0)
+	payloadAlign := uint32(1)
+
+	for _, case_ := range decl.Cases {
+		layout := cFieldAligner{}
+
+		for _, field := range getEnumCaseFields(abi, decl, case_) {
+			layout.add(abi.Size(field.Type()), abi.Align(field.Type()))
+		}
+
+		payloadSize = max(payloadSize, layout.size())
+		payloadAlign = max(payloadAlign, layout.biggestAlign)
+	}
+
+	payloadOffset := alignBytes(abi.Size(decl.ActualType), payloadAlign)
+	align := max(abi.Align(decl.ActualType), payloadAlign)
+
+	return EnumLayout{
+		PayloadOffset: payloadOffset,
+		PayloadSize:   payloadSize,
+		PayloadAlign:  payloadAlign,
+
+		Size:  alignBytes(payloadOffset+payloadSize, align),
+		Align: align,
+	}
+}
+
+// GetEnumCaseFields returns the payload fields of an enum case in memory order together with their offsets relative to
+// the start of the payload.
+func GetEnumCaseFields(abi Abi, case_ *ast.EnumCase) ([]*ast.Field, []uint32) {
+	fields := getEnumCaseFields(abi, case_.Parent().(*ast.Enum), case_)
+
+	layout := cFieldAligner{}
+	offsets := make([]uint32, len(fields))
+
+	for i, field := range fields {
+		offsets[i] = layout.add(abi.Size(field.Type()), abi.Align(field.Type()))
+	}
+
+	return fields, offsets
+}
+
+func getEnumCaseFields(abi Abi, decl *ast.Enum, case_ *ast.EnumCase) []*ast.Field {
+	if decl.IsC() {
+		return case_.Fields
+	}
+
+	fields := slices.Clone(case_.Fields)
+
+	slices.SortStableFunc(fields, func(f1, f2 *ast.Field) int {
+		a1 := abi.Align(f1.Type())
+		a2 := abi.Align(f2.Type())
+
+		if a1 < a2 {
+			return +1
+		}
+		if a1 > a2 {
+			return -1
+		}
+		return 0
+	})
+
+	return fields
+}
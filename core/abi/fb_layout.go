@@ -12,10 +12,10 @@ type fbLayout struct{}
 func (f *fbLayout) Size(abi Abi, decl ast.StructType) uint32 {
 	fields := f.sorted(abi, decl)
 
-	layout := cFieldAligner{}
+	layout := newFieldAligner(decl)
 
 	for _, field := range fields {
-		layout.add(abi.Size(field.Type()), abi.Align(field.Type()))
+		layout.add(abi.Size(field.Type()), getFieldAlign(abi, decl, field))
 	}
 
 	return layout.size()
@@ -24,11 +24,11 @@ func (f *fbLayout) Size(abi Abi, decl ast.StructType) uint32 {
 func (f *fbLayout) Fields(abi Abi, decl ast.StructType) ([]ast.FieldLike, []uint32) {
 	fields := f.sorted(abi, decl)
 
-	layout := cFieldAligner{}
+	layout := newFieldAligner(decl)
 	offsets := make([]uint32, len(fields))
 
 	for i, field := range fields {
-		offsets[i] = layout.add(abi.Size(field.Type()), abi.Align(field.Type()))
+		offsets[i] = layout.add(abi.Size(field.Type()), getFieldAlign(abi, decl, field))
 	}
 
 	return fields, offsets
@@ -42,8 +42,8 @@ func (f *fbLayout) sorted(abi Abi, decl ast.StructType) []ast.FieldLike {
 	}
 
 	slices.SortStableFunc(fields, func(f1, f2 ast.FieldLike) int {
-		a1 := abi.Align(f1.Type())
-		a2 := abi.Align(f2.Type())
+		a1 := getFieldAlign(abi, decl, f1)
+		a2 := getFieldAlign(abi, decl, f2)
 
 		if a1 < a2 {
 			return +1
@@ -9,11 +9,44 @@ type Layout interface {
 }
 
 func GetStructLayout(s *ast.Struct) Layout {
-	for _, attribute := range s.Attributes {
-		if attribute.Name.String() == "C" {
-			return CLayout
-		}
+	if s.IsUnion() {
+		return UnionLayout
+	}
+
+	if s.IsC() {
+		return CLayout
 	}
 
 	return FbLayout
 }
+
+// getFieldAlign returns the alignment of a field inside its struct after applying Packed and Align attributes
+func getFieldAlign(abi Abi, decl ast.StructType, field ast.FieldLike) uint32 {
+	align := abi.Align(field.Type())
+
+	if ast.GetAttribute(decl.Underlying().Attributes, "Packed") != nil || ast.GetAttribute(field.Underlying().Attributes, "Packed") != nil {
+		align = 1
+	}
+
+	return max(align, getAlignAttribute(field.Underlying().Attributes))
+}
+
+func getStructAlign(abi Abi, decl ast.StructType) uint32 {
+	align := getAlignAttribute(decl.Underlying().Attributes)
+
+	for i := 0; i < decl.FieldCount(); i++ {
+		align = max(align, getFieldAlign(abi, decl, decl.FieldIndex(i)))
+	}
+
+	return align
+}
+
+func getAlignAttribute(attributes []*ast.Attribute) uint32 {
+	if attribute := ast.GetAttribute(attributes, "Align"); attribute != nil {
+		if align, ok := attribute.NumberArg(0); ok {
+			return align
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,34 @@
+package abi
+
+import (
+	"fireball/core/ast"
+)
+
+var UnionLayout Layout = &unionLayout{}
+
+type unionLayout struct{}
+
+func (u *unionLayout) Size(abi Abi, decl ast.StructType) uint32 {
+	size := uint32(0)
+
+	for i := 0; i < decl.FieldCount(); i++ {
+		size = max(size, abi.Size(decl.FieldIndex(i).Type()))
+	}
+
+	if size == 0 {
+		return 0
+	}
+
+	return alignBytes(size, getStructAlign(abi, decl))
+}
+
+func (u *unionLayout) Fields(abi Abi, decl ast.StructType) ([]ast.FieldLike, []uint32) {
+	fields := make([]ast.FieldLike, decl.FieldCount())
+
+	// All fields of a union share the same memory
+	for i := 0; i < decl.FieldCount(); i++ {
+		fields[i] = decl.FieldIndex(i)
+	}
+
+	return fields, make([]uint32, len(fields))
+}
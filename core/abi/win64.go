@@ -13,7 +13,7 @@ func (w *win64) Size(type_ ast.Type) uint32 {
 }
 
 func (w *win64) Align(type_ ast.Type) uint32 {
-	return getX64Align(type_)
+	return getX64Align(w, type_)
 }
 
 func (w *win64) Classify(type_ ast.Type, args []Arg) []Arg {
@@ -45,31 +45,39 @@ func (w *win64) Classify(type_ ast.Type, args []Arg) []Arg {
 
 		return append(args, arg)
 
-	case *ast.Pointer, ast.FuncType:
+	case *ast.Pointer:
 		return append(args, ptr)
 
-	case *ast.Array, ast.StructType, *ast.Interface:
-		var arg Arg
+	case *ast.Array, *ast.Slice, ast.StructType, ast.InterfaceType, ast.FuncType:
+		return w.classifyAggregate(type_, args)
 
-		switch w.Size(type_) {
-		case 1:
-			arg = i8
-		case 2:
-			arg = i16
-		case 4:
-			arg = i32
-		case 8:
-			arg = i64
-		default:
-			arg = memory
+	case *ast.Enum:
+		if type_.IsTagged() {
+			return w.classifyAggregate(type_, args)
 		}
 
-		return append(args, arg)
-
-	case *ast.Enum:
 		return w.Classify(type_.ActualType, args)
 
 	default:
 		return args
 	}
 }
+
+func (w *win64) classifyAggregate(type_ ast.Type, args []Arg) []Arg {
+	var arg Arg
+
+	switch w.Size(type_) {
+	case 1:
+		arg = i8
+	case 2:
+		arg = i16
+	case 4:
+		arg = i32
+	case 8:
+		arg = i64
+	default:
+		arg = memory
+	}
+
+	return append(args, arg)
+}
@@ -13,24 +13,31 @@ func getX64Size(abi Abi, type_ ast.Type) uint32 {
 	case *ast.Array:
 		return abi.Size(type_.Base) * type_.Count
 
+	case *ast.Slice:
+		return 8 * 2
+
 	case ast.StructType:
 		return GetStructLayout(type_.Underlying()).Size(abi, type_)
 
 	case *ast.Enum:
+		if type_.IsTagged() {
+			return GetEnumLayout(abi, type_).Size
+		}
+
 		return abi.Size(type_.ActualType)
 
-	case *ast.Interface:
+	case ast.InterfaceType:
 		return 8 * 2
 
 	case ast.FuncType:
-		return 8
+		return 8 * 2
 
 	default:
 		panic("abi.getX64Size() - Not implemented")
 	}
 }
 
-func getX64Align(type_ ast.Type) uint32 {
+func getX64Align(abi Abi, type_ ast.Type) uint32 {
 	switch type_ := ast.Resolved(type_).(type) {
 	case *ast.Primitive:
 		return getX64PrimitiveSize(type_.Kind)
@@ -39,21 +46,26 @@ func getX64Align(type_ ast.Type) uint32 {
 		return 8
 
 	case *ast.Array:
-		return getX64Align(type_.Base)
+		return abi.Align(type_.Base)
+
+	case *ast.Slice:
+		return 8
 
 	case ast.StructType:
-		maxAlign := uint32(0)
+		return getStructAlign(abi, type_)
+
+	case *ast.Enum:
+		maxAlign := abi.Align(type_.ActualType)
 
-		for i := 0; i < type_.FieldCount(); i++ {
-			maxAlign = max(maxAlign, getX64Align(type_.FieldIndex(i).Type()))
+		for _, case_ := range type_.Cases {
+			for _, field := range case_.Fields {
+				maxAlign = max(maxAlign, abi.Align(field.Type()))
+			}
 		}
 
 		return maxAlign
 
-	case *ast.Enum:
-		return getX64Align(type_.ActualType)
-
-	case *ast.Interface:
+	case ast.InterfaceType:
 		return 8
 
 	case ast.FuncType:
@@ -0,0 +1,74 @@
+package ast
+
+import (
+	"strconv"
+)
+
+// Constant
+
+type ConstantKind uint8
+
+const (
+	InvalidConstant ConstantKind = iota
+
+	IntConstant
+	FloatConstant
+	BoolConstant
+)
+
+// Constant is the value of an expression evaluated at compile time. Booleans are stored in Int as 0 or 1.
+type Constant struct {
+	Kind ConstantKind
+
+	Int   int64
+	Float float64
+}
+
+func IntValue(value int64) Constant {
+	return Constant{Kind: IntConstant, Int: value}
+}
+
+func FloatValue(value float64) Constant {
+	return Constant{Kind: FloatConstant, Float: value}
+}
+
+func BoolValue(value bool) Constant {
+	if value {
+		return Constant{Kind: BoolConstant, Int: 1}
+	}
+
+	return Constant{Kind: BoolConstant}
+}
+
+func (c Constant) IsValid() bool {
+	return c.Kind != InvalidConstant
+}
+
+func (c Constant) Bool() bool {
+	return c.Int != 0
+}
+
+func (c Constant) String() string {
+	switch c.Kind {
+	case IntConstant:
+		return strconv.FormatInt(c.Int, 10)
+	case FloatConstant:
+		return strconv.FormatFloat(c.Float, 'g', -1, 64)
+	case BoolConstant:
+		return strconv.FormatBool(c.Bool())
+
+	default:
+		return "<invalid>"
+	}
+}
+
+// Evaluation state
+
+// EvalState tracks the evaluation of nodes whose values are computed at compile time so that cycles can be detected.
+type EvalState uint8
+
+const (
+	NotEvaluated EvalState = iota
+	Evaluating
+	Evaluated
+)
@@ -33,6 +33,12 @@ func (c *converter) convertDecl(node cst.Node) ast.Decl {
 		return nil
 	case cst.VarDeclNode:
 		return c.convertVarDecl(node)
+	case cst.ConstDeclNode:
+		if constant := c.convertConstDecl(node); constant != nil {
+			return constant
+		}
+
+		return nil
 
 	default:
 		panic("cst2ast.convertDecl() - Not implemented")
@@ -49,6 +55,8 @@ func (c *converter) convertNamespaceDecl(node cst.Node) *ast.Namespace {
 			name = c.convertNamespaceName(child)
 		} else if child.Kind == cst.AttributesNode {
 			c.error(child.Children[0], "Namespaces cannot have attributes")
+		} else if child.Token.Kind == scanner.Pub {
+			c.error(child, "Namespaces cannot be public")
 		}
 	}
 
@@ -63,6 +71,8 @@ func (c *converter) convertUsingDecl(node cst.Node) ast.Decl {
 			name = c.convertNamespaceName(child)
 		} else if child.Kind == cst.AttributesNode {
 			c.error(child.Children[0], "Using cannot have attributes")
+		} else if child.Token.Kind == scanner.Pub {
+			c.error(child, "Using cannot be public")
 		}
 	}
 
@@ -97,6 +107,7 @@ func (c *converter) convertStructDecl(node cst.Node) ast.Decl {
 	var genericParams []*ast.Generic
 	var fields []*ast.Field
 	var staticFields []*ast.Field
+	var constants []*ast.Const
 
 	for _, child := range node.Children {
 		if child.Kind == cst.TokenNode {
@@ -117,12 +128,18 @@ func (c *converter) convertStructDecl(node cst.Node) ast.Decl {
 					fields = append(fields, field)
 				}
 			}
+		} else if child.Kind == cst.ConstDeclNode {
+			constant := c.convertConstDecl(child)
+
+			if constant != nil {
+				constants = append(constants, constant)
+			}
 		} else if child.Kind == cst.AttributesNode {
 			attributes = c.convertAttributes(child)
 		}
 	}
 
-	if s := ast.NewStruct(node, attributes, name, genericParams, fields, staticFields); s != nil {
+	if s := ast.NewStruct(node, attributes, name, genericParams, fields, staticFields, constants); s != nil {
 		return s
 	}
 
@@ -130,8 +147,10 @@ func (c *converter) convertStructDecl(node cst.Node) ast.Decl {
 }
 
 func (c *converter) convertStructField(node cst.Node) (*ast.Field, bool) {
+	var attributes []*ast.Attribute
 	var name *ast.Token
 	var type_ ast.Type
+	var value ast.Expr
 
 	static := false
 
@@ -142,10 +161,18 @@ func (c *converter) convertStructField(node cst.Node) (*ast.Field, bool) {
 			name = c.convertToken(child)
 		} else if child.Kind.IsType() {
 			type_ = c.convertType(child)
+		} else if child.Kind.IsExpr() {
+			if static {
+				value = c.convertExpr(child)
+			} else {
+				c.error(child, "Only static fields can have an initializer")
+			}
+		} else if child.Kind == cst.AttributesNode {
+			attributes = c.convertAttributes(child)
 		}
 	}
 
-	return ast.NewField(node, name, type_), static
+	return ast.NewField(node, attributes, name, type_, value), static
 }
 
 // Impl
@@ -168,6 +195,8 @@ func (c *converter) convertImplDecl(node cst.Node) ast.Decl {
 			}
 		} else if child.Kind == cst.AttributesNode {
 			c.error(child.Children[0], "Implementations cannot have attributes")
+		} else if child.Token.Kind == scanner.Pub {
+			c.error(child, "Implementations cannot be public")
 		}
 	}
 
@@ -181,6 +210,7 @@ func (c *converter) convertImplDecl(node cst.Node) ast.Decl {
 // Enum
 
 func (c *converter) convertEnumDecl(node cst.Node) ast.Decl {
+	var attributes []*ast.Attribute
 	var name *ast.Token
 	var type_ ast.Type
 	var cases []*ast.EnumCase
@@ -197,11 +227,11 @@ func (c *converter) convertEnumDecl(node cst.Node) ast.Decl {
 				cases = append(cases, case_)
 			}
 		} else if child.Kind == cst.AttributesNode {
-			c.error(child.Children[0], "Enums cannot have attributes")
+			attributes = c.convertAttributes(child)
 		}
 	}
 
-	if e := ast.NewEnum(node, name, type_, cases); e != nil {
+	if e := ast.NewEnum(node, attributes, name, type_, cases); e != nil {
 		return e
 	}
 
@@ -210,28 +240,62 @@ func (c *converter) convertEnumDecl(node cst.Node) ast.Decl {
 
 func (c *converter) convertEnumCase(node cst.Node) *ast.EnumCase {
 	var name *ast.Token
-	var value *ast.Token
+	var value ast.Expr
+	var fields []*ast.Field
+
+	for _, child := range node.Children {
+		if child.Kind == cst.TokenNode {
+			name = c.convertToken(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
+		} else if child.Kind == cst.EnumCaseFieldNode {
+			field := c.convertEnumCaseField(child)
+
+			if field != nil {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return ast.NewEnumCase(node, name, value, fields)
+}
+
+func (c *converter) convertEnumCaseField(node cst.Node) *ast.Field {
+	var name *ast.Token
+	var type_ ast.Type
 
 	for _, child := range node.Children {
 		if child.Kind == cst.TokenNode {
 			name = c.convertToken(child)
-		} else if child.Kind == cst.NumberExprNode {
-			value = c.convertToken(child)
+		} else if child.Kind.IsType() {
+			type_ = c.convertType(child)
 		}
 	}
 
-	return ast.NewEnumCase(node, name, value)
+	return ast.NewField(node, nil, name, type_, nil)
 }
 
 // Interface
 
 func (c *converter) convertInterfaceDecl(node cst.Node) ast.Decl {
 	var name *ast.Token
+	var genericParams []*ast.Generic
+	var inherits []ast.Type
 	var methods []*ast.Func
 
 	for _, child := range node.Children {
 		if child.Kind == cst.TokenNode {
 			name = c.convertToken(child)
+		} else if child.Kind == cst.GenericParamNode {
+			param := c.convertGenericParam(child)
+
+			if param != nil {
+				genericParams = append(genericParams, param)
+			}
+		} else if child.Kind.IsType() {
+			if type_ := c.convertType(child); type_ != nil {
+				inherits = append(inherits, type_)
+			}
 		} else if child.Kind == cst.FuncDeclNode {
 			method := c.convertFuncDecl(child)
 
@@ -241,7 +305,7 @@ func (c *converter) convertInterfaceDecl(node cst.Node) ast.Decl {
 		}
 	}
 
-	if i := ast.NewInterface(node, name, methods); i != nil {
+	if i := ast.NewInterface(node, name, genericParams, inherits, methods); i != nil {
 		return i
 	}
 
@@ -307,19 +371,23 @@ func (c *converter) convertFuncDecl(node cst.Node) *ast.Func {
 
 func (c *converter) convertGenericParam(node cst.Node) *ast.Generic {
 	var name scanner.Token
+	var bound ast.Type
 
 	for _, child := range node.Children {
-		if child.Kind == cst.TokenNode {
+		if child.Kind == cst.TokenNode && child.Token.Kind == scanner.Identifier {
 			name = child.Token
+		} else if child.Kind.IsType() {
+			bound = c.convertType(child)
 		}
 	}
 
-	return ast.NewGeneric(node, name)
+	return ast.NewGeneric(node, name, bound)
 }
 
 func (c *converter) convertFuncParam(node cst.Node) (*ast.Param, bool) {
 	var name *ast.Token
 	var type_ ast.Type
+	var value ast.Expr
 
 	varArgs := false
 
@@ -328,12 +396,14 @@ func (c *converter) convertFuncParam(node cst.Node) (*ast.Param, bool) {
 			name = c.convertToken(child)
 		} else if child.Kind.IsType() {
 			type_ = c.convertType(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
 		} else if child.Token.Kind == scanner.DotDotDot {
 			varArgs = true
 		}
 	}
 
-	if p := ast.NewParam(node, name, type_); p != nil {
+	if p := ast.NewParam(node, name, type_, value); p != nil {
 		return p, varArgs
 	}
 
@@ -343,26 +413,52 @@ func (c *converter) convertFuncParam(node cst.Node) (*ast.Param, bool) {
 // Var
 
 func (c *converter) convertVarDecl(node cst.Node) ast.Decl {
+	var attributes []*ast.Attribute
 	var name *ast.Token
 	var type_ ast.Type
+	var value ast.Expr
 
 	for _, child := range node.Children {
 		if child.Kind == cst.TokenNode {
 			name = c.convertToken(child)
 		} else if child.Kind.IsType() {
 			type_ = c.convertType(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
 		} else if child.Kind == cst.AttributesNode {
-			c.error(child.Children[0], "Global variables cannot have attributes")
+			attributes = c.convertAttributes(child)
 		}
 	}
 
-	if v := ast.NewGlobalVar(node, name, type_); v != nil {
+	if v := ast.NewGlobalVar(node, attributes, name, type_, value); v != nil {
 		return v
 	}
 
 	return nil
 }
 
+// Const
+
+func (c *converter) convertConstDecl(node cst.Node) *ast.Const {
+	var name *ast.Token
+	var type_ ast.Type
+	var value ast.Expr
+
+	for _, child := range node.Children {
+		if child.Kind == cst.TokenNode {
+			name = c.convertToken(child)
+		} else if child.Kind.IsType() {
+			type_ = c.convertType(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
+		} else if child.Kind == cst.AttributesNode {
+			c.error(child.Children[0], "Constants cannot have attributes")
+		}
+	}
+
+	return ast.NewConst(node, name, type_, value)
+}
+
 // Attributes
 
 func (c *converter) convertAttributes(node cst.Node) []*ast.Attribute {
@@ -388,7 +484,7 @@ func (c *converter) convertAttribute(node cst.Node) *ast.Attribute {
 	for _, child := range node.Children {
 		if child.Kind == cst.TokenNode {
 			name = c.convertToken(child)
-		} else if child.Kind == cst.StringExprNode {
+		} else if child.Kind == cst.StringExprNode || child.Kind == cst.NumberExprNode {
 			arg := c.convertToken(child)
 
 			if arg != nil {
@@ -18,16 +18,22 @@ func (c *converter) convertExpr(node cst.Node) ast.Expr {
 		return c.convertIndexExpr(node)
 	case cst.CallExprNode:
 		return c.convertCallExpr(node)
+	case cst.NamedArgExprNode:
+		return c.convertNamedArgExpr(node)
 	case cst.TypeCallExprNode:
 		return c.convertTypeCallExpr(node)
 	case cst.TypeofExprNode:
 		return c.convertTypeofExpr(node)
+	case cst.AsmExprNode:
+		return c.convertAsmExpr(node)
 	case cst.StructExprNode:
 		return c.convertStructExpr(node)
 	case cst.ArrayExprNode:
 		return c.convertArrayExpr(node)
 	case cst.AllocateArrayExprNode:
 		return c.convertAllocateArrayExpr(node)
+	case cst.ClosureExprNode:
+		return c.convertClosureExpr(node)
 	case cst.IdentifierExprNode:
 		return c.convertIdentifierExpr(node)
 	case cst.NilExprNode, cst.BoolExprNode, cst.NumberExprNode, cst.CharacterExprNode, cst.StringExprNode:
@@ -145,15 +151,30 @@ func (c *converter) convertMemberExpr(node cst.Node) ast.Expr {
 func (c *converter) convertIndexExpr(node cst.Node) ast.Expr {
 	var value ast.Expr
 	var index ast.Expr
+	var high ast.Expr
+
+	slicing := false
 
 	for _, child := range node.Children {
 		if child.Kind.IsExpr() {
 			if value == nil {
 				value = c.convertExpr(child)
+			} else if slicing {
+				high = c.convertExpr(child)
 			} else {
 				index = c.convertExpr(child)
 			}
+		} else if child.Token.Kind == scanner.Colon {
+			slicing = true
+		}
+	}
+
+	if slicing {
+		if s := ast.NewSlicing(node, value, index, high); s != nil {
+			return s
 		}
+
+		return nil
 	}
 
 	if i := ast.NewIndex(node, value, index); i != nil {
@@ -210,6 +231,25 @@ func (c *converter) convertCallExpr(node cst.Node) ast.Expr {
 	return nil
 }
 
+func (c *converter) convertNamedArgExpr(node cst.Node) ast.Expr {
+	var name *ast.Token
+	var value ast.Expr
+
+	for _, child := range node.Children {
+		if child.Kind == cst.TokenNode {
+			name = c.convertToken(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
+		}
+	}
+
+	if n := ast.NewNamedArg(node, name, value); n != nil {
+		return n
+	}
+
+	return nil
+}
+
 func (c *converter) convertTypeCallExpr(node cst.Node) ast.Expr {
 	var callee *ast.Token
 	var arg ast.Type
@@ -234,8 +274,8 @@ func (c *converter) convertTypeofExpr(node cst.Node) ast.Expr {
 	var arg ast.Expr
 
 	for _, child := range node.Children {
-		if child.Kind == cst.TokenNode {
-			callee = c.convertToken(child)
+		if callee == nil && child.Kind == cst.IdentifierExprNode {
+			callee = c.convertToken(child.Children[0])
 		} else if child.Kind.IsExpr() {
 			arg = c.convertExpr(child)
 		}
@@ -248,6 +288,58 @@ func (c *converter) convertTypeofExpr(node cst.Node) ast.Expr {
 	return nil
 }
 
+func (c *converter) convertAsmExpr(node cst.Node) ast.Expr {
+	var template *ast.Token
+	var outputs []*ast.AsmOperand
+	var inputs []*ast.AsmOperand
+	var clobbers []*ast.Token
+
+	section := 0
+
+	for _, child := range node.Children {
+		if child.Token.Kind == scanner.Colon {
+			section++
+		} else if child.Kind == cst.AsmOperandNode {
+			if section == 1 {
+				outputs = append(outputs, c.convertAsmOperand(child))
+			} else {
+				inputs = append(inputs, c.convertAsmOperand(child))
+			}
+		} else if child.Kind == cst.StringExprNode {
+			if section == 0 {
+				template = c.convertToken(child)
+			} else {
+				clobbers = append(clobbers, c.convertToken(child))
+			}
+		}
+	}
+
+	if a := ast.NewAsm(node, template, outputs, inputs, clobbers); a != nil {
+		return a
+	}
+
+	return nil
+}
+
+func (c *converter) convertAsmOperand(node cst.Node) *ast.AsmOperand {
+	var constraint *ast.Token
+	var value ast.Expr
+
+	for _, child := range node.Children {
+		if child.Kind == cst.StringExprNode && constraint == nil {
+			constraint = c.convertToken(child)
+		} else if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
+		}
+	}
+
+	if a := ast.NewAsmOperand(node, constraint, value); a != nil {
+		return a
+	}
+
+	return nil
+}
+
 func (c *converter) convertStructExpr(node cst.Node) ast.Expr {
 	new_ := false
 	var type_ ast.Type
@@ -324,6 +416,66 @@ func (c *converter) convertAllocateArrayExpr(node cst.Node) ast.Expr {
 	return nil
 }
 
+func (c *converter) convertClosureExpr(node cst.Node) ast.Expr {
+	var captures []*ast.Capture
+	var params []*ast.Param
+	var returns ast.Type
+	var body []ast.Stmt
+
+	for _, child := range node.Children {
+		if child.Kind == cst.ClosureCaptureNode {
+			capture := c.convertClosureCapture(child)
+
+			if capture != nil {
+				captures = append(captures, capture)
+			}
+		} else if child.Kind == cst.FuncParamNode {
+			param, varArgs := c.convertFuncParam(child)
+
+			if varArgs {
+				c.error(child, "Closures can't be variadic")
+			} else if param != nil {
+				params = append(params, param)
+			}
+		} else if child.Kind.IsType() {
+			returns = c.convertType(child)
+		} else if child.Kind.IsStmt() {
+			stmt := c.convertStmt(child)
+
+			if stmt != nil {
+				body = append(body, stmt)
+			}
+		}
+	}
+
+	if returns == nil {
+		returns = ast.NewPrimitive(cst.Node{}, ast.Void, scanner.Token{})
+	}
+
+	function := ast.NewFunc(node, nil, 0, nil, nil, params, returns, body)
+
+	if closure := ast.NewClosure(node, captures, function); closure != nil {
+		return closure
+	}
+
+	return nil
+}
+
+func (c *converter) convertClosureCapture(node cst.Node) *ast.Capture {
+	reference := false
+	var name *ast.Token
+
+	for _, child := range node.Children {
+		if child.Token.Kind == scanner.Ampersand {
+			reference = true
+		} else if child.Kind == cst.TokenNode {
+			name = c.convertToken(child)
+		}
+	}
+
+	return ast.NewCapture(node, reference, name)
+}
+
 func (c *converter) convertIdentifierExpr(node cst.Node) ast.Expr {
 	var name *ast.Token
 	var genericArgs []ast.Type
@@ -20,6 +20,10 @@ func (c *converter) convertStmt(node cst.Node) ast.Stmt {
 		return c.convertWhileStmt(node)
 	case cst.ForStmtNode:
 		return c.convertForStmt(node)
+	case cst.MatchStmtNode:
+		return c.convertMatchStmt(node)
+	case cst.DeferStmtNode:
+		return c.convertDeferStmt(node)
 	case cst.ReturnStmtNode:
 		return c.convertReturnStmt(node)
 	case cst.BreakStmtNode:
@@ -122,7 +126,9 @@ func (c *converter) convertWhileStmt(node cst.Node) ast.Stmt {
 	var condition ast.Expr
 	var body ast.Stmt
 
-	for _, child := range node.Children {
+	label, children := c.convertLabel(node)
+
+	for _, child := range children {
 		if child.Kind.IsExpr() {
 			condition = c.convertExpr(child)
 		} else if child.Kind.IsStmt() {
@@ -130,7 +136,7 @@ func (c *converter) convertWhileStmt(node cst.Node) ast.Stmt {
 		}
 	}
 
-	if w := ast.NewWhile(node, condition, body); w != nil {
+	if w := ast.NewWhile(node, label, condition, body); w != nil {
 		return w
 	}
 
@@ -138,14 +144,21 @@ func (c *converter) convertWhileStmt(node cst.Node) ast.Stmt {
 }
 
 func (c *converter) convertForStmt(node cst.Node) ast.Stmt {
+	for _, child := range node.Children {
+		if child.Token.Kind == scanner.In {
+			return c.convertForInStmt(node)
+		}
+	}
+
 	var initializer ast.Stmt
 	var condition ast.Expr
 	var increment ast.Expr
 	var body ast.Stmt
 
+	label, children := c.convertLabel(node)
 	semicolons := 0
 
-	for _, child := range node.Children {
+	for _, child := range children {
 		if child.Kind.IsStmt() {
 			if semicolons == 0 {
 				initializer = c.convertStmt(child)
@@ -164,13 +177,129 @@ func (c *converter) convertForStmt(node cst.Node) ast.Stmt {
 		}
 	}
 
-	if f := ast.NewFor(node, initializer, condition, increment, body); f != nil {
+	if f := ast.NewFor(node, label, initializer, condition, increment, body); f != nil {
 		return f
 	}
 
 	return nil
 }
 
+func (c *converter) convertForInStmt(node cst.Node) ast.Stmt {
+	var name *ast.Token
+	var iterable ast.Expr
+	var body ast.Stmt
+
+	label, children := c.convertLabel(node)
+
+	for _, child := range children {
+		if child.Kind == cst.TokenNode {
+			name = c.convertToken(child)
+		} else if child.Kind.IsExpr() {
+			iterable = c.convertExpr(child)
+		} else if child.Kind.IsStmt() {
+			body = c.convertStmt(child)
+		}
+	}
+
+	if f := ast.NewForIn(node, label, name, iterable, body); f != nil {
+		return f
+	}
+
+	return nil
+}
+
+// convertLabel returns the label of a loop statement together with the remaining children of the node.
+func (c *converter) convertLabel(node cst.Node) (*ast.Token, []cst.Node) {
+	if len(node.Children) >= 2 && node.Children[0].Kind == cst.TokenNode && node.Children[1].Token.Kind == scanner.Colon {
+		return c.convertToken(node.Children[0]), node.Children[2:]
+	}
+
+	return nil, node.Children
+}
+
+func (c *converter) convertMatchStmt(node cst.Node) ast.Stmt {
+	var value ast.Expr
+	var arms []*ast.MatchArm
+
+	for _, child := range node.Children {
+		if child.Kind.IsExpr() {
+			value = c.convertExpr(child)
+		} else if child.Kind == cst.MatchArmNode {
+			arm := c.convertMatchArm(child)
+
+			if arm != nil {
+				arms = append(arms, arm)
+			}
+		}
+	}
+
+	if m := ast.NewMatch(node, value, arms); m != nil {
+		return m
+	}
+
+	return nil
+}
+
+func (c *converter) convertMatchArm(node cst.Node) *ast.MatchArm {
+	default_ := false
+	var values []ast.Expr
+	var bindings []*ast.Token
+	var body ast.Stmt
+
+	for _, child := range node.Children {
+		if child.Token.Kind == scanner.Else {
+			default_ = true
+		} else if child.Kind == cst.CallExprNode {
+			// Enum case with payload bindings, eg. 'Shape.Circle(radius)'
+			var value ast.Expr
+
+			for _, callChild := range child.Children {
+				if !callChild.Kind.IsExpr() {
+					continue
+				}
+
+				if value == nil {
+					value = c.convertExpr(callChild)
+				} else if callChild.Kind == cst.IdentifierExprNode && len(callChild.Children) == 1 {
+					bindings = append(bindings, c.convertToken(callChild.Children[0]))
+				} else {
+					c.error(callChild, "Expected a binding name")
+				}
+			}
+
+			if value != nil {
+				values = append(values, value)
+			}
+		} else if child.Kind.IsExpr() {
+			value := c.convertExpr(child)
+
+			if value != nil {
+				values = append(values, value)
+			}
+		} else if child.Kind.IsStmt() {
+			body = c.convertStmt(child)
+		}
+	}
+
+	return ast.NewMatchArm(node, default_, values, bindings, body)
+}
+
+func (c *converter) convertDeferStmt(node cst.Node) ast.Stmt {
+	var stmt ast.Stmt
+
+	for _, child := range node.Children {
+		if child.Kind.IsStmt() {
+			stmt = c.convertStmt(child)
+		}
+	}
+
+	if d := ast.NewDefer(node, stmt); d != nil {
+		return d
+	}
+
+	return nil
+}
+
 func (c *converter) convertReturnStmt(node cst.Node) ast.Stmt {
 	var value ast.Expr
 
@@ -188,7 +317,15 @@ func (c *converter) convertReturnStmt(node cst.Node) ast.Stmt {
 }
 
 func (c *converter) convertBreakStmt(node cst.Node) ast.Stmt {
-	if b := ast.NewBreak(node); b != nil {
+	var label *ast.Token
+
+	for _, child := range node.Children {
+		if child.Kind == cst.TokenNode {
+			label = c.convertToken(child)
+		}
+	}
+
+	if b := ast.NewBreak(node, label); b != nil {
 		return b
 	}
 
@@ -196,7 +333,15 @@ func (c *converter) convertBreakStmt(node cst.Node) ast.Stmt {
 }
 
 func (c *converter) convertContinueStmt(node cst.Node) ast.Stmt {
-	if c := ast.NewContinue(node); c != nil {
+	var label *ast.Token
+
+	for _, child := range node.Children {
+		if child.Kind == cst.TokenNode {
+			label = c.convertToken(child)
+		}
+	}
+
+	if c := ast.NewContinue(node, label); c != nil {
 		return c
 	}
 
@@ -4,7 +4,6 @@ import (
 	"fireball/core/ast"
 	"fireball/core/cst"
 	"fireball/core/scanner"
-	"strconv"
 )
 
 func (c *converter) convertType(node cst.Node) ast.Type {
@@ -15,6 +14,8 @@ func (c *converter) convertType(node cst.Node) ast.Type {
 		return c.convertPointerType(node)
 	case cst.ArrayTypeNode:
 		return c.convertArrayType(node)
+	case cst.SliceTypeNode:
+		return c.convertSliceType(node)
 	case cst.FuncTypeNode:
 		return c.convertFuncType(node)
 
@@ -108,24 +109,39 @@ func (c *converter) convertPointerType(node cst.Node) ast.Type {
 
 func (c *converter) convertArrayType(node cst.Node) ast.Type {
 	var base ast.Type
-	count := uint32(0)
+	var size ast.Expr
 
 	for _, child := range node.Children {
 		if child.Kind.IsType() {
 			base = c.convertType(child)
-		} else if child.Kind == cst.NumberExprNode {
-			c, _ := strconv.ParseUint(child.Token.Lexeme, 10, 32)
-			count = uint32(c)
+		} else if child.Kind.IsExpr() {
+			size = c.convertExpr(child)
 		}
 	}
 
-	if a := ast.NewArray(node, base, count); a != nil {
+	if a := ast.NewArray(node, base, size); a != nil {
 		return a
 	}
 
 	return nil
 }
 
+func (c *converter) convertSliceType(node cst.Node) ast.Type {
+	var base ast.Type
+
+	for _, child := range node.Children {
+		if child.Kind.IsType() {
+			base = c.convertType(child)
+		}
+	}
+
+	if s := ast.NewSlice(node, base); s != nil {
+		return s
+	}
+
+	return nil
+}
+
 func (c *converter) convertFuncType(node cst.Node) ast.Type {
 	var flags ast.FuncFlags
 	var params []*ast.Param
@@ -16,6 +16,7 @@ type DeclVisitor interface {
 	VisitInterface(decl *Interface)
 	VisitFunc(decl *Func)
 	VisitGlobalVar(decl *GlobalVar)
+	VisitConst(decl *Const)
 }
 
 type Decl interface {
@@ -189,12 +190,13 @@ type Struct struct {
 	GenericParams   []*Generic
 	Fields          []*Field
 	StaticFields    []*Field
+	Constants       []*Const
 	Specializations []*SpecializedStruct
 	Type            Type
 }
 
-func NewStruct(node cst.Node, attributes []*Attribute, name *Token, genericparams []*Generic, fields []*Field, staticfields []*Field) *Struct {
-	if attributes == nil && name == nil && genericparams == nil && fields == nil && staticfields == nil {
+func NewStruct(node cst.Node, attributes []*Attribute, name *Token, genericparams []*Generic, fields []*Field, staticfields []*Field, constants []*Const) *Struct {
+	if attributes == nil && name == nil && genericparams == nil && fields == nil && staticfields == nil && constants == nil {
 		return nil
 	}
 
@@ -205,6 +207,7 @@ func NewStruct(node cst.Node, attributes []*Attribute, name *Token, genericparam
 		GenericParams: genericparams,
 		Fields:        fields,
 		StaticFields:  staticfields,
+		Constants:     constants,
 	}
 
 	for _, child := range attributes {
@@ -222,6 +225,9 @@ func NewStruct(node cst.Node, attributes []*Attribute, name *Token, genericparam
 	for _, child := range staticfields {
 		child.SetParent(s)
 	}
+	for _, child := range constants {
+		child.SetParent(s)
+	}
 
 	return s
 }
@@ -266,6 +272,9 @@ func (s *Struct) AcceptChildren(visitor Visitor) {
 	for _, child := range s.StaticFields {
 		visitor.VisitNode(child)
 	}
+	for _, child := range s.Constants {
+		visitor.VisitNode(child)
+	}
 }
 
 func (s *Struct) Clone() Node {
@@ -299,6 +308,11 @@ func (s *Struct) Clone() Node {
 		s2.StaticFields[i] = child.Clone().(*Field)
 		s2.StaticFields[i].SetParent(s2)
 	}
+	s2.Constants = make([]*Const, len(s.Constants))
+	for i, child := range s2.Constants {
+		s2.Constants[i] = child.Clone().(*Const)
+		s2.Constants[i].SetParent(s2)
+	}
 	if s.Type != nil {
 		s2.Type = s.Type.Clone().(Type)
 		s2.Type.SetParent(s2)
@@ -321,24 +335,30 @@ type Enum struct {
 	cst    cst.Node
 	parent Node
 
+	Attributes []*Attribute
 	Name       *Token
 	Type       Type
 	ActualType Type
 	Cases      []*EnumCase
+	State      EvalState
 }
 
-func NewEnum(node cst.Node, name *Token, type_ Type, cases []*EnumCase) *Enum {
-	if name == nil && type_ == nil && cases == nil {
+func NewEnum(node cst.Node, attributes []*Attribute, name *Token, type_ Type, cases []*EnumCase) *Enum {
+	if attributes == nil && name == nil && type_ == nil && cases == nil {
 		return nil
 	}
 
 	e := &Enum{
-		cst:   node,
-		Name:  name,
-		Type:  type_,
-		Cases: cases,
+		cst:        node,
+		Attributes: attributes,
+		Name:       name,
+		Type:       type_,
+		Cases:      cases,
 	}
 
+	for _, child := range attributes {
+		child.SetParent(e)
+	}
 	if name != nil {
 		name.SetParent(e)
 	}
@@ -377,6 +397,9 @@ func (e *Enum) SetParent(parent Node) {
 }
 
 func (e *Enum) AcceptChildren(visitor Visitor) {
+	for _, child := range e.Attributes {
+		visitor.VisitNode(child)
+	}
 	if e.Name != nil {
 		visitor.VisitNode(e.Name)
 	}
@@ -392,8 +415,14 @@ func (e *Enum) Clone() Node {
 	e2 := &Enum{
 		cst:        e.cst,
 		ActualType: e.ActualType,
+		State:      e.State,
 	}
 
+	e2.Attributes = make([]*Attribute, len(e.Attributes))
+	for i, child := range e2.Attributes {
+		e2.Attributes[i] = child.Clone().(*Attribute)
+		e2.Attributes[i].SetParent(e2)
+	}
 	if e.Name != nil {
 		e2.Name = e.Name.Clone().(*Token)
 		e2.Name.SetParent(e2)
@@ -537,24 +566,35 @@ type Interface struct {
 	cst    cst.Node
 	parent Node
 
-	Name    *Token
-	Methods []*Func
+	Name            *Token
+	GenericParams   []*Generic
+	Inherits        []Type
+	Methods         []*Func
+	Specializations []*SpecializedInterface
 }
 
-func NewInterface(node cst.Node, name *Token, methods []*Func) *Interface {
-	if name == nil && methods == nil {
+func NewInterface(node cst.Node, name *Token, genericparams []*Generic, inherits []Type, methods []*Func) *Interface {
+	if name == nil && genericparams == nil && inherits == nil && methods == nil {
 		return nil
 	}
 
 	i := &Interface{
-		cst:     node,
-		Name:    name,
-		Methods: methods,
+		cst:           node,
+		Name:          name,
+		GenericParams: genericparams,
+		Inherits:      inherits,
+		Methods:       methods,
 	}
 
 	if name != nil {
 		name.SetParent(i)
 	}
+	for _, child := range genericparams {
+		child.SetParent(i)
+	}
+	for _, child := range inherits {
+		child.SetParent(i)
+	}
 	for _, child := range methods {
 		child.SetParent(i)
 	}
@@ -590,6 +630,12 @@ func (i *Interface) AcceptChildren(visitor Visitor) {
 	if i.Name != nil {
 		visitor.VisitNode(i.Name)
 	}
+	for _, child := range i.GenericParams {
+		visitor.VisitNode(child)
+	}
+	for _, child := range i.Inherits {
+		visitor.VisitNode(child)
+	}
 	for _, child := range i.Methods {
 		visitor.VisitNode(child)
 	}
@@ -597,13 +643,24 @@ func (i *Interface) AcceptChildren(visitor Visitor) {
 
 func (i *Interface) Clone() Node {
 	i2 := &Interface{
-		cst: i.cst,
+		cst:             i.cst,
+		Specializations: i.Specializations,
 	}
 
 	if i.Name != nil {
 		i2.Name = i.Name.Clone().(*Token)
 		i2.Name.SetParent(i2)
 	}
+	i2.GenericParams = make([]*Generic, len(i.GenericParams))
+	for i, child := range i2.GenericParams {
+		i2.GenericParams[i] = child.Clone().(*Generic)
+		i2.GenericParams[i].SetParent(i2)
+	}
+	i2.Inherits = make([]Type, len(i.Inherits))
+	for i, child := range i2.Inherits {
+		i2.Inherits[i] = child.Clone().(Type)
+		i2.Inherits[i].SetParent(i2)
+	}
 	i2.Methods = make([]*Func, len(i.Methods))
 	for i, child := range i2.Methods {
 		i2.Methods[i] = child.Clone().(*Func)
@@ -635,6 +692,7 @@ type Func struct {
 	Returns_        Type
 	Body            []Stmt
 	Specializations []*SpecializedFunc
+	UsedOutsideFile bool
 }
 
 func NewFunc(node cst.Node, attributes []*Attribute, flags FuncFlags, name *Token, genericparams []*Generic, params []*Param, returns_ Type, body []Stmt) *Func {
@@ -725,6 +783,7 @@ func (f *Func) Clone() Node {
 		cst:             f.cst,
 		Flags:           f.Flags,
 		Specializations: f.Specializations,
+		UsedOutsideFile: f.UsedOutsideFile,
 	}
 
 	f2.Attributes = make([]*Attribute, len(f.Attributes))
@@ -773,27 +832,38 @@ type GlobalVar struct {
 	cst    cst.Node
 	parent Node
 
-	Name *Token
-	Type Type
+	Attributes  []*Attribute
+	Name        *Token
+	Type        Type
+	Value       Expr
+	ActualValue Constant
 }
 
-func NewGlobalVar(node cst.Node, name *Token, type_ Type) *GlobalVar {
-	if name == nil && type_ == nil {
+func NewGlobalVar(node cst.Node, attributes []*Attribute, name *Token, type_ Type, value Expr) *GlobalVar {
+	if attributes == nil && name == nil && type_ == nil && value == nil {
 		return nil
 	}
 
 	g := &GlobalVar{
-		cst:  node,
-		Name: name,
-		Type: type_,
+		cst:        node,
+		Attributes: attributes,
+		Name:       name,
+		Type:       type_,
+		Value:      value,
 	}
 
+	for _, child := range attributes {
+		child.SetParent(g)
+	}
 	if name != nil {
 		name.SetParent(g)
 	}
 	if type_ != nil {
 		type_.SetParent(g)
 	}
+	if value != nil {
+		value.SetParent(g)
+	}
 
 	return g
 }
@@ -823,19 +893,31 @@ func (g *GlobalVar) SetParent(parent Node) {
 }
 
 func (g *GlobalVar) AcceptChildren(visitor Visitor) {
+	for _, child := range g.Attributes {
+		visitor.VisitNode(child)
+	}
 	if g.Name != nil {
 		visitor.VisitNode(g.Name)
 	}
 	if g.Type != nil {
 		visitor.VisitNode(g.Type)
 	}
+	if g.Value != nil {
+		visitor.VisitNode(g.Value)
+	}
 }
 
 func (g *GlobalVar) Clone() Node {
 	g2 := &GlobalVar{
-		cst: g.cst,
+		cst:         g.cst,
+		ActualValue: g.ActualValue,
 	}
 
+	g2.Attributes = make([]*Attribute, len(g.Attributes))
+	for i, child := range g2.Attributes {
+		g2.Attributes[i] = child.Clone().(*Attribute)
+		g2.Attributes[i].SetParent(g2)
+	}
 	if g.Name != nil {
 		g2.Name = g.Name.Clone().(*Token)
 		g2.Name.SetParent(g2)
@@ -844,6 +926,10 @@ func (g *GlobalVar) Clone() Node {
 		g2.Type = g.Type.Clone().(Type)
 		g2.Type.SetParent(g2)
 	}
+	if g.Value != nil {
+		g2.Value = g.Value.Clone().(Expr)
+		g2.Value.SetParent(g2)
+	}
 
 	return g2
 }
@@ -855,3 +941,108 @@ func (g *GlobalVar) String() string {
 func (g *GlobalVar) AcceptDecl(visitor DeclVisitor) {
 	visitor.VisitGlobalVar(g)
 }
+
+// Const
+
+type Const struct {
+	cst    cst.Node
+	parent Node
+
+	Name        *Token
+	Type        Type
+	Value       Expr
+	ActualValue Constant
+	State       EvalState
+}
+
+func NewConst(node cst.Node, name *Token, type_ Type, value Expr) *Const {
+	if name == nil && type_ == nil && value == nil {
+		return nil
+	}
+
+	c := &Const{
+		cst:   node,
+		Name:  name,
+		Type:  type_,
+		Value: value,
+	}
+
+	if name != nil {
+		name.SetParent(c)
+	}
+	if type_ != nil {
+		type_.SetParent(c)
+	}
+	if value != nil {
+		value.SetParent(c)
+	}
+
+	return c
+}
+
+func (c *Const) Cst() *cst.Node {
+	if c.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &c.cst
+}
+
+func (c *Const) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (c *Const) Parent() Node {
+	return c.parent
+}
+
+func (c *Const) SetParent(parent Node) {
+	if parent != nil && c.parent != nil {
+		panic("ast.Const.SetParent() - Parent is already set")
+	}
+
+	c.parent = parent
+}
+
+func (c *Const) AcceptChildren(visitor Visitor) {
+	if c.Name != nil {
+		visitor.VisitNode(c.Name)
+	}
+	if c.Type != nil {
+		visitor.VisitNode(c.Type)
+	}
+	if c.Value != nil {
+		visitor.VisitNode(c.Value)
+	}
+}
+
+func (c *Const) Clone() Node {
+	c2 := &Const{
+		cst:         c.cst,
+		ActualValue: c.ActualValue,
+		State:       c.State,
+	}
+
+	if c.Name != nil {
+		c2.Name = c.Name.Clone().(*Token)
+		c2.Name.SetParent(c2)
+	}
+	if c.Type != nil {
+		c2.Type = c.Type.Clone().(Type)
+		c2.Type.SetParent(c2)
+	}
+	if c.Value != nil {
+		c2.Value = c.Value.Clone().(Expr)
+		c2.Value.SetParent(c2)
+	}
+
+	return c2
+}
+
+func (c *Const) String() string {
+	return ""
+}
+
+func (c *Const) AcceptDecl(visitor DeclVisitor) {
+	visitor.VisitConst(c)
+}
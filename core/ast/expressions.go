@@ -16,13 +16,17 @@ type ExprVisitor interface {
 	VisitAssignment(expr *Assignment)
 	VisitMember(expr *Member)
 	VisitIndex(expr *Index)
+	VisitSlicing(expr *Slicing)
 	VisitCast(expr *Cast)
 	VisitCall(expr *Call)
+	VisitNamedArg(expr *NamedArg)
 	VisitTypeCall(expr *TypeCall)
 	VisitTypeof(expr *Typeof)
+	VisitAsm(expr *Asm)
 	VisitStructInitializer(expr *StructInitializer)
 	VisitArrayInitializer(expr *ArrayInitializer)
 	VisitAllocateArray(expr *AllocateArray)
+	VisitClosure(expr *Closure)
 	VisitIdentifier(expr *Identifier)
 }
 
@@ -811,6 +815,113 @@ func (i *Index) Result() *ExprResult {
 	return &i.result
 }
 
+// Slicing
+
+type Slicing struct {
+	cst    cst.Node
+	parent Node
+
+	Value Expr
+	Low   Expr
+	High  Expr
+
+	result ExprResult
+}
+
+func NewSlicing(node cst.Node, value Expr, low Expr, high Expr) *Slicing {
+	if value == nil && low == nil && high == nil {
+		return nil
+	}
+
+	s := &Slicing{
+		cst:   node,
+		Value: value,
+		Low:   low,
+		High:  high,
+	}
+
+	if value != nil {
+		value.SetParent(s)
+	}
+	if low != nil {
+		low.SetParent(s)
+	}
+	if high != nil {
+		high.SetParent(s)
+	}
+
+	return s
+}
+
+func (s *Slicing) Cst() *cst.Node {
+	if s.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &s.cst
+}
+
+func (s *Slicing) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (s *Slicing) Parent() Node {
+	return s.parent
+}
+
+func (s *Slicing) SetParent(parent Node) {
+	if parent != nil && s.parent != nil {
+		panic("ast.Slicing.SetParent() - Parent is already set")
+	}
+
+	s.parent = parent
+}
+
+func (s *Slicing) AcceptChildren(visitor Visitor) {
+	if s.Value != nil {
+		visitor.VisitNode(s.Value)
+	}
+	if s.Low != nil {
+		visitor.VisitNode(s.Low)
+	}
+	if s.High != nil {
+		visitor.VisitNode(s.High)
+	}
+}
+
+func (s *Slicing) Clone() Node {
+	s2 := &Slicing{
+		cst: s.cst,
+	}
+
+	if s.Value != nil {
+		s2.Value = s.Value.Clone().(Expr)
+		s2.Value.SetParent(s2)
+	}
+	if s.Low != nil {
+		s2.Low = s.Low.Clone().(Expr)
+		s2.Low.SetParent(s2)
+	}
+	if s.High != nil {
+		s2.High = s.High.Clone().(Expr)
+		s2.High.SetParent(s2)
+	}
+
+	return s2
+}
+
+func (s *Slicing) String() string {
+	return ""
+}
+
+func (s *Slicing) AcceptExpr(visitor ExprVisitor) {
+	visitor.VisitSlicing(s)
+}
+
+func (s *Slicing) Result() *ExprResult {
+	return &s.result
+}
+
 // Cast
 
 type Cast struct {
@@ -924,8 +1035,10 @@ type Call struct {
 	cst    cst.Node
 	parent Node
 
-	Callee Expr
-	Args   []Expr
+	Callee      Expr
+	Args        []Expr
+	ActualArgs  []Expr
+	MemoryOrder *EnumCase
 
 	result ExprResult
 }
@@ -986,7 +1099,9 @@ func (c *Call) AcceptChildren(visitor Visitor) {
 
 func (c *Call) Clone() Node {
 	c2 := &Call{
-		cst: c.cst,
+		cst:         c.cst,
+		ActualArgs:  c.ActualArgs,
+		MemoryOrder: c.MemoryOrder,
 	}
 
 	if c.Callee != nil {
@@ -998,6 +1113,11 @@ func (c *Call) Clone() Node {
 		c2.Args[i] = child.Clone().(Expr)
 		c2.Args[i].SetParent(c2)
 	}
+	c2.ActualArgs = make([]Expr, len(c.ActualArgs))
+	for i, child := range c2.ActualArgs {
+		c2.ActualArgs[i] = child.Clone().(Expr)
+		c2.ActualArgs[i].SetParent(c2)
+	}
 
 	return c2
 }
@@ -1014,6 +1134,101 @@ func (c *Call) Result() *ExprResult {
 	return &c.result
 }
 
+// NamedArg
+
+type NamedArg struct {
+	cst    cst.Node
+	parent Node
+
+	Name  *Token
+	Value Expr
+
+	result ExprResult
+}
+
+func NewNamedArg(node cst.Node, name *Token, value Expr) *NamedArg {
+	if name == nil && value == nil {
+		return nil
+	}
+
+	n := &NamedArg{
+		cst:   node,
+		Name:  name,
+		Value: value,
+	}
+
+	if name != nil {
+		name.SetParent(n)
+	}
+	if value != nil {
+		value.SetParent(n)
+	}
+
+	return n
+}
+
+func (n *NamedArg) Cst() *cst.Node {
+	if n.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &n.cst
+}
+
+func (n *NamedArg) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (n *NamedArg) Parent() Node {
+	return n.parent
+}
+
+func (n *NamedArg) SetParent(parent Node) {
+	if parent != nil && n.parent != nil {
+		panic("ast.NamedArg.SetParent() - Parent is already set")
+	}
+
+	n.parent = parent
+}
+
+func (n *NamedArg) AcceptChildren(visitor Visitor) {
+	if n.Name != nil {
+		visitor.VisitNode(n.Name)
+	}
+	if n.Value != nil {
+		visitor.VisitNode(n.Value)
+	}
+}
+
+func (n *NamedArg) Clone() Node {
+	n2 := &NamedArg{
+		cst: n.cst,
+	}
+
+	if n.Name != nil {
+		n2.Name = n.Name.Clone().(*Token)
+		n2.Name.SetParent(n2)
+	}
+	if n.Value != nil {
+		n2.Value = n.Value.Clone().(Expr)
+		n2.Value.SetParent(n2)
+	}
+
+	return n2
+}
+
+func (n *NamedArg) String() string {
+	return ""
+}
+
+func (n *NamedArg) AcceptExpr(visitor ExprVisitor) {
+	visitor.VisitNamedArg(n)
+}
+
+func (n *NamedArg) Result() *ExprResult {
+	return &n.result
+}
+
 // TypeCall
 
 type TypeCall struct {
@@ -1204,6 +1419,128 @@ func (t *Typeof) Result() *ExprResult {
 	return &t.result
 }
 
+// Asm
+
+type Asm struct {
+	cst    cst.Node
+	parent Node
+
+	Template *Token
+	Outputs  []*AsmOperand
+	Inputs   []*AsmOperand
+	Clobbers []*Token
+
+	result ExprResult
+}
+
+func NewAsm(node cst.Node, template *Token, outputs []*AsmOperand, inputs []*AsmOperand, clobbers []*Token) *Asm {
+	if template == nil && outputs == nil && inputs == nil && clobbers == nil {
+		return nil
+	}
+
+	a := &Asm{
+		cst:      node,
+		Template: template,
+		Outputs:  outputs,
+		Inputs:   inputs,
+		Clobbers: clobbers,
+	}
+
+	if template != nil {
+		template.SetParent(a)
+	}
+	for _, child := range outputs {
+		child.SetParent(a)
+	}
+	for _, child := range inputs {
+		child.SetParent(a)
+	}
+	for _, child := range clobbers {
+		child.SetParent(a)
+	}
+
+	return a
+}
+
+func (a *Asm) Cst() *cst.Node {
+	if a.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &a.cst
+}
+
+func (a *Asm) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (a *Asm) Parent() Node {
+	return a.parent
+}
+
+func (a *Asm) SetParent(parent Node) {
+	if parent != nil && a.parent != nil {
+		panic("ast.Asm.SetParent() - Parent is already set")
+	}
+
+	a.parent = parent
+}
+
+func (a *Asm) AcceptChildren(visitor Visitor) {
+	if a.Template != nil {
+		visitor.VisitNode(a.Template)
+	}
+	for _, child := range a.Outputs {
+		visitor.VisitNode(child)
+	}
+	for _, child := range a.Inputs {
+		visitor.VisitNode(child)
+	}
+	for _, child := range a.Clobbers {
+		visitor.VisitNode(child)
+	}
+}
+
+func (a *Asm) Clone() Node {
+	a2 := &Asm{
+		cst: a.cst,
+	}
+
+	if a.Template != nil {
+		a2.Template = a.Template.Clone().(*Token)
+		a2.Template.SetParent(a2)
+	}
+	a2.Outputs = make([]*AsmOperand, len(a.Outputs))
+	for i, child := range a2.Outputs {
+		a2.Outputs[i] = child.Clone().(*AsmOperand)
+		a2.Outputs[i].SetParent(a2)
+	}
+	a2.Inputs = make([]*AsmOperand, len(a.Inputs))
+	for i, child := range a2.Inputs {
+		a2.Inputs[i] = child.Clone().(*AsmOperand)
+		a2.Inputs[i].SetParent(a2)
+	}
+	a2.Clobbers = make([]*Token, len(a.Clobbers))
+	for i, child := range a2.Clobbers {
+		a2.Clobbers[i] = child.Clone().(*Token)
+		a2.Clobbers[i].SetParent(a2)
+	}
+
+	return a2
+}
+
+func (a *Asm) String() string {
+	return ""
+}
+
+func (a *Asm) AcceptExpr(visitor ExprVisitor) {
+	visitor.VisitAsm(a)
+}
+
+func (a *Asm) Result() *ExprResult {
+	return &a.result
+}
+
 // StructInitializer
 
 type StructInitializer struct {
@@ -1482,6 +1819,102 @@ func (a *AllocateArray) Result() *ExprResult {
 	return &a.result
 }
 
+// Closure
+
+type Closure struct {
+	cst    cst.Node
+	parent Node
+
+	Captures []*Capture
+	Function *Func
+
+	result ExprResult
+}
+
+func NewClosure(node cst.Node, captures []*Capture, function *Func) *Closure {
+	if captures == nil && function == nil {
+		return nil
+	}
+
+	c := &Closure{
+		cst:      node,
+		Captures: captures,
+		Function: function,
+	}
+
+	for _, child := range captures {
+		child.SetParent(c)
+	}
+	if function != nil {
+		function.SetParent(c)
+	}
+
+	return c
+}
+
+func (c *Closure) Cst() *cst.Node {
+	if c.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &c.cst
+}
+
+func (c *Closure) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (c *Closure) Parent() Node {
+	return c.parent
+}
+
+func (c *Closure) SetParent(parent Node) {
+	if parent != nil && c.parent != nil {
+		panic("ast.Closure.SetParent() - Parent is already set")
+	}
+
+	c.parent = parent
+}
+
+func (c *Closure) AcceptChildren(visitor Visitor) {
+	for _, child := range c.Captures {
+		visitor.VisitNode(child)
+	}
+	if c.Function != nil {
+		visitor.VisitNode(c.Function)
+	}
+}
+
+func (c *Closure) Clone() Node {
+	c2 := &Closure{
+		cst: c.cst,
+	}
+
+	c2.Captures = make([]*Capture, len(c.Captures))
+	for i, child := range c2.Captures {
+		c2.Captures[i] = child.Clone().(*Capture)
+		c2.Captures[i].SetParent(c2)
+	}
+	if c.Function != nil {
+		c2.Function = c.Function.Clone().(*Func)
+		c2.Function.SetParent(c2)
+	}
+
+	return c2
+}
+
+func (c *Closure) String() string {
+	return ""
+}
+
+func (c *Closure) AcceptExpr(visitor ExprVisitor) {
+	visitor.VisitClosure(c)
+}
+
+func (c *Closure) Result() *ExprResult {
+	return &c.result
+}
+
 // Identifier
 
 type Identifier struct {
@@ -67,6 +67,30 @@ func specializeFields(s *Struct, types []Type, fields []*Field) []SpecializedFie
 	return specFields
 }
 
+// Interface
+
+func (i *Interface) Specialize(types []Type) InterfaceType {
+	if len(types) != len(i.GenericParams) || len(types) == 0 {
+		return i
+	}
+
+	// Check cache
+	for _, spec := range i.Specializations {
+		if slices.EqualFunc(spec.Types, types, typesEquals) {
+			return spec
+		}
+	}
+
+	// Create
+	spec := &SpecializedInterface{
+		wrapper: wrapper[*Interface]{wrapped: i},
+		Types:   types,
+	}
+
+	i.Specializations = append(i.Specializations, spec)
+	return spec
+}
+
 // Func
 
 func (f *Func) Generics() []*Generic {
@@ -124,6 +148,20 @@ func specializeFunc(receiver Type, specializations *[]*SpecializedFunc, f Specia
 	return spec, true
 }
 
+// SpecializeType replaces the generic parameters inside the type with the types. The type is returned unchanged if it
+// does not contain any of the generic parameters.
+func SpecializeType(generics []*Generic, types []Type, type_ Type) Type {
+	if len(generics) != len(types) || IsNil(type_) {
+		return type_
+	}
+
+	if spec := specialize(generics, types, type_); spec != nil {
+		return spec
+	}
+
+	return type_
+}
+
 func specialize(generics []*Generic, types []Type, type_ Type) Type {
 	switch type_ := type_.Resolved().(type) {
 	case *Pointer:
@@ -138,6 +176,18 @@ func specialize(generics []*Generic, types []Type, type_ Type) Type {
 
 		return nil
 
+	case *Slice:
+		base := specialize(generics, types, type_.Base)
+
+		if base != nil {
+			return &Slice{
+				cst:  type_.cst,
+				Base: base,
+			}
+		}
+
+		return nil
+
 	case *Resolvable:
 		return specialize(generics, types, type_.Type)
 
@@ -149,6 +199,25 @@ func specialize(generics []*Generic, types []Type, type_ Type) Type {
 
 		return nil
 
+	case *SpecializedInterface:
+		var specTypes []Type
+
+		for i, arg := range type_.Types {
+			if spec := specialize(generics, types, arg); spec != nil {
+				if specTypes == nil {
+					specTypes = slices.Clone(type_.Types)
+				}
+
+				specTypes[i] = spec
+			}
+		}
+
+		if specTypes == nil {
+			return nil
+		}
+
+		return type_.Underlying().Specialize(specTypes)
+
 	case StructType:
 		var copied *SpecializedStruct
 
@@ -476,6 +545,124 @@ func (s *SpecializedStruct) MangledName(name *strings.Builder) {
 	name.WriteRune(']')
 }
 
+// Specialized Interface
+
+type SpecializedInterface struct {
+	wrapper[*Interface]
+
+	Types []Type
+
+	methods []FuncType
+
+	Type Type
+}
+
+func (s *SpecializedInterface) Clone() Node {
+	return &SpecializedInterface{
+		wrapper: s.wrapper,
+		Types:   s.Types,
+		methods: s.methods,
+	}
+}
+
+func (s *SpecializedInterface) Equals(other Type) bool {
+	return other != nil && s == other.Resolved()
+}
+
+func (s *SpecializedInterface) Resolved() Type {
+	if s.Type != nil {
+		return s.Type
+	}
+
+	return s
+}
+
+func (s *SpecializedInterface) AcceptType(visitor TypeVisitor) {
+	s.Underlying().AcceptType(visitor)
+}
+
+func (s *SpecializedInterface) Underlying() *Interface {
+	return s.wrapped
+}
+
+func (s *SpecializedInterface) MethodCount() int {
+	return len(s.getMethods())
+}
+
+func (s *SpecializedInterface) Parents() []InterfaceType {
+	parents := s.Underlying().Parents()
+
+	for i, parent := range parents {
+		if spec := specialize(s.Underlying().GenericParams, s.Types, parent); spec != nil {
+			if spec, ok := As[InterfaceType](spec); ok {
+				parents[i] = spec
+			}
+		}
+	}
+
+	return parents
+}
+
+func (s *SpecializedInterface) MethodIndex(index int) FuncType {
+	return s.getMethods()[index]
+}
+
+func (s *SpecializedInterface) GetMethod(name string) (FuncType, int) {
+	for i, method := range s.getMethods() {
+		if method.Underlying().Name != nil && method.Underlying().Name.String() == name {
+			return method, i
+		}
+	}
+
+	return nil, 0
+}
+
+func (s *SpecializedInterface) getMethods() []FuncType {
+	// Methods are specialized lazily because their types might not be specialized yet when the interface is
+	if s.methods != nil {
+		return s.methods
+	}
+
+	methods := s.Underlying().collectMethods(nil, make(map[*Interface]struct{}))
+	s.methods = make([]FuncType, len(methods))
+
+	for i, method := range methods {
+		s.methods[i] = s.specializeMethod(method)
+	}
+
+	return s.methods
+}
+
+func (s *SpecializedInterface) specializeMethod(method FuncType) FuncType {
+	params := make([]SpecializedParam, method.ParameterCount())
+	returns := method.Returns()
+
+	for j := 0; j < method.ParameterCount(); j++ {
+		param := method.ParameterIndex(j)
+		type_ := specialize(s.Underlying().GenericParams, s.Types, param.Type)
+
+		if type_ == nil {
+			type_ = param.Type
+		}
+
+		params[j] = SpecializedParam{
+			Param: param.Param,
+			Type:  type_,
+		}
+	}
+
+	if spec := specialize(s.Underlying().GenericParams, s.Types, returns); spec != nil {
+		returns = spec
+	}
+
+	return &SpecializedFunc{
+		wrapper:  wrapper[*Func]{wrapped: method.Underlying()},
+		receiver: s,
+		params:   params,
+		returns:  returns,
+	}
+}
+
 // Partially Specialized Func
 
 type PartiallySpecializedFunc struct {
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fireball/core/scanner"
+	"strconv"
 	"strings"
 )
 
@@ -17,6 +18,26 @@ func (n *NamespaceName) WriteTo(sb *strings.Builder) {
 	}
 }
 
+// Struct
+
+func (s *Struct) GetConstant(name string) *Const {
+	for _, constant := range s.Constants {
+		if constant.Name != nil && constant.Name.String() == name {
+			return constant
+		}
+	}
+
+	return nil
+}
+
+func (s *Struct) IsUnion() bool {
+	return GetAttribute(s.Attributes, "Union") != nil
+}
+
+func (s *Struct) IsC() bool {
+	return GetAttribute(s.Attributes, "C") != nil
+}
+
 // Impl
 
 func (i *Impl) GetMethod(name string, static bool) *Func {
@@ -48,6 +69,32 @@ func (e *Enum) GetCase(name string) *EnumCase {
 	return nil
 }
 
+func (e *Enum) IsTagged() bool {
+	for _, case_ := range e.Cases {
+		if len(case_.Fields) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Enum) IsFlags() bool {
+	return GetAttribute(e.Attributes, "Flags") != nil
+}
+
+func (e *Enum) IsC() bool {
+	return GetAttribute(e.Attributes, "C") != nil
+}
+
+func (e *Enum) MangledName(name *strings.Builder) {
+	file := GetParent[*File](e)
+	file.Namespace.Name.WriteTo(name)
+
+	name.WriteRune('.')
+	name.WriteString(e.Name.String())
+}
+
 // Field
 
 func (f *Field) IsStatic() bool {
@@ -58,6 +105,10 @@ func (f *Field) IsStatic() bool {
 	return false
 }
 
+func (f *Field) IsThreadLocal() bool {
+	return GetAttribute(f.Attributes, "ThreadLocal") != nil
+}
+
 func (f *Field) MangledName() string {
 	sb := strings.Builder{}
 	sb.WriteString("fb$")
@@ -83,9 +134,9 @@ func (f *Field) MangledName() string {
 
 // Interface
 
-func (i *Interface) GetMethod(name string) (*Func, int) {
-	for i, function := range i.Methods {
-		if function.Name != nil && function.Name.String() == name {
+func (i *Interface) GetMethod(name string) (FuncType, int) {
+	for i, function := range i.collectMethods(nil, make(map[*Interface]struct{})) {
+		if function.Underlying().Name != nil && function.Underlying().Name.String() == name {
 			return function, i
 		}
 	}
@@ -93,6 +144,72 @@ func (i *Interface) GetMethod(name string) (*Func, int) {
 	return nil, 0
 }
 
+// collectMethods appends the methods of the interface including the inherited ones, inherited methods come first in
+// the order of the inherited interfaces. Methods with the same name are only added once.
+func (i *Interface) collectMethods(methods []FuncType, visited map[*Interface]struct{}) []FuncType {
+	if _, ok := visited[i]; ok {
+		return methods
+	}
+
+	visited[i] = struct{}{}
+
+	for _, parent := range i.Inherits {
+		switch parent := Resolved(parent).(type) {
+		case *Interface:
+			methods = parent.collectMethods(methods, visited)
+
+		case *SpecializedInterface:
+			start := len(methods)
+			methods = parent.Underlying().collectMethods(methods, visited)
+
+			for j := start; j < len(methods); j++ {
+				methods[j] = parent.specializeMethod(methods[j])
+			}
+		}
+	}
+
+	for _, method := range i.Methods {
+		methods = appendMethod(methods, method)
+	}
+
+	return methods
+}
+
+func appendMethod(methods []FuncType, method FuncType) []FuncType {
+	if method.Underlying().Name == nil {
+		return methods
+	}
+
+	for _, m := range methods {
+		if m.Underlying().Name != nil && m.Underlying().Name.String() == method.Underlying().Name.String() {
+			return methods
+		}
+	}
+
+	return append(methods, method)
+}
+
+// Inherits returns true if the interface inherits the parent interface, directly or through other interfaces.
+func Inherits(inter InterfaceType, parent InterfaceType) bool {
+	return inherits(inter, parent, make(map[*Interface]struct{}))
+}
+
+func inherits(inter InterfaceType, parent InterfaceType, visited map[*Interface]struct{}) bool {
+	if _, ok := visited[inter.Underlying()]; ok {
+		return false
+	}
+
+	visited[inter.Underlying()] = struct{}{}
+
+	for _, type_ := range inter.Parents() {
+		if type_.Equals(parent) || inherits(type_, parent, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Func
 
 func (f *Func) IsStatic() bool {
@@ -106,8 +223,8 @@ func (f *Func) IsVariadic() bool {
 func (f *Func) ExternName() string {
 	for _, attribute := range f.Attributes {
 		if attribute.Name.String() == "Extern" {
-			if len(attribute.Args) > 0 {
-				return attribute.Args[0].String()[1 : len(attribute.Args[0].String())-1]
+			if arg, ok := attribute.StringArg(0); ok {
+				return arg
 			}
 
 			return f.Name.String()
@@ -120,8 +237,8 @@ func (f *Func) ExternName() string {
 func (f *Func) IntrinsicName() string {
 	for _, attribute := range f.Attributes {
 		if attribute.Name.String() == "Intrinsic" {
-			if len(attribute.Args) > 0 {
-				return attribute.Args[0].String()[1 : len(attribute.Args[0].String())-1]
+			if arg, ok := attribute.StringArg(0); ok {
+				return arg
 			}
 
 			return f.Name.String()
@@ -131,11 +248,23 @@ func (f *Func) IntrinsicName() string {
 	return ""
 }
 
+// IsAtomicIntrinsic returns true if the intrinsic is lowered to atomic instructions instead of a call.
+func IsAtomicIntrinsic(name string) bool {
+	switch name {
+	case "atomic_load", "atomic_store", "atomic_exchange", "atomic_compare_exchange",
+		"atomic_add", "atomic_sub", "atomic_and", "atomic_or", "atomic_xor", "fence":
+		return true
+
+	default:
+		return false
+	}
+}
+
 func (f *Func) TestName() string {
 	for _, attribute := range f.Attributes {
 		if attribute.Name.String() == "Test" {
-			if len(attribute.Args) > 0 {
-				return attribute.Args[0].String()[1 : len(attribute.Args[0].String())-1]
+			if arg, ok := attribute.StringArg(0); ok {
+				return arg
 			}
 
 			return f.Name.String()
@@ -152,13 +281,23 @@ func (f *Func) HasBody() bool {
 		}
 	}
 
+	// Interface methods only have a body when they provide a default implementation
 	if _, ok := f.Parent().(*Interface); ok {
-		return false
+		return f.IsDefault()
 	}
 
 	return true
 }
 
+// IsDefault returns true for interface methods with a default implementation.
+func (f *Func) IsDefault() bool {
+	if _, ok := f.Parent().(*Interface); !ok {
+		return false
+	}
+
+	return f.Cst() != nil && f.Cst().Contains(scanner.LeftBrace)
+}
+
 func (f *Func) Struct() StructType {
 	if impl, ok := f.Parent().(*Impl); ok {
 		if s, ok := impl.Type.(StructType); ok {
@@ -208,6 +347,10 @@ func Signature(f FuncType, paramNames bool) string {
 
 // GlobalVar
 
+func (g *GlobalVar) IsThreadLocal() bool {
+	return GetAttribute(g.Attributes, "ThreadLocal") != nil
+}
+
 func (g *GlobalVar) MangledName() string {
 	sb := strings.Builder{}
 	sb.WriteString("fb$")
@@ -223,3 +366,56 @@ func (g *GlobalVar) MangledName() string {
 
 	return sb.String()
 }
+
+// Attribute
+
+func GetAttribute(attributes []*Attribute, name string) *Attribute {
+	for _, attribute := range attributes {
+		if attribute.Name != nil && attribute.Name.String() == name {
+			return attribute
+		}
+	}
+
+	return nil
+}
+
+func (a *Attribute) StringArg(index int) (string, bool) {
+	if index >= len(a.Args) || a.Args[index].Token().Kind != scanner.String {
+		return "", false
+	}
+
+	str := a.Args[index].String()
+	return str[1 : len(str)-1], true
+}
+
+func (a *Attribute) NumberArg(index int) (uint32, bool) {
+	if index >= len(a.Args) || a.Args[index].Token().Kind != scanner.Number {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(a.Args[index].String(), 10, 32)
+	return uint32(value), err == nil
+}
+
+// Asm
+
+func (a *Asm) TemplateString() string {
+	return unquote(a.Template)
+}
+
+func (a *Asm) ClobberString(index int) string {
+	return unquote(a.Clobbers[index])
+}
+
+func (a *AsmOperand) ConstraintString() string {
+	return unquote(a.Constraint)
+}
+
+func unquote(token *Token) string {
+	if token == nil || token.Token().Kind != scanner.String {
+		return ""
+	}
+
+	str := token.String()
+	return str[1 : len(str)-1]
+}
@@ -29,6 +29,18 @@ type StructType interface {
 	MangledName(name *strings.Builder)
 }
 
+type InterfaceType interface {
+	Type
+
+	Underlying() *Interface
+
+	MethodCount() int
+	MethodIndex(index int) FuncType
+	GetMethod(name string) (FuncType, int)
+
+	Parents() []InterfaceType
+}
+
 type SpecializableFunc interface {
 	FuncType
 
@@ -70,6 +70,32 @@ func (s *Struct) MangledName(name *strings.Builder) {
 	name.WriteString(s.Name.String())
 }
 
+// Interface
+
+func (i *Interface) Underlying() *Interface {
+	return i
+}
+
+func (i *Interface) MethodCount() int {
+	return len(i.collectMethods(nil, make(map[*Interface]struct{})))
+}
+
+func (i *Interface) MethodIndex(index int) FuncType {
+	return i.collectMethods(nil, make(map[*Interface]struct{}))[index]
+}
+
+func (i *Interface) Parents() []InterfaceType {
+	parents := make([]InterfaceType, 0, len(i.Inherits))
+
+	for _, parent := range i.Inherits {
+		if parent, ok := As[InterfaceType](parent); ok {
+			parents = append(parents, parent)
+		}
+	}
+
+	return parents
+}
+
 // Func
 
 func (f *Func) Underlying() *Func {
@@ -123,6 +149,14 @@ func funcMangledName(f *Func, receiver StructType, name *strings.Builder) {
 		} else {
 			name.WriteString(":m:")
 		}
+	} else if inter, ok := f.Parent().(*Interface); ok {
+		// Interface
+		file := GetParent[*File](f)
+
+		file.Namespace.Name.WriteTo(name)
+		name.WriteRune('.')
+		name.WriteString(inter.Name.String())
+		name.WriteString(":m:")
 	} else {
 		// Namespace
 		file := GetParent[*File](f)
@@ -11,10 +11,12 @@ type File struct {
 	cst    cst.Node
 	parent Node
 
-	Path      string
-	Namespace *Namespace
-	Resolver  Resolver
-	Decls     []Decl
+	Path         string
+	Namespace    *Namespace
+	Resolver     Resolver
+	Decls        []Decl
+	Initializers []Node
+	InitPriority uint16
 }
 
 func NewFile(node cst.Node, path string, namespace *Namespace, decls []Decl) *File {
@@ -70,9 +72,11 @@ func (f *File) AcceptChildren(visitor Visitor) {
 
 func (f *File) Clone() Node {
 	f2 := &File{
-		cst:      f.cst,
-		Path:     f.Path,
-		Resolver: f.Resolver,
+		cst:          f.cst,
+		Path:         f.Path,
+		Resolver:     f.Resolver,
+		Initializers: f.Initializers,
+		InitPriority: f.InitPriority,
 	}
 
 	if f.Namespace != nil {
@@ -172,27 +176,38 @@ type Field struct {
 	cst    cst.Node
 	parent Node
 
-	Name_ *Token
-	Type_ Type
+	Attributes  []*Attribute
+	Name_       *Token
+	Type_       Type
+	Value       Expr
+	ActualValue Constant
 }
 
-func NewField(node cst.Node, name_ *Token, type_ Type) *Field {
-	if name_ == nil && type_ == nil {
+func NewField(node cst.Node, attributes []*Attribute, name_ *Token, type_ Type, value Expr) *Field {
+	if attributes == nil && name_ == nil && type_ == nil && value == nil {
 		return nil
 	}
 
 	f := &Field{
-		cst:   node,
-		Name_: name_,
-		Type_: type_,
+		cst:        node,
+		Attributes: attributes,
+		Name_:      name_,
+		Type_:      type_,
+		Value:      value,
 	}
 
+	for _, child := range attributes {
+		child.SetParent(f)
+	}
 	if name_ != nil {
 		name_.SetParent(f)
 	}
 	if type_ != nil {
 		type_.SetParent(f)
 	}
+	if value != nil {
+		value.SetParent(f)
+	}
 
 	return f
 }
@@ -222,19 +237,31 @@ func (f *Field) SetParent(parent Node) {
 }
 
 func (f *Field) AcceptChildren(visitor Visitor) {
+	for _, child := range f.Attributes {
+		visitor.VisitNode(child)
+	}
 	if f.Name_ != nil {
 		visitor.VisitNode(f.Name_)
 	}
 	if f.Type_ != nil {
 		visitor.VisitNode(f.Type_)
 	}
+	if f.Value != nil {
+		visitor.VisitNode(f.Value)
+	}
 }
 
 func (f *Field) Clone() Node {
 	f2 := &Field{
-		cst: f.cst,
+		cst:         f.cst,
+		ActualValue: f.ActualValue,
 	}
 
+	f2.Attributes = make([]*Attribute, len(f.Attributes))
+	for i, child := range f2.Attributes {
+		f2.Attributes[i] = child.Clone().(*Attribute)
+		f2.Attributes[i].SetParent(f2)
+	}
 	if f.Name_ != nil {
 		f2.Name_ = f.Name_.Clone().(*Token)
 		f2.Name_.SetParent(f2)
@@ -243,6 +270,10 @@ func (f *Field) Clone() Node {
 		f2.Type_ = f.Type_.Clone().(Type)
 		f2.Type_.SetParent(f2)
 	}
+	if f.Value != nil {
+		f2.Value = f.Value.Clone().(Expr)
+		f2.Value.SetParent(f2)
+	}
 
 	return f2
 }
@@ -336,6 +367,192 @@ func (i *InitField) String() string {
 	return ""
 }
 
+// MatchArm
+
+type MatchArm struct {
+	cst    cst.Node
+	parent Node
+
+	Default      bool
+	Values       []Expr
+	Bindings     []*Token
+	Body         Stmt
+	ActualValues []int64
+}
+
+func NewMatchArm(node cst.Node, default_ bool, values []Expr, bindings []*Token, body Stmt) *MatchArm {
+	if values == nil && bindings == nil && body == nil {
+		return nil
+	}
+
+	m := &MatchArm{
+		cst:      node,
+		Default:  default_,
+		Values:   values,
+		Bindings: bindings,
+		Body:     body,
+	}
+
+	for _, child := range values {
+		child.SetParent(m)
+	}
+	for _, child := range bindings {
+		child.SetParent(m)
+	}
+	if body != nil {
+		body.SetParent(m)
+	}
+
+	return m
+}
+
+func (m *MatchArm) Cst() *cst.Node {
+	if m.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &m.cst
+}
+
+func (m *MatchArm) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (m *MatchArm) Parent() Node {
+	return m.parent
+}
+
+func (m *MatchArm) SetParent(parent Node) {
+	if parent != nil && m.parent != nil {
+		panic("ast.MatchArm.SetParent() - Parent is already set")
+	}
+
+	m.parent = parent
+}
+
+func (m *MatchArm) AcceptChildren(visitor Visitor) {
+	for _, child := range m.Values {
+		visitor.VisitNode(child)
+	}
+	for _, child := range m.Bindings {
+		visitor.VisitNode(child)
+	}
+	if m.Body != nil {
+		visitor.VisitNode(m.Body)
+	}
+}
+
+func (m *MatchArm) Clone() Node {
+	m2 := &MatchArm{
+		cst:          m.cst,
+		Default:      m.Default,
+		ActualValues: m.ActualValues,
+	}
+
+	m2.Values = make([]Expr, len(m.Values))
+	for i, child := range m2.Values {
+		m2.Values[i] = child.Clone().(Expr)
+		m2.Values[i].SetParent(m2)
+	}
+	m2.Bindings = make([]*Token, len(m.Bindings))
+	for i, child := range m2.Bindings {
+		m2.Bindings[i] = child.Clone().(*Token)
+		m2.Bindings[i].SetParent(m2)
+	}
+	if m.Body != nil {
+		m2.Body = m.Body.Clone().(Stmt)
+		m2.Body.SetParent(m2)
+	}
+
+	return m2
+}
+
+func (m *MatchArm) String() string {
+	return ""
+}
+
+// Capture
+
+type Capture struct {
+	cst    cst.Node
+	parent Node
+
+	Reference  bool
+	Name       *Token
+	ActualType Type
+}
+
+func NewCapture(node cst.Node, reference bool, name *Token) *Capture {
+	if name == nil {
+		return nil
+	}
+
+	c := &Capture{
+		cst:       node,
+		Reference: reference,
+		Name:      name,
+	}
+
+	if name != nil {
+		name.SetParent(c)
+	}
+
+	return c
+}
+
+func (c *Capture) Cst() *cst.Node {
+	if c.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &c.cst
+}
+
+func (c *Capture) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (c *Capture) Parent() Node {
+	return c.parent
+}
+
+func (c *Capture) SetParent(parent Node) {
+	if parent != nil && c.parent != nil {
+		panic("ast.Capture.SetParent() - Parent is already set")
+	}
+
+	c.parent = parent
+}
+
+func (c *Capture) AcceptChildren(visitor Visitor) {
+	if c.Name != nil {
+		visitor.VisitNode(c.Name)
+	}
+}
+
+func (c *Capture) Clone() Node {
+	c2 := &Capture{
+		cst:        c.cst,
+		Reference:  c.Reference,
+		ActualType: c.ActualType,
+	}
+
+	if c.Name != nil {
+		c2.Name = c.Name.Clone().(*Token)
+		c2.Name.SetParent(c2)
+	}
+	if c.ActualType != nil {
+		c2.ActualType = c.ActualType.Clone().(Type)
+		c2.ActualType.SetParent(c2)
+	}
+
+	return c2
+}
+
+func (c *Capture) String() string {
+	return ""
+}
+
 // EnumCase
 
 type EnumCase struct {
@@ -343,19 +560,21 @@ type EnumCase struct {
 	parent Node
 
 	Name        *Token
-	Value       *Token
+	Value       Expr
+	Fields      []*Field
 	ActualValue int64
 }
 
-func NewEnumCase(node cst.Node, name *Token, value *Token) *EnumCase {
-	if name == nil && value == nil {
+func NewEnumCase(node cst.Node, name *Token, value Expr, fields []*Field) *EnumCase {
+	if name == nil && value == nil && fields == nil {
 		return nil
 	}
 
 	e := &EnumCase{
-		cst:   node,
-		Name:  name,
-		Value: value,
+		cst:    node,
+		Name:   name,
+		Value:  value,
+		Fields: fields,
 	}
 
 	if name != nil {
@@ -364,6 +583,9 @@ func NewEnumCase(node cst.Node, name *Token, value *Token) *EnumCase {
 	if value != nil {
 		value.SetParent(e)
 	}
+	for _, child := range fields {
+		child.SetParent(e)
+	}
 
 	return e
 }
@@ -399,6 +621,9 @@ func (e *EnumCase) AcceptChildren(visitor Visitor) {
 	if e.Value != nil {
 		visitor.VisitNode(e.Value)
 	}
+	for _, child := range e.Fields {
+		visitor.VisitNode(child)
+	}
 }
 
 func (e *EnumCase) Clone() Node {
@@ -412,9 +637,14 @@ func (e *EnumCase) Clone() Node {
 		e2.Name.SetParent(e2)
 	}
 	if e.Value != nil {
-		e2.Value = e.Value.Clone().(*Token)
+		e2.Value = e.Value.Clone().(Expr)
 		e2.Value.SetParent(e2)
 	}
+	e2.Fields = make([]*Field, len(e.Fields))
+	for i, child := range e2.Fields {
+		e2.Fields[i] = child.Clone().(*Field)
+		e2.Fields[i].SetParent(e2)
+	}
 
 	return e2
 }
@@ -429,19 +659,21 @@ type Param struct {
 	cst    cst.Node
 	parent Node
 
-	Name *Token
-	Type Type
+	Name  *Token
+	Type  Type
+	Value Expr
 }
 
-func NewParam(node cst.Node, name *Token, type_ Type) *Param {
-	if name == nil && type_ == nil {
+func NewParam(node cst.Node, name *Token, type_ Type, value Expr) *Param {
+	if name == nil && type_ == nil && value == nil {
 		return nil
 	}
 
 	p := &Param{
-		cst:  node,
-		Name: name,
-		Type: type_,
+		cst:   node,
+		Name:  name,
+		Type:  type_,
+		Value: value,
 	}
 
 	if name != nil {
@@ -450,6 +682,9 @@ func NewParam(node cst.Node, name *Token, type_ Type) *Param {
 	if type_ != nil {
 		type_.SetParent(p)
 	}
+	if value != nil {
+		value.SetParent(p)
+	}
 
 	return p
 }
@@ -485,6 +720,9 @@ func (p *Param) AcceptChildren(visitor Visitor) {
 	if p.Type != nil {
 		visitor.VisitNode(p.Type)
 	}
+	if p.Value != nil {
+		visitor.VisitNode(p.Value)
+	}
 }
 
 func (p *Param) Clone() Node {
@@ -500,6 +738,10 @@ func (p *Param) Clone() Node {
 		p2.Type = p.Type.Clone().(Type)
 		p2.Type.SetParent(p2)
 	}
+	if p.Value != nil {
+		p2.Value = p.Value.Clone().(Expr)
+		p2.Value.SetParent(p2)
+	}
 
 	return p2
 }
@@ -508,6 +750,91 @@ func (p *Param) String() string {
 	return ""
 }
 
+// AsmOperand
+
+type AsmOperand struct {
+	cst    cst.Node
+	parent Node
+
+	Constraint *Token
+	Value      Expr
+}
+
+func NewAsmOperand(node cst.Node, constraint *Token, value Expr) *AsmOperand {
+	if constraint == nil && value == nil {
+		return nil
+	}
+
+	a := &AsmOperand{
+		cst:        node,
+		Constraint: constraint,
+		Value:      value,
+	}
+
+	if constraint != nil {
+		constraint.SetParent(a)
+	}
+	if value != nil {
+		value.SetParent(a)
+	}
+
+	return a
+}
+
+func (a *AsmOperand) Cst() *cst.Node {
+	if a.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &a.cst
+}
+
+func (a *AsmOperand) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (a *AsmOperand) Parent() Node {
+	return a.parent
+}
+
+func (a *AsmOperand) SetParent(parent Node) {
+	if parent != nil && a.parent != nil {
+		panic("ast.AsmOperand.SetParent() - Parent is already set")
+	}
+
+	a.parent = parent
+}
+
+func (a *AsmOperand) AcceptChildren(visitor Visitor) {
+	if a.Constraint != nil {
+		visitor.VisitNode(a.Constraint)
+	}
+	if a.Value != nil {
+		visitor.VisitNode(a.Value)
+	}
+}
+
+func (a *AsmOperand) Clone() Node {
+	a2 := &AsmOperand{
+		cst: a.cst,
+	}
+
+	if a.Constraint != nil {
+		a2.Constraint = a.Constraint.Clone().(*Token)
+		a2.Constraint.SetParent(a2)
+	}
+	if a.Value != nil {
+		a2.Value = a.Value.Clone().(Expr)
+		a2.Value.SetParent(a2)
+	}
+
+	return a2
+}
+
+func (a *AsmOperand) String() string {
+	return ""
+}
+
 // Attribute
 
 type Attribute struct {
@@ -668,6 +995,8 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *Array:
 		return node == nil
+	case *Slice:
+		return node == nil
 	case *Resolvable:
 		return node == nil
 	case *Generic:
@@ -688,6 +1017,8 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *GlobalVar:
 		return node == nil
+	case *Const:
+		return node == nil
 	case *Expression:
 		return node == nil
 	case *Block:
@@ -700,6 +1031,12 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *For:
 		return node == nil
+	case *ForIn:
+		return node == nil
+	case *Match:
+		return node == nil
+	case *Defer:
+		return node == nil
 	case *Return:
 		return node == nil
 	case *Break:
@@ -722,20 +1059,28 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *Index:
 		return node == nil
+	case *Slicing:
+		return node == nil
 	case *Cast:
 		return node == nil
 	case *Call:
 		return node == nil
+	case *NamedArg:
+		return node == nil
 	case *TypeCall:
 		return node == nil
 	case *Typeof:
 		return node == nil
+	case *Asm:
+		return node == nil
 	case *StructInitializer:
 		return node == nil
 	case *ArrayInitializer:
 		return node == nil
 	case *AllocateArray:
 		return node == nil
+	case *Closure:
+		return node == nil
 	case *Identifier:
 		return node == nil
 	case *File:
@@ -746,10 +1091,16 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *InitField:
 		return node == nil
+	case *MatchArm:
+		return node == nil
+	case *Capture:
+		return node == nil
 	case *EnumCase:
 		return node == nil
 	case *Param:
 		return node == nil
+	case *AsmOperand:
+		return node == nil
 	case *Attribute:
 		return node == nil
 	case *Token:
@@ -758,6 +1109,8 @@ func IsNil(node Node) bool {
 		return node == nil
 	case *SpecializedStruct:
 		return node == nil
+	case *SpecializedInterface:
+		return node == nil
 	case *SpecializedFunc:
 		return node == nil
 	case *PartiallySpecializedFunc:
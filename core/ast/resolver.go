@@ -10,10 +10,11 @@ type Resolver interface {
 
 	GetFunction(name string) *Func
 	GetVariable(name string) *GlobalVar
+	GetConstant(name string) *Const
 
 	GetMethod(type_ Type, name string, static bool) FuncType
 	GetMethods(type_ Type, static bool) []*Func
-	GetImpl(type_ Type, inter *Interface) *Impl
+	GetImpl(type_ Type, inter InterfaceType) *Impl
 
 	GetChildren() []string
 	GetSymbols(visitor SymbolVisitor)
@@ -79,6 +80,16 @@ func (c *CombinedResolver) GetVariable(name string) *GlobalVar {
 	return nil
 }
 
+func (c *CombinedResolver) GetConstant(name string) *Const {
+	for _, resolver := range c.resolvers {
+		if constant := resolver.GetConstant(name); constant != nil {
+			return constant
+		}
+	}
+
+	return nil
+}
+
 func (c *CombinedResolver) GetMethod(type_ Type, name string, static bool) FuncType {
 	for _, resolver := range c.resolvers {
 		if method := resolver.GetMethod(type_, name, static); method != nil {
@@ -99,7 +110,7 @@ func (c *CombinedResolver) GetMethods(type_ Type, static bool) []*Func {
 	return methods
 }
 
-func (c *CombinedResolver) GetImpl(type_ Type, inter *Interface) *Impl {
+func (c *CombinedResolver) GetImpl(type_ Type, inter InterfaceType) *Impl {
 	for _, resolver := range c.resolvers {
 		if impl := resolver.GetImpl(type_, inter); impl != nil {
 			return impl
@@ -161,6 +172,10 @@ func (g *genericResolver) GetVariable(name string) *GlobalVar {
 	return g.base.GetVariable(name)
 }
 
+func (g *genericResolver) GetConstant(name string) *Const {
+	return g.base.GetConstant(name)
+}
+
 func (g *genericResolver) GetMethod(type_ Type, name string, static bool) FuncType {
 	return g.base.GetMethod(type_, name, static)
 }
@@ -169,7 +184,7 @@ func (g *genericResolver) GetMethods(type_ Type, static bool) []*Func {
 	return g.base.GetMethods(type_, static)
 }
 
-func (g *genericResolver) GetImpl(type_ Type, inter *Interface) *Impl {
+func (g *genericResolver) GetImpl(type_ Type, inter InterfaceType) *Impl {
 	return g.base.GetImpl(type_, inter)
 }
 
@@ -14,6 +14,9 @@ type StmtVisitor interface {
 	VisitIf(stmt *If)
 	VisitWhile(stmt *While)
 	VisitFor(stmt *For)
+	VisitForIn(stmt *ForIn)
+	VisitMatch(stmt *Match)
+	VisitDefer(stmt *Defer)
 	VisitReturn(stmt *Return)
 	VisitBreak(stmt *Break)
 	VisitContinue(stmt *Continue)
@@ -394,21 +397,26 @@ type While struct {
 	cst    cst.Node
 	parent Node
 
+	Label     *Token
 	Condition Expr
 	Body      Stmt
 }
 
-func NewWhile(node cst.Node, condition Expr, body Stmt) *While {
-	if condition == nil && body == nil {
+func NewWhile(node cst.Node, label *Token, condition Expr, body Stmt) *While {
+	if label == nil && condition == nil && body == nil {
 		return nil
 	}
 
 	w := &While{
 		cst:       node,
+		Label:     label,
 		Condition: condition,
 		Body:      body,
 	}
 
+	if label != nil {
+		label.SetParent(w)
+	}
 	if condition != nil {
 		condition.SetParent(w)
 	}
@@ -444,6 +452,9 @@ func (w *While) SetParent(parent Node) {
 }
 
 func (w *While) AcceptChildren(visitor Visitor) {
+	if w.Label != nil {
+		visitor.VisitNode(w.Label)
+	}
 	if w.Condition != nil {
 		visitor.VisitNode(w.Condition)
 	}
@@ -457,6 +468,10 @@ func (w *While) Clone() Node {
 		cst: w.cst,
 	}
 
+	if w.Label != nil {
+		w2.Label = w.Label.Clone().(*Token)
+		w2.Label.SetParent(w2)
+	}
 	if w.Condition != nil {
 		w2.Condition = w.Condition.Clone().(Expr)
 		w2.Condition.SetParent(w2)
@@ -483,25 +498,30 @@ type For struct {
 	cst    cst.Node
 	parent Node
 
+	Label       *Token
 	Initializer Stmt
 	Condition   Expr
 	Increment   Expr
 	Body        Stmt
 }
 
-func NewFor(node cst.Node, initializer Stmt, condition Expr, increment Expr, body Stmt) *For {
-	if initializer == nil && condition == nil && increment == nil && body == nil {
+func NewFor(node cst.Node, label *Token, initializer Stmt, condition Expr, increment Expr, body Stmt) *For {
+	if label == nil && initializer == nil && condition == nil && increment == nil && body == nil {
 		return nil
 	}
 
 	f := &For{
 		cst:         node,
+		Label:       label,
 		Initializer: initializer,
 		Condition:   condition,
 		Increment:   increment,
 		Body:        body,
 	}
 
+	if label != nil {
+		label.SetParent(f)
+	}
 	if initializer != nil {
 		initializer.SetParent(f)
 	}
@@ -543,6 +563,9 @@ func (f *For) SetParent(parent Node) {
 }
 
 func (f *For) AcceptChildren(visitor Visitor) {
+	if f.Label != nil {
+		visitor.VisitNode(f.Label)
+	}
 	if f.Initializer != nil {
 		visitor.VisitNode(f.Initializer)
 	}
@@ -562,6 +585,10 @@ func (f *For) Clone() Node {
 		cst: f.cst,
 	}
 
+	if f.Label != nil {
+		f2.Label = f.Label.Clone().(*Token)
+		f2.Label.SetParent(f2)
+	}
 	if f.Initializer != nil {
 		f2.Initializer = f.Initializer.Clone().(Stmt)
 		f2.Initializer.SetParent(f2)
@@ -590,6 +617,292 @@ func (f *For) AcceptStmt(visitor StmtVisitor) {
 	visitor.VisitFor(f)
 }
 
+// ForIn
+
+type ForIn struct {
+	cst    cst.Node
+	parent Node
+
+	Label      *Token
+	Name       *Token
+	Iterable   Expr
+	Body       Stmt
+	ActualType Type
+}
+
+func NewForIn(node cst.Node, label *Token, name *Token, iterable Expr, body Stmt) *ForIn {
+	if label == nil && name == nil && iterable == nil && body == nil {
+		return nil
+	}
+
+	f := &ForIn{
+		cst:      node,
+		Label:    label,
+		Name:     name,
+		Iterable: iterable,
+		Body:     body,
+	}
+
+	if label != nil {
+		label.SetParent(f)
+	}
+	if name != nil {
+		name.SetParent(f)
+	}
+	if iterable != nil {
+		iterable.SetParent(f)
+	}
+	if body != nil {
+		body.SetParent(f)
+	}
+
+	return f
+}
+
+func (f *ForIn) Cst() *cst.Node {
+	if f.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &f.cst
+}
+
+func (f *ForIn) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (f *ForIn) Parent() Node {
+	return f.parent
+}
+
+func (f *ForIn) SetParent(parent Node) {
+	if parent != nil && f.parent != nil {
+		panic("ast.ForIn.SetParent() - Parent is already set")
+	}
+
+	f.parent = parent
+}
+
+func (f *ForIn) AcceptChildren(visitor Visitor) {
+	if f.Label != nil {
+		visitor.VisitNode(f.Label)
+	}
+	if f.Name != nil {
+		visitor.VisitNode(f.Name)
+	}
+	if f.Iterable != nil {
+		visitor.VisitNode(f.Iterable)
+	}
+	if f.Body != nil {
+		visitor.VisitNode(f.Body)
+	}
+}
+
+func (f *ForIn) Clone() Node {
+	f2 := &ForIn{
+		cst:        f.cst,
+		ActualType: f.ActualType,
+	}
+
+	if f.Label != nil {
+		f2.Label = f.Label.Clone().(*Token)
+		f2.Label.SetParent(f2)
+	}
+	if f.Name != nil {
+		f2.Name = f.Name.Clone().(*Token)
+		f2.Name.SetParent(f2)
+	}
+	if f.Iterable != nil {
+		f2.Iterable = f.Iterable.Clone().(Expr)
+		f2.Iterable.SetParent(f2)
+	}
+	if f.Body != nil {
+		f2.Body = f.Body.Clone().(Stmt)
+		f2.Body.SetParent(f2)
+	}
+	if f.ActualType != nil {
+		f2.ActualType = f.ActualType.Clone().(Type)
+		f2.ActualType.SetParent(f2)
+	}
+
+	return f2
+}
+
+func (f *ForIn) String() string {
+	return ""
+}
+
+func (f *ForIn) AcceptStmt(visitor StmtVisitor) {
+	visitor.VisitForIn(f)
+}
+
+// Match
+
+type Match struct {
+	cst    cst.Node
+	parent Node
+
+	Value Expr
+	Arms  []*MatchArm
+}
+
+func NewMatch(node cst.Node, value Expr, arms []*MatchArm) *Match {
+	if value == nil && arms == nil {
+		return nil
+	}
+
+	m := &Match{
+		cst:   node,
+		Value: value,
+		Arms:  arms,
+	}
+
+	if value != nil {
+		value.SetParent(m)
+	}
+	for _, child := range arms {
+		child.SetParent(m)
+	}
+
+	return m
+}
+
+func (m *Match) Cst() *cst.Node {
+	if m.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &m.cst
+}
+
+func (m *Match) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (m *Match) Parent() Node {
+	return m.parent
+}
+
+func (m *Match) SetParent(parent Node) {
+	if parent != nil && m.parent != nil {
+		panic("ast.Match.SetParent() - Parent is already set")
+	}
+
+	m.parent = parent
+}
+
+func (m *Match) AcceptChildren(visitor Visitor) {
+	if m.Value != nil {
+		visitor.VisitNode(m.Value)
+	}
+	for _, child := range m.Arms {
+		visitor.VisitNode(child)
+	}
+}
+
+func (m *Match) Clone() Node {
+	m2 := &Match{
+		cst: m.cst,
+	}
+
+	if m.Value != nil {
+		m2.Value = m.Value.Clone().(Expr)
+		m2.Value.SetParent(m2)
+	}
+	m2.Arms = make([]*MatchArm, len(m.Arms))
+	for i, child := range m2.Arms {
+		m2.Arms[i] = child.Clone().(*MatchArm)
+		m2.Arms[i].SetParent(m2)
+	}
+
+	return m2
+}
+
+func (m *Match) String() string {
+	return ""
+}
+
+func (m *Match) AcceptStmt(visitor StmtVisitor) {
+	visitor.VisitMatch(m)
+}
+
+// Defer
+
+type Defer struct {
+	cst    cst.Node
+	parent Node
+
+	Stmt Stmt
+}
+
+func NewDefer(node cst.Node, stmt Stmt) *Defer {
+	if stmt == nil {
+		return nil
+	}
+
+	d := &Defer{
+		cst:  node,
+		Stmt: stmt,
+	}
+
+	if stmt != nil {
+		stmt.SetParent(d)
+	}
+
+	return d
+}
+
+func (d *Defer) Cst() *cst.Node {
+	if d.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &d.cst
+}
+
+func (d *Defer) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (d *Defer) Parent() Node {
+	return d.parent
+}
+
+func (d *Defer) SetParent(parent Node) {
+	if parent != nil && d.parent != nil {
+		panic("ast.Defer.SetParent() - Parent is already set")
+	}
+
+	d.parent = parent
+}
+
+func (d *Defer) AcceptChildren(visitor Visitor) {
+	if d.Stmt != nil {
+		visitor.VisitNode(d.Stmt)
+	}
+}
+
+func (d *Defer) Clone() Node {
+	d2 := &Defer{
+		cst: d.cst,
+	}
+
+	if d.Stmt != nil {
+		d2.Stmt = d.Stmt.Clone().(Stmt)
+		d2.Stmt.SetParent(d2)
+	}
+
+	return d2
+}
+
+func (d *Defer) String() string {
+	return ""
+}
+
+func (d *Defer) AcceptStmt(visitor StmtVisitor) {
+	visitor.VisitDefer(d)
+}
+
 // Return
 
 type Return struct {
@@ -668,11 +981,18 @@ func (r *Return) AcceptStmt(visitor StmtVisitor) {
 type Break struct {
 	cst    cst.Node
 	parent Node
+
+	Label *Token
 }
 
-func NewBreak(node cst.Node) *Break {
+func NewBreak(node cst.Node, label *Token) *Break {
 	b := &Break{
-		cst: node,
+		cst:   node,
+		Label: label,
+	}
+
+	if label != nil {
+		label.SetParent(b)
 	}
 
 	return b
@@ -703,6 +1023,9 @@ func (b *Break) SetParent(parent Node) {
 }
 
 func (b *Break) AcceptChildren(visitor Visitor) {
+	if b.Label != nil {
+		visitor.VisitNode(b.Label)
+	}
 }
 
 func (b *Break) Clone() Node {
@@ -710,6 +1033,11 @@ func (b *Break) Clone() Node {
 		cst: b.cst,
 	}
 
+	if b.Label != nil {
+		b2.Label = b.Label.Clone().(*Token)
+		b2.Label.SetParent(b2)
+	}
+
 	return b2
 }
 
@@ -726,11 +1054,18 @@ func (b *Break) AcceptStmt(visitor StmtVisitor) {
 type Continue struct {
 	cst    cst.Node
 	parent Node
+
+	Label *Token
 }
 
-func NewContinue(node cst.Node) *Continue {
+func NewContinue(node cst.Node, label *Token) *Continue {
 	c := &Continue{
-		cst: node,
+		cst:   node,
+		Label: label,
+	}
+
+	if label != nil {
+		label.SetParent(c)
 	}
 
 	return c
@@ -761,6 +1096,9 @@ func (c *Continue) SetParent(parent Node) {
 }
 
 func (c *Continue) AcceptChildren(visitor Visitor) {
+	if c.Label != nil {
+		visitor.VisitNode(c.Label)
+	}
 }
 
 func (c *Continue) Clone() Node {
@@ -768,6 +1106,11 @@ func (c *Continue) Clone() Node {
 		cst: c.cst,
 	}
 
+	if c.Label != nil {
+		c2.Label = c.Label.Clone().(*Token)
+		c2.Label.SetParent(c2)
+	}
+
 	return c2
 }
 
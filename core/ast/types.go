@@ -11,6 +11,7 @@ type TypeVisitor interface {
 	VisitPrimitive(type_ *Primitive)
 	VisitPointer(type_ *Pointer)
 	VisitArray(type_ *Array)
+	VisitSlice(type_ *Slice)
 	VisitResolvable(type_ *Resolvable)
 	VisitGeneric(type_ *Generic)
 	VisitStruct(type_ *Struct)
@@ -190,23 +191,28 @@ type Array struct {
 	parent Node
 
 	Base  Type
+	Size  Expr
 	Count uint32
+	State EvalState
 }
 
-func NewArray(node cst.Node, base Type, count uint32) *Array {
-	if base == nil {
+func NewArray(node cst.Node, base Type, size Expr) *Array {
+	if base == nil && size == nil {
 		return nil
 	}
 
 	a := &Array{
-		cst:   node,
-		Base:  base,
-		Count: count,
+		cst:  node,
+		Base: base,
+		Size: size,
 	}
 
 	if base != nil {
 		base.SetParent(a)
 	}
+	if size != nil {
+		size.SetParent(a)
+	}
 
 	return a
 }
@@ -239,18 +245,26 @@ func (a *Array) AcceptChildren(visitor Visitor) {
 	if a.Base != nil {
 		visitor.VisitNode(a.Base)
 	}
+	if a.Size != nil {
+		visitor.VisitNode(a.Size)
+	}
 }
 
 func (a *Array) Clone() Node {
 	a2 := &Array{
 		cst:   a.cst,
 		Count: a.Count,
+		State: a.State,
 	}
 
 	if a.Base != nil {
 		a2.Base = a.Base.Clone().(Type)
 		a2.Base.SetParent(a2)
 	}
+	if a.Size != nil {
+		a2.Size = a.Size.Clone().(Expr)
+		a2.Size.SetParent(a2)
+	}
 
 	return a2
 }
@@ -267,6 +281,87 @@ func (a *Array) Resolved() Type {
 	return a
 }
 
+// Slice
+
+type Slice struct {
+	cst    cst.Node
+	parent Node
+
+	Base Type
+}
+
+func NewSlice(node cst.Node, base Type) *Slice {
+	if base == nil {
+		return nil
+	}
+
+	s := &Slice{
+		cst:  node,
+		Base: base,
+	}
+
+	if base != nil {
+		base.SetParent(s)
+	}
+
+	return s
+}
+
+func (s *Slice) Cst() *cst.Node {
+	if s.cst.Kind == cst.UnknownNode {
+		return nil
+	}
+
+	return &s.cst
+}
+
+func (s *Slice) Token() scanner.Token {
+	return scanner.Token{}
+}
+
+func (s *Slice) Parent() Node {
+	return s.parent
+}
+
+func (s *Slice) SetParent(parent Node) {
+	if parent != nil && s.parent != nil {
+		panic("ast.Slice.SetParent() - Parent is already set")
+	}
+
+	s.parent = parent
+}
+
+func (s *Slice) AcceptChildren(visitor Visitor) {
+	if s.Base != nil {
+		visitor.VisitNode(s.Base)
+	}
+}
+
+func (s *Slice) Clone() Node {
+	s2 := &Slice{
+		cst: s.cst,
+	}
+
+	if s.Base != nil {
+		s2.Base = s.Base.Clone().(Type)
+		s2.Base.SetParent(s2)
+	}
+
+	return s2
+}
+
+func (s *Slice) String() string {
+	return ""
+}
+
+func (s *Slice) AcceptType(visitor TypeVisitor) {
+	visitor.VisitSlice(s)
+}
+
+func (s *Slice) Resolved() Type {
+	return s
+}
+
 // Resolvable
 
 type Resolvable struct {
@@ -374,18 +469,24 @@ type Generic struct {
 	cst    cst.Node
 	parent Node
 
-	Name scanner.Token
-	Type Type
+	Name  scanner.Token
+	Bound Type
+	Type  Type
 }
 
-func NewGeneric(node cst.Node, name scanner.Token) *Generic {
-	if name.IsEmpty() {
+func NewGeneric(node cst.Node, name scanner.Token, bound Type) *Generic {
+	if name.IsEmpty() && bound == nil {
 		return nil
 	}
 
 	g := &Generic{
-		cst:  node,
-		Name: name,
+		cst:   node,
+		Name:  name,
+		Bound: bound,
+	}
+
+	if bound != nil {
+		bound.SetParent(g)
 	}
 
 	return g
@@ -416,6 +517,9 @@ func (g *Generic) SetParent(parent Node) {
 }
 
 func (g *Generic) AcceptChildren(visitor Visitor) {
+	if g.Bound != nil {
+		visitor.VisitNode(g.Bound)
+	}
 }
 
 func (g *Generic) Clone() Node {
@@ -425,6 +529,10 @@ func (g *Generic) Clone() Node {
 		Type: g.Type,
 	}
 
+	if g.Bound != nil {
+		g2.Bound = g.Bound.Clone().(Type)
+		g2.Bound.SetParent(g2)
+	}
 	if g.Type != nil {
 		g2.Type = g.Type.Clone().(Type)
 		g2.Type.SetParent(g2)
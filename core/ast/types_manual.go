@@ -58,6 +58,16 @@ func (a *Array) Equals(other Type) bool {
 	return false
 }
 
+// Slice
+
+func (s *Slice) Equals(other Type) bool {
+	if s2, ok := As[*Slice](other); ok {
+		return typesEquals(s.Base, s2.Base)
+	}
+
+	return false
+}
+
 // Resolvable
 
 func (r *Resolvable) Equals(other Type) bool {
@@ -209,6 +219,11 @@ func (t *typePrinter) VisitArray(type_ *Array) {
 	type_.AcceptChildren(t)
 }
 
+func (t *typePrinter) VisitSlice(type_ *Slice) {
+	t.str += "[]"
+	type_.AcceptChildren(t)
+}
+
 func (t *typePrinter) VisitResolvable(type_ *Resolvable) {
 	for i, part := range type_.Parts {
 		if i > 0 {
@@ -217,6 +232,20 @@ func (t *typePrinter) VisitResolvable(type_ *Resolvable) {
 
 		t.str += part.String()
 	}
+
+	if len(type_.GenericArgs) != 0 {
+		t.str += "!["
+
+		for i, arg := range type_.GenericArgs {
+			if i > 0 {
+				t.str += ", "
+			}
+
+			t.VisitNode(arg)
+		}
+
+		t.str += "]"
+	}
 }
 
 func (t *typePrinter) VisitGeneric(type_ *Generic) {
@@ -276,6 +305,20 @@ func (t *typePrinter) VisitNode(node Node) {
 			t.str += "]"
 		}
 
+	case *SpecializedInterface:
+		t.VisitNode(type_.Underlying())
+		t.str += "!["
+
+		for i, type_ := range type_.Types {
+			if i > 0 {
+				t.str += ", "
+			}
+
+			t.VisitNode(Resolved(type_))
+		}
+
+		t.str += "]"
+
 	case FuncType:
 		if t.options.FuncNames && type_.Underlying().Name != nil {
 			t.str += type_.Underlying().Name.String()
@@ -0,0 +1,95 @@
+package ast
+
+import "fireball/core/scanner"
+
+// Visible is implemented by declarations which are private to their namespace unless they are marked with 'pub'.
+type Visible interface {
+	Node
+
+	IsPublic() bool
+}
+
+func (s *Struct) IsPublic() bool {
+	return isPublic(s)
+}
+
+func (e *Enum) IsPublic() bool {
+	return isPublic(e)
+}
+
+func (i *Interface) IsPublic() bool {
+	return isPublic(i)
+}
+
+func (f *Func) IsPublic() bool {
+	switch parent := f.Parent().(type) {
+	case *Interface:
+		// Interface methods are as visible as the interface itself
+		return true
+
+	case *Impl:
+		// Methods implementing an interface can be called by anyone holding the interface
+		if parent.Implements != nil {
+			return true
+		}
+	}
+
+	return isPublic(f)
+}
+
+func (v *GlobalVar) IsPublic() bool {
+	return isPublic(v)
+}
+
+func (c *Const) IsPublic() bool {
+	return isPublic(c)
+}
+
+func (f *Field) IsPublic() bool {
+	// Fields of enum cases are as visible as the enum itself
+	if _, ok := f.Parent().(*EnumCase); ok {
+		return true
+	}
+
+	return isPublic(f)
+}
+
+func isPublic(node Node) bool {
+	// Nodes created by the compiler don't have a source and are always public
+	if node.Cst() == nil {
+		return true
+	}
+
+	return node.Cst().Contains(scanner.Pub)
+}
+
+// IsAccessible returns true if the declaration can be used from the node, private declarations are only accessible
+// from files inside the same namespace.
+func IsAccessible(decl Visible, from Node) bool {
+	if IsNil(decl) || decl.IsPublic() {
+		return true
+	}
+
+	declFile := GetParent[*File](decl)
+	fromFile := GetParent[*File](from)
+
+	if declFile == nil || fromFile == nil || declFile.Namespace == nil || fromFile.Namespace == nil {
+		return true
+	}
+
+	return sameNamespace(declFile.Namespace.Name, fromFile.Namespace.Name)
+}
+
+func sameNamespace(a, b *NamespaceName) bool {
+	if a == nil || b == nil || len(a.Parts) != len(b.Parts) {
+		return false
+	}
+
+	for i, part := range a.Parts {
+		if part.String() != b.Parts[i].String() {
+			return false
+		}
+	}
+
+	return true
+}
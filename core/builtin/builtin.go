@@ -0,0 +1,14 @@
+package builtin
+
+import _ "embed"
+
+// Namespace of the builtin source files, available in every project.
+const Namespace = "Core"
+
+//go:embed core.fb
+var core string
+
+// Files maps the paths of builtin source files to their contents.
+var Files = map[string]string{
+	"core.fb": core,
+}
@@ -1,6 +1,10 @@
 package checker
 
-import "fireball/core/ast"
+import (
+	"fireball/core/ast"
+	"fireball/core/common"
+	"fireball/core/scanner"
+)
 
 func (c *checker) visitStructAttribute(attribute *ast.Attribute) {
 	if attribute.Name == nil {
@@ -13,11 +17,117 @@ func (c *checker) visitStructAttribute(attribute *ast.Attribute) {
 			c.error(attribute.Name, "C doesn't have any arguments")
 		}
 
+	case "Packed":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "Packed doesn't have any arguments")
+		}
+
+	case "Align":
+		c.visitAlignAttribute(attribute)
+
+	case "Union":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "Union doesn't have any arguments")
+		}
+
 	default:
 		c.error(attribute.Name, "Struct attribute with this name doesn't exist")
 	}
 }
 
+func (c *checker) visitFieldAttribute(attribute *ast.Attribute) {
+	if attribute.Name == nil {
+		return
+	}
+
+	switch attribute.Name.String() {
+	case "Packed":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "Packed doesn't have any arguments")
+		}
+
+	case "Align":
+		c.visitAlignAttribute(attribute)
+
+	default:
+		c.error(attribute.Name, "Field attribute with this name doesn't exist")
+	}
+}
+
+func (c *checker) visitStaticFieldAttribute(attribute *ast.Attribute) {
+	if attribute.Name == nil {
+		return
+	}
+
+	switch attribute.Name.String() {
+	case "ThreadLocal":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "ThreadLocal doesn't have any arguments")
+		}
+
+	default:
+		c.error(attribute.Name, "Static field attribute with this name doesn't exist")
+	}
+}
+
+func (c *checker) visitGlobalVarAttribute(attribute *ast.Attribute) {
+	if attribute.Name == nil {
+		return
+	}
+
+	switch attribute.Name.String() {
+	case "ThreadLocal":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "ThreadLocal doesn't have any arguments")
+		}
+
+	default:
+		c.error(attribute.Name, "Variable attribute with this name doesn't exist")
+	}
+}
+
+func (c *checker) visitAlignAttribute(attribute *ast.Attribute) {
+	if len(attribute.Args) != 1 {
+		c.error(attribute.Name, "Align attribute needs to have exactly one argument")
+		return
+	}
+
+	align, ok := attribute.NumberArg(0)
+
+	if !ok || align == 0 || align&(align-1) != 0 {
+		c.error(attribute.Args[0], "Alignment needs to be a power of two")
+	}
+}
+
+func (c *checker) checkStringArgs(attribute *ast.Attribute) {
+	for _, arg := range attribute.Args {
+		if arg.Token().Kind != scanner.String {
+			c.error(arg, "%s attribute argument needs to be a string", attribute.Name)
+		}
+	}
+}
+
+func (c *checker) visitEnumAttribute(attribute *ast.Attribute) {
+	if attribute.Name == nil {
+		return
+	}
+
+	switch attribute.Name.String() {
+	case "C":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "C doesn't have any arguments")
+		}
+
+	case "Flags":
+		if len(attribute.Args) > 0 {
+			c.error(attribute.Name, "Flags doesn't have any arguments")
+		}
+
+	default:
+		c.error(attribute.Name, "Enum attribute with this name doesn't exist")
+	}
+}
+
 func (c *checker) visitFuncAttribute(decl *ast.Func, attribute *ast.Attribute) {
 	if attribute.Name == nil {
 		return
@@ -29,11 +139,15 @@ func (c *checker) visitFuncAttribute(decl *ast.Func, attribute *ast.Attribute) {
 			c.error(attribute.Name, "Extern attribute can only have one argument")
 		}
 
+		c.checkStringArgs(attribute)
+
 	case "Intrinsic":
 		if len(attribute.Args) > 1 {
 			c.error(attribute.Name, "Intrinsic attribute can only have one argument")
 		}
 
+		c.checkStringArgs(attribute)
+
 		c.visitIntrinsic(decl, attribute)
 
 	case "Inline":
@@ -46,6 +160,8 @@ func (c *checker) visitFuncAttribute(decl *ast.Func, attribute *ast.Attribute) {
 			c.error(attribute.Name, "Test attribute can only have one argument")
 		}
 
+		c.checkStringArgs(attribute)
+
 		if !ast.IsPrimitive(decl.Returns(), ast.Bool) {
 			c.error(attribute.Name, "Tests need to return a boolean")
 		}
@@ -66,13 +182,13 @@ func (c *checker) visitIntrinsic(decl *ast.Func, attribute *ast.Attribute) {
 	name := ""
 
 	if len(attribute.Args) > 0 {
-		arg := attribute.Args[0]
-		if arg == nil {
+		arg, ok := attribute.StringArg(0)
+		if !ok {
 			return
 		}
 
-		token = arg
-		name = arg.String()[1 : len(arg.String())-1]
+		token = attribute.Args[0]
+		name = arg
 	} else {
 		if decl.Name == nil {
 			return
@@ -103,6 +219,24 @@ func (c *checker) visitIntrinsic(decl *ast.Func, attribute *ast.Attribute) {
 
 	case "memset":
 		valid = isExactIntrinsic(decl, ast.Void, ast.U8, ast.U32)
+
+	case "atomic_load":
+		valid = c.isAtomicIntrinsic(decl, 0, anyAtomic, nil)
+
+	case "atomic_store":
+		valid = c.isAtomicIntrinsic(decl, 1, anyAtomic, &ast.Primitive{Kind: ast.Void})
+
+	case "atomic_exchange":
+		valid = c.isAtomicIntrinsic(decl, 1, anyAtomic, nil)
+
+	case "atomic_add", "atomic_sub", "atomic_and", "atomic_or", "atomic_xor":
+		valid = c.isAtomicIntrinsic(decl, 1, integerAtomic, nil)
+
+	case "atomic_compare_exchange":
+		valid = c.isAtomicIntrinsic(decl, 2, integerAtomic|pointerAtomic, &ast.Primitive{Kind: ast.Bool})
+
+	case "fence":
+		valid = len(decl.Params) == 1 && c.isMemoryOrder(decl.Params[0].Type) && ast.IsPrimitive(decl.Returns(), ast.Void)
 	}
 
 	if !valid {
@@ -187,3 +321,133 @@ func isExactIntrinsic(decl *ast.Func, params ...ast.PrimitiveKind) bool {
 
 	return true
 }
+
+type atomicPredicate uint8
+
+const (
+	integerAtomic atomicPredicate = 1 << iota
+	floatingAtomic
+	pointerAtomic
+
+	anyAtomic = integerAtomic | floatingAtomic | pointerAtomic
+)
+
+// isAtomicIntrinsic checks the signature of atomic intrinsics, they take a pointer, a number of values of the pointee
+// type and a memory order. A nil return type means the intrinsic returns the pointee type.
+func (c *checker) isAtomicIntrinsic(decl *ast.Func, valueCount int, predicate atomicPredicate, returns ast.Type) bool {
+	if len(decl.Params) != valueCount+2 {
+		return false
+	}
+
+	pointer, ok := ast.As[*ast.Pointer](decl.Params[0].Type)
+	if !ok || !isAtomicType(pointer.Pointee, predicate) {
+		return false
+	}
+
+	for _, param := range decl.Params[1 : valueCount+1] {
+		if param.Type == nil || !param.Type.Equals(pointer.Pointee) {
+			return false
+		}
+	}
+
+	if !c.isMemoryOrder(decl.Params[valueCount+1].Type) {
+		return false
+	}
+
+	if returns == nil {
+		returns = pointer.Pointee
+	}
+
+	return decl.Returns().Equals(returns)
+}
+
+func isAtomicType(type_ ast.Type, predicate atomicPredicate) bool {
+	if v, ok := ast.As[*ast.Primitive](type_); ok {
+		if predicate&integerAtomic != 0 && ast.IsInteger(v.Kind) {
+			return true
+		}
+
+		if predicate&floatingAtomic != 0 && ast.IsFloating(v.Kind) {
+			return true
+		}
+	}
+
+	if _, ok := ast.As[*ast.Pointer](type_); ok && predicate&pointerAtomic != 0 {
+		return true
+	}
+
+	return false
+}
+
+func (c *checker) isMemoryOrder(type_ ast.Type) bool {
+	memoryOrder := common.GetBuiltinType(c.root, "MemoryOrder")
+	return memoryOrder != nil && type_ != nil && type_.Equals(memoryOrder)
+}
+
+// getMemoryOrder returns the memory order case of the last argument of a call to an atomic intrinsic, it needs to be
+// known at compile time and supported by the operation. Default values can belong to a file that is not checked yet so
+// they are evaluated from their names and are not reported.
+func (c *checker) getMemoryOrder(intrinsic string, arg ast.Expr, explicit bool) *ast.EnumCase {
+	memoryOrder, _ := ast.As[*ast.Enum](common.GetBuiltinType(c.root, "MemoryOrder"))
+	if memoryOrder == nil {
+		return nil
+	}
+
+	if named, ok := arg.(*ast.NamedArg); ok {
+		if arg = named.Value; arg == nil {
+			return nil
+		}
+	}
+
+	var value ast.Constant
+
+	if explicit {
+		if arg.Result().Kind != ast.ValueResultKind {
+			return nil
+		}
+
+		value = c.evaluator.getConstant(arg, memoryOrder)
+	} else {
+		value = newEvaluator(c.reporter, nil, false).getConstant(arg, memoryOrder)
+	}
+
+	var case_ *ast.EnumCase
+
+	if value.Kind == ast.IntConstant {
+		for _, enumCase := range memoryOrder.Cases {
+			if enumCase.ActualValue == value.Int && enumCase.Name != nil {
+				case_ = enumCase
+				break
+			}
+		}
+	}
+
+	if !explicit {
+		return case_
+	}
+
+	if case_ == nil {
+		c.error(arg, "Memory order needs to be a constant")
+		return nil
+	}
+
+	order := case_.Name.String()
+	valid := true
+
+	switch intrinsic {
+	case "atomic_load":
+		valid = order != "Release" && order != "AcqRel"
+
+	case "atomic_store":
+		valid = order != "Acquire" && order != "AcqRel"
+
+	case "fence":
+		valid = order != "Relaxed"
+	}
+
+	if !valid {
+		c.error(arg, "Memory order '%s' can't be used with '%s'", order, intrinsic)
+	}
+
+	return case_
+}
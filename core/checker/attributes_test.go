@@ -0,0 +1,78 @@
+package checker_test
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStringAttributeArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		message string
+	}{
+		{
+			name:    "Extern",
+			text:    "namespace Tests;\n\n#[Extern(5)]\nfunc puts(str *u8) i32\n",
+			message: "Extern attribute argument needs to be a string",
+		},
+		{
+			name:    "Intrinsic",
+			text:    "namespace Tests;\n\n#[Intrinsic(5)]\nfunc sqrt(x f64) f64\n",
+			message: "Intrinsic attribute argument needs to be a string",
+		},
+		{
+			name:    "Test",
+			text:    "namespace Tests;\n\n#[Test(5)]\nfunc test() bool {\n    return true;\n}\n",
+			message: "Test attribute argument needs to be a string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			messages := getMessages(t, test.text)
+
+			if !slices.Contains(messages, test.message) {
+				t.Errorf("expected '%s' but got %q", test.message, messages)
+			}
+		})
+	}
+}
+
+func TestStringAttributeArgsValid(t *testing.T) {
+	text := "namespace Tests;\n\n#[Extern(\"puts\")]\nfunc print(str *u8) i32\n\n#[Test(\"name\")]\nfunc test() bool {\n    return true;\n}\n"
+
+	if messages := getMessages(t, text); len(messages) > 0 {
+		t.Errorf("expected no diagnostics but got %q", messages)
+	}
+}
+
+func TestMemoryOrderArgs(t *testing.T) {
+	text := `namespace Tests;
+
+using Core;
+
+const ACQUIRE MemoryOrder = MemoryOrder.Acquire;
+const RELEASE MemoryOrder = MemoryOrder.Release;
+
+#[Intrinsic("atomic_load")]
+func load(ptr *i32, order MemoryOrder = ACQUIRE) i32
+
+func main() {
+    var value = 0;
+    var order = MemoryOrder.Relaxed;
+
+    load(&value);
+    load(&value, ACQUIRE);
+    load(&value, order: ACQUIRE);
+    load(&value, order);
+    load(&value, RELEASE);
+}
+`
+
+	messages := getMessages(t, text)
+
+	if countMessages(messages, "Memory order needs to be a constant") != 1 || countMessages(messages, "Memory order 'Release' can't be used with 'atomic_load'") != 1 || len(messages) != 2 {
+		t.Errorf("expected a non-constant and an invalid memory order but got %q", messages)
+	}
+}
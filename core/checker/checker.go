@@ -4,22 +4,34 @@ import (
 	"fireball/core"
 	"fireball/core/ast"
 	"fireball/core/common"
+	"fireball/core/scanner"
 	"fireball/core/utils"
 	"fmt"
+	"slices"
 )
 
 type checker struct {
 	scopes    []scope
 	variables []variable
 
+	// Variables of the enclosing functions when checking a closure body
+	closureVariables []variable
+
 	function *ast.Func
 
-	loopDepth int
+	// Labels of the enclosing loops, nil for loops without a label
+	loops      []*ast.Token
+	deferDepth int
+
+	// Scope depths of the enclosing loops, unlike loops it is kept inside deferred statements
+	loopDepths []int
 
 	typeExpr ast.Expr
 
-	reporter utils.Reporter
-	resolver ast.Resolver
+	reporter  utils.Reporter
+	root      ast.RootResolver
+	resolver  ast.Resolver
+	evaluator *evaluator
 }
 
 type scope struct {
@@ -35,14 +47,47 @@ type variable struct {
 
 	param bool
 	used  bool
+
+	depth   int
+	closure closureInfo
+}
+
+// closureInfo describes the local variables that a closure value stored in a variable refers to.
+type closureInfo struct {
+	captures bool
+
+	// The value comes from a caller and can contain closures that capture local variables of the caller
+	outer bool
+
+	refDepth int
+	ref      *ast.Token
+
+	// Scope depth of the innermost loop the closure is created in, its environment is reused by every iteration
+	loopDepth int
+}
+
+func (i closureInfo) merge(other closureInfo) closureInfo {
+	i.captures = i.captures || other.captures
+	i.outer = i.outer || other.outer
+
+	if other.refDepth > i.refDepth {
+		i.refDepth = other.refDepth
+		i.ref = other.ref
+	}
+
+	i.loopDepth = max(i.loopDepth, other.loopDepth)
+
+	return i
 }
 
 func Check(reporter utils.Reporter, root ast.RootResolver, file *ast.File) {
 	resolver := ast.NewCombinedResolver(root)
 
 	c := &checker{
-		reporter: reporter,
-		resolver: resolver,
+		reporter:  reporter,
+		root:      root,
+		resolver:  resolver,
+		evaluator: newEvaluator(reporter, file, true),
 	}
 
 	for _, decl := range file.Decls {
@@ -85,6 +130,16 @@ func (c *checker) getVariable(name string) *variable {
 	return nil
 }
 
+func (c *checker) isUncapturedVariable(name *ast.Token) bool {
+	for i := len(c.closureVariables) - 1; i >= 0; i-- {
+		if c.closureVariables[i].name.String() == name.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *checker) addVariable(name *ast.Token, type_ ast.Type, node ast.Node) *variable {
 	if name == nil || type_ == nil {
 		return nil
@@ -94,6 +149,7 @@ func (c *checker) addVariable(name *ast.Token, type_ ast.Type, node ast.Node) *v
 		name:  name,
 		type_: type_,
 		node:  node,
+		depth: len(c.scopes),
 	})
 
 	c.peekScope().variableCount++
@@ -128,6 +184,212 @@ func (c *checker) peekScope() *scope {
 	return &c.scopes[len(c.scopes)-1]
 }
 
+// Closures
+
+// getClosureInfo returns which local variables the closure values contained in the expression refer to.
+func (c *checker) getClosureInfo(expr ast.Expr) closureInfo {
+	var info closureInfo
+
+	if ast.IsNil(expr) || !mayHoldClosure(expr.Result().Type, nil) {
+		return info
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Closure:
+		for _, capture := range expr.Captures {
+			if capture.Name == nil || capture.ActualType == nil {
+				continue
+			}
+
+			variable := c.getVariable(capture.Name.String())
+			if variable == nil {
+				continue
+			}
+
+			info.captures = true
+			info = info.merge(variable.closure)
+
+			if len(c.loopDepths) > 0 {
+				info.loopDepth = max(info.loopDepth, c.loopDepths[len(c.loopDepths)-1])
+			}
+
+			if capture.Reference && variable.depth > info.refDepth {
+				info.refDepth = variable.depth
+				info.ref = capture.Name
+			}
+		}
+
+	case *ast.Paren:
+		info = c.getClosureInfo(expr.Expr)
+
+	case *ast.Identifier:
+		if variable := c.getLocalVariable(expr); variable != nil {
+			info = variable.closure
+		}
+
+	case *ast.Unary:
+		if expr.Prefix && (expr.Operator.Token().Kind == scanner.Ampersand || expr.Operator.Token().Kind == scanner.Star) {
+			info = c.getClosureInfo(expr.Value)
+		}
+
+	case *ast.Member:
+		info = c.getClosureInfo(expr.Value)
+
+	case *ast.Index:
+		info = c.getClosureInfo(expr.Value)
+
+	case *ast.Slicing:
+		info = c.getClosureInfo(expr.Value)
+
+	case *ast.Cast:
+		info = c.getClosureInfo(expr.Value)
+
+	case *ast.Call:
+		// The result of a call can contain any closure passed to it
+		info = c.getClosureInfo(expr.Callee)
+
+		for _, arg := range expr.Args {
+			if named, ok := arg.(*ast.NamedArg); ok {
+				arg = named.Value
+			}
+
+			info = info.merge(c.getClosureInfo(arg))
+		}
+
+	case *ast.StructInitializer:
+		for _, field := range expr.Fields {
+			if field.Value != nil {
+				info = info.merge(c.getClosureInfo(field.Value))
+			}
+		}
+
+	case *ast.ArrayInitializer:
+		for _, value := range expr.Values {
+			info = info.merge(c.getClosureInfo(value))
+		}
+	}
+
+	return info
+}
+
+// checkClosureAssignment checks that a closure value which captures local variables does not outlive them when
+// assigned to the target expression.
+func (c *checker) checkClosureAssignment(target ast.Expr, value ast.Expr) {
+	info := c.getClosureInfo(value)
+	if !info.captures && !info.outer {
+		return
+	}
+
+	// Find the local variable that contains the target
+	var variable *variable
+
+	for {
+		switch expr := target.(type) {
+		case *ast.Paren:
+			target = expr.Expr
+			continue
+
+		case *ast.Member:
+			if _, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); !ok {
+				target = expr.Value
+				continue
+			}
+
+		case *ast.Index:
+			if _, ok := ast.As[*ast.Array](expr.Value.Result().Type); ok {
+				target = expr.Value
+				continue
+			}
+
+		case *ast.Identifier:
+			variable = c.getLocalVariable(expr)
+		}
+
+		break
+	}
+
+	if variable == nil {
+		if info.captures {
+			c.error(value, "A closure that captures local variables cannot escape the function")
+		} else {
+			c.error(value, "A closure passed to the function cannot escape it since it can capture local variables of the caller")
+		}
+
+		return
+	}
+
+	if info.refDepth > variable.depth {
+		c.error(value, "Closure captures '%s' by reference and cannot outlive it", info.ref)
+		return
+	}
+
+	if info.loopDepth > 0 && variable.depth <= info.loopDepth {
+		c.error(value, "A closure that captures local variables cannot outlive the loop iteration that creates it")
+		return
+	}
+
+	variable.closure = variable.closure.merge(info)
+}
+
+// mayHoldClosure returns true if values of the type can contain a function value, directly or through pointers.
+func mayHoldClosure(type_ ast.Type, visited []ast.Type) bool {
+	if type_ == nil {
+		return false
+	}
+
+	type_ = ast.Resolved(type_)
+
+	if slices.Contains(visited, type_) {
+		return false
+	}
+
+	visited = append(visited, type_)
+
+	switch type_ := type_.(type) {
+	case ast.FuncType:
+		return true
+
+	case *ast.Pointer:
+		return mayHoldClosure(type_.Pointee, visited)
+
+	case *ast.Array:
+		return mayHoldClosure(type_.Base, visited)
+
+	case *ast.Slice:
+		return mayHoldClosure(type_.Base, visited)
+
+	case ast.StructType:
+		for i := 0; i < type_.FieldCount(); i++ {
+			if mayHoldClosure(type_.FieldIndex(i).Type(), visited) {
+				return true
+			}
+		}
+
+	case *ast.Enum:
+		for _, case_ := range type_.Cases {
+			for _, field := range case_.Fields {
+				if mayHoldClosure(field.Type(), visited) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *checker) getLocalVariable(expr *ast.Identifier) *variable {
+	if expr.Name == nil || expr.Result().Kind != ast.ValueResultKind {
+		return nil
+	}
+
+	if _, ok := expr.Result().Value().(*ast.GlobalVar); ok {
+		return nil
+	}
+
+	return c.getVariable(expr.Name.String())
+}
+
 // Other
 
 func (c *checker) expectPrimitiveValue(expr ast.Expr, kind ast.PrimitiveKind) {
@@ -171,6 +433,13 @@ func (c *checker) VisitNode(node ast.Node) {
 	case ast.Expr:
 		node.AcceptExpr(c)
 
+	case *ast.Resolvable:
+		if s, ok := node.Type.(*ast.SpecializedStruct); ok {
+			c.checkGenericArgs(node, s.Underlying().GenericParams, node.GenericArgs)
+		}
+
+		node.AcceptChildren(c)
+
 	default:
 		node.AcceptChildren(c)
 	}
@@ -0,0 +1,254 @@
+package checker_test
+
+import (
+	"fireball/core/workspace"
+	"slices"
+	"testing"
+)
+
+// getMessages checks a single file and returns the messages of its diagnostics.
+func getMessages(t *testing.T, text string) []string {
+	project := workspace.NewEmptyProject(t.TempDir(), "Tests")
+
+	file := project.GetOrCreateFile("main.fb")
+	file.SetText(text, true)
+	file.EnsureChecked()
+
+	var messages []string
+
+	for _, diagnostic := range file.Diagnostics() {
+		messages = append(messages, diagnostic.Message)
+	}
+
+	return messages
+}
+
+func countMessages(messages []string, message string) int {
+	count := 0
+
+	for _, m := range messages {
+		if m == message {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestClosureOutlivesLoopIteration(t *testing.T) {
+	text := `namespace Tests;
+
+func test() {
+    var fs [3]fn () i32;
+
+    for (var i = 0; i < 3; i++) {
+        fs[i] = fn [i] () i32 { return i; };
+    }
+
+    fs[0]();
+}
+`
+
+	message := "A closure that captures local variables cannot outlive the loop iteration that creates it"
+
+	if messages := getMessages(t, text); !slices.Contains(messages, message) {
+		t.Errorf("expected '%s' but got %q", message, messages)
+	}
+}
+
+func TestClosureInsideLoopIteration(t *testing.T) {
+	text := `namespace Tests;
+
+func test() i32 {
+    var sum = 0;
+
+    while (sum < 10) {
+        var f = fn [sum] () i32 { return sum + 1; };
+        var g = f;
+
+        g = f;
+        sum += g();
+    }
+
+    return sum;
+}
+`
+
+	if messages := getMessages(t, text); len(messages) > 0 {
+		t.Errorf("expected no diagnostics but got %q", messages)
+	}
+}
+
+func TestClosureEscapesThroughMember(t *testing.T) {
+	text := `namespace Tests;
+
+struct Box {
+    f fn () i32,
+}
+
+func member() Box {
+    var x = 5;
+    var c = Box { f: fn [x] () i32 { return x; } };
+
+    return Box { f: c.f };
+}
+
+func index() fn () i32 {
+    var x = 5;
+    var a = [ fn [x] () i32 { return x; } ];
+
+    return a[0];
+}
+
+func pointer() Box {
+    var x = 5;
+    var c = Box { f: fn [x] () i32 { return x; } };
+    var p = &c;
+
+    return *p;
+}
+`
+
+	message := "A closure that captures local variables cannot escape the function"
+	count := countMessages(getMessages(t, text), message)
+
+	if count != 3 {
+		t.Errorf("expected '%s' to be reported 3 times but got %d", message, count)
+	}
+}
+
+func TestClosureParameterEscapes(t *testing.T) {
+	text := `namespace Tests;
+
+struct Box {
+    f fn () i32,
+}
+
+var saved fn () i32;
+var savedBox Box;
+
+func keep(f fn () i32) {
+    saved = f;
+}
+
+func keepBox(b *Box) {
+    savedBox = *b;
+}
+
+impl Box {
+    func keep() {
+        saved = this.f;
+    }
+}
+`
+
+	message := "A closure passed to the function cannot escape it since it can capture local variables of the caller"
+	count := countMessages(getMessages(t, text), message)
+
+	if count != 3 {
+		t.Errorf("expected '%s' to be reported 3 times but got %d", message, count)
+	}
+}
+
+func TestClosureParameterUsedLocally(t *testing.T) {
+	text := `namespace Tests;
+
+var saved fn () i32;
+
+func pass(f fn () i32) fn () i32 {
+    var local = f;
+    return local;
+}
+
+func call(f fn () i32) i32 {
+    saved = => other;
+    return pass(f)();
+}
+
+func other() i32 {
+    return 1;
+}
+
+func test() i32 {
+    var x = 42;
+    return call(fn [x] () i32 { return x; });
+}
+`
+
+	if messages := getMessages(t, text); len(messages) > 0 {
+		t.Errorf("expected no diagnostics but got %q", messages)
+	}
+}
+
+func TestFuncFieldInCStruct(t *testing.T) {
+	text := `namespace Tests;
+
+#[C]
+struct Callbacks {
+    data *void,
+    callback fn (data *void) void,
+    callbacks [2]fn () void,
+}
+
+struct Handler {
+    callback fn () void,
+}
+`
+
+	message := "Fields of C structs can't have a function type"
+	count := countMessages(getMessages(t, text), message)
+
+	if count != 2 {
+		t.Errorf("expected '%s' to be reported 2 times but got %d", message, count)
+	}
+}
+
+func TestPublicDefaultValueAccess(t *testing.T) {
+	text := `namespace Tests;
+
+pub func withPrivate(value i32 = helper()) i32 {
+    return value;
+}
+
+func withPrivateInternal(value i32 = helper()) i32 {
+    return value;
+}
+
+pub func withPublic(value i32 = pubHelper()) i32 {
+    return value;
+}
+
+func helper() i32 {
+    return 1;
+}
+
+pub func pubHelper() i32 {
+    return 2;
+}
+`
+
+	message := "Default value of a public function can't use 'helper' since it is private to its namespace"
+	count := countMessages(getMessages(t, text), message)
+
+	if count != 1 {
+		t.Errorf("expected '%s' to be reported once but got %d", message, count)
+	}
+}
+
+func TestAsmTiedInputOutOfRange(t *testing.T) {
+	text := `namespace Tests;
+
+func main() {
+    var result = 0;
+
+    asm("addl $2, $0" : "=r"(result) : "0"(3), "1"(4));
+    asm("nop" : : "0"(1));
+}
+`
+
+	messages := getMessages(t, text)
+
+	if !slices.Contains(messages, "Tied input refers to output 1 which doesn't exist") || !slices.Contains(messages, "Tied input refers to output 0 which doesn't exist") || len(messages) != 2 {
+		t.Errorf("expected two out of range tied inputs but got %q", messages)
+	}
+}
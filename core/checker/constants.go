@@ -0,0 +1,1053 @@
+package checker
+
+import (
+	"fireball/core/abi"
+	"fireball/core/ast"
+	"fireball/core/scanner"
+	"fireball/core/utils"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// evaluator folds expressions into constants at compile time. Before checking it computes the values of constants,
+// array sizes and enum cases, the checker then uses it for expressions that need to be constant.
+type evaluator struct {
+	reporter utils.Reporter
+	file     *ast.File
+
+	resolvers map[*ast.File]ast.Resolver
+
+	// Enum cases whose values are known while the rest of their enum is still being evaluated
+	cases map[*ast.EnumCase]struct{}
+
+	// Expressions were already visited by the checker so their results can be used instead of resolving names
+	checked bool
+	silent  bool
+}
+
+// Evaluate computes the values of constants, array sizes and enum cases declared in the file. It needs to run after
+// the types of all files are resolved and before they are specialized and checked.
+func Evaluate(reporter utils.Reporter, root ast.RootResolver, file *ast.File) {
+	e := newEvaluator(reporter, file, false)
+	e.resolvers[file] = newFileResolver(root, file)
+
+	e.VisitNode(file)
+}
+
+func newEvaluator(reporter utils.Reporter, file *ast.File, checked bool) *evaluator {
+	return &evaluator{
+		reporter:  reporter,
+		file:      file,
+		resolvers: make(map[*ast.File]ast.Resolver),
+		cases:     make(map[*ast.EnumCase]struct{}),
+		checked:   checked,
+	}
+}
+
+func newFileResolver(root ast.RootResolver, file *ast.File) ast.Resolver {
+	resolver := ast.NewCombinedResolver(root)
+
+	for _, decl := range file.Decls {
+		if using, ok := decl.(*ast.Using); ok {
+			if resolver2 := root.GetResolver(using.Name); resolver2 != nil {
+				resolver.Add(resolver2)
+			}
+		}
+	}
+
+	return resolver
+}
+
+// Declarations
+
+func (e *evaluator) evaluateConst(decl *ast.Const) ast.Constant {
+	switch decl.State {
+	case ast.Evaluated:
+		return decl.ActualValue
+
+	case ast.Evaluating:
+		e.error(decl.Name, "Constant '%s' depends on its own value", decl.Name)
+		return ast.Constant{}
+	}
+
+	decl.State = ast.Evaluating
+	decl.ActualValue = ast.Constant{}
+
+	if decl.Type != nil && decl.Value != nil {
+		value := e.evaluate(decl.Value)
+
+		if value.IsValid() {
+			decl.ActualValue = e.convert(decl.Value, value, decl.Type)
+		}
+	}
+
+	decl.State = e.finish(decl)
+	return decl.ActualValue
+}
+
+func (e *evaluator) evaluateArray(array *ast.Array) bool {
+	switch array.State {
+	case ast.Evaluated:
+		return true
+
+	case ast.Evaluating:
+		e.error(array, "Array size depends on its own value")
+		return false
+	}
+
+	array.State = ast.Evaluating
+	array.Count = 0
+
+	value := e.evaluate(array.Size)
+	ok := false
+
+	if value.Kind == ast.IntConstant {
+		if e.checkRange(array.Size, value.Int, ast.U32) {
+			array.Count = uint32(value.Int)
+			ok = true
+		}
+	} else if value.IsValid() {
+		e.error(array.Size, "Array size needs to be an integer constant")
+	}
+
+	array.State = e.finish(array)
+	return ok
+}
+
+func (e *evaluator) evaluateEnum(decl *ast.Enum) bool {
+	switch decl.State {
+	case ast.Evaluated:
+		return true
+
+	case ast.Evaluating:
+		e.error(decl.Name, "Enum '%s' depends on its own case values", decl.Name)
+		return false
+	}
+
+	decl.State = ast.Evaluating
+
+	// Set case values
+	flags := decl.IsFlags()
+
+	lastValue := int64(-1)
+	usedBits := uint64(0)
+
+	for _, case_ := range decl.Cases {
+		lastValue++
+
+		// Cases of flags enums without a value get the next unused bit
+		if flags {
+			lastValue = int64(1) << bits.Len64(usedBits)
+		}
+
+		if case_.Value != nil {
+			value := e.evaluate(case_.Value)
+
+			if value.Kind == ast.IntConstant {
+				lastValue = value.Int
+			} else if value.IsValid() {
+				e.error(case_.Value, "Enum case value needs to be an integer constant")
+			}
+		}
+
+		case_.ActualValue = lastValue
+		e.cases[case_] = struct{}{}
+
+		if lastValue > 0 {
+			usedBits |= uint64(lastValue)
+		}
+	}
+
+	for _, case_ := range decl.Cases {
+		delete(e.cases, case_)
+	}
+
+	if flags {
+		e.checkFlags(decl)
+	}
+
+	// Find type
+	if decl.Type == nil {
+		minValue := int64(math.MaxInt64)
+		maxValue := int64(math.MinInt64)
+
+		for _, case_ := range decl.Cases {
+			minValue = min(minValue, case_.ActualValue)
+			maxValue = max(maxValue, case_.ActualValue)
+		}
+
+		var kind ast.PrimitiveKind
+
+		if minValue >= 0 {
+			// Unsigned
+			if maxValue <= math.MaxUint8 {
+				kind = ast.U8
+			} else if maxValue <= math.MaxUint16 {
+				kind = ast.U16
+			} else if maxValue <= math.MaxUint32 {
+				kind = ast.U32
+			} else {
+				kind = ast.U64
+			}
+		} else {
+			// Signed
+			if minValue >= math.MinInt8 && maxValue <= math.MaxInt8 {
+				kind = ast.I8
+			} else if minValue >= math.MinInt16 && maxValue <= math.MaxInt16 {
+				kind = ast.I16
+			} else if minValue >= math.MinInt32 && maxValue <= math.MaxInt32 {
+				kind = ast.I32
+			} else {
+				kind = ast.I64
+			}
+		}
+
+		decl.ActualType = &ast.Primitive{Kind: kind}
+	} else {
+		decl.ActualType = decl.Type
+
+		// Check if all cases fit inside the type
+		if v, ok := ast.As[*ast.Primitive](decl.Type); ok && ast.IsInteger(v.Kind) {
+			for _, case_ := range decl.Cases {
+				var node ast.Node = case_.Name
+
+				if case_.Value != nil {
+					node = case_.Value
+				}
+
+				e.checkRange(node, case_.ActualValue, v.Kind)
+			}
+		}
+	}
+
+	decl.State = e.finish(decl)
+	return true
+}
+
+// checkFlags reports cases of a flags enum whose values are neither a single bit nor a combination of other cases.
+func (e *evaluator) checkFlags(decl *ast.Enum) {
+	singleBits := int64(0)
+
+	for _, case_ := range decl.Cases {
+		if isSingleBit(case_.ActualValue) {
+			singleBits |= case_.ActualValue
+		}
+	}
+
+	for _, case_ := range decl.Cases {
+		if case_.Value == nil || case_.ActualValue == 0 || isSingleBit(case_.ActualValue) {
+			continue
+		}
+
+		if case_.ActualValue < 0 || case_.ActualValue&^singleBits != 0 {
+			e.error(case_.Value, "Flags enum case value needs to be a single bit or a combination of other cases")
+		}
+	}
+}
+
+func isSingleBit(value int64) bool {
+	return value > 0 && value&(value-1) == 0
+}
+
+// finish returns the state of a node after evaluating it. Nodes of other files are evaluated again when their own
+// file is evaluated so that diagnostics get reported to the correct file.
+func (e *evaluator) finish(node ast.Node) ast.EvalState {
+	if e.checked || ast.GetParent[*ast.File](node) == e.file {
+		return ast.Evaluated
+	}
+
+	return ast.NotEvaluated
+}
+
+// Expressions
+
+func (e *evaluator) evaluate(expr ast.Expr) ast.Constant {
+	switch expr := expr.(type) {
+	case *ast.Paren:
+		return e.evaluate(expr.Expr)
+
+	case *ast.Literal:
+		return e.evaluateLiteral(expr)
+
+	case *ast.Unary:
+		return e.evaluateUnary(expr)
+
+	case *ast.Binary:
+		return e.evaluateBinary(expr)
+
+	case *ast.Logical:
+		return e.evaluateLogical(expr)
+
+	case *ast.Cast:
+		return e.evaluateCast(expr)
+
+	case *ast.TypeCall:
+		return e.evaluateTypeCall(expr)
+
+	case *ast.Identifier, *ast.Member:
+		return e.evaluateName(expr)
+
+	case nil:
+		return ast.Constant{}
+	}
+
+	e.error(expr, "Expected a constant expression")
+	return ast.Constant{}
+}
+
+func (e *evaluator) evaluateLiteral(expr *ast.Literal) ast.Constant {
+	raw := expr.String()
+
+	switch expr.Token().Kind {
+	case scanner.True:
+		return ast.BoolValue(true)
+	case scanner.False:
+		return ast.BoolValue(false)
+
+	case scanner.Number:
+		last := raw[len(raw)-1]
+
+		if last == 'f' || last == 'F' {
+			if v, err := strconv.ParseFloat(raw[:len(raw)-1], 32); err == nil {
+				return ast.FloatValue(v)
+			}
+		} else if strings.ContainsRune(raw, '.') {
+			if v, err := strconv.ParseFloat(raw, 64); err == nil {
+				return ast.FloatValue(v)
+			}
+		} else {
+			if v, err := strconv.ParseInt(strings.TrimRight(raw, "uU"), 10, 64); err == nil {
+				return ast.IntValue(v)
+			}
+		}
+
+	case scanner.Hex:
+		if v, err := strconv.ParseUint(raw[2:], 16, 63); err == nil {
+			return ast.IntValue(int64(v))
+		}
+
+	case scanner.Binary:
+		if v, err := strconv.ParseUint(raw[2:], 2, 63); err == nil {
+			return ast.IntValue(int64(v))
+		}
+
+	default:
+		e.error(expr, "Expected a constant expression")
+		return ast.Constant{}
+	}
+
+	e.error(expr, "Failed to parse number")
+	return ast.Constant{}
+}
+
+func (e *evaluator) evaluateUnary(expr *ast.Unary) ast.Constant {
+	if !expr.Prefix {
+		e.error(expr, "Expected a constant expression")
+		return ast.Constant{}
+	}
+
+	value := e.evaluate(expr.Value)
+	if !value.IsValid() {
+		return value
+	}
+
+	switch expr.Operator.Token().Kind {
+	case scanner.Minus:
+		switch value.Kind {
+		case ast.IntConstant:
+			return ast.IntValue(-value.Int)
+		case ast.FloatConstant:
+			return ast.FloatValue(-value.Float)
+		}
+
+	case scanner.Bang:
+		if value.Kind == ast.BoolConstant {
+			return ast.BoolValue(!value.Bool())
+		}
+
+	case scanner.Tilde:
+		if value.Kind == ast.IntConstant {
+			return ast.IntValue(^value.Int)
+		}
+
+	default:
+		e.error(expr, "Expected a constant expression")
+		return ast.Constant{}
+	}
+
+	e.error(expr, "Operator '%s' cannot be applied to the constant '%s'", expr.Operator, value)
+	return ast.Constant{}
+}
+
+func (e *evaluator) evaluateBinary(expr *ast.Binary) ast.Constant {
+	left := e.evaluate(expr.Left)
+	right := e.evaluate(expr.Right)
+
+	if !left.IsValid() || !right.IsValid() {
+		return ast.Constant{}
+	}
+
+	kind := expr.Operator.Token().Kind
+
+	// Integers are promoted to floating point numbers when mixed with them
+	if left.Kind == ast.IntConstant && right.Kind == ast.FloatConstant {
+		left = ast.FloatValue(float64(left.Int))
+	} else if left.Kind == ast.FloatConstant && right.Kind == ast.IntConstant {
+		right = ast.FloatValue(float64(right.Int))
+	}
+
+	if left.Kind == right.Kind {
+		switch left.Kind {
+		case ast.IntConstant:
+			if result, ok := e.binaryInt(expr, kind, left.Int, right.Int); ok {
+				return result
+			}
+
+		case ast.FloatConstant:
+			if result, ok := binaryFloat(kind, left.Float, right.Float); ok {
+				return result
+			}
+
+		case ast.BoolConstant:
+			switch kind {
+			case scanner.EqualEqual:
+				return ast.BoolValue(left.Int == right.Int)
+			case scanner.BangEqual:
+				return ast.BoolValue(left.Int != right.Int)
+			}
+		}
+	}
+
+	if e.isValidBinary(expr) {
+		e.error(expr, "Operator '%s' cannot be applied to the constants '%s' and '%s'", expr.Operator, left, right)
+	}
+
+	return ast.Constant{}
+}
+
+func (e *evaluator) binaryInt(expr *ast.Binary, kind scanner.TokenKind, left, right int64) (ast.Constant, bool) {
+	switch kind {
+	case scanner.Plus:
+		return ast.IntValue(left + right), true
+	case scanner.Minus:
+		return ast.IntValue(left - right), true
+	case scanner.Star:
+		return ast.IntValue(left * right), true
+
+	case scanner.Slash, scanner.Percentage:
+		if right == 0 {
+			e.error(expr, "Division by zero")
+			return ast.Constant{}, true
+		}
+
+		if kind == scanner.Slash {
+			return ast.IntValue(left / right), true
+		}
+
+		return ast.IntValue(left % right), true
+
+	case scanner.Pipe:
+		return ast.IntValue(left | right), true
+	case scanner.Xor:
+		return ast.IntValue(left ^ right), true
+	case scanner.Ampersand:
+		return ast.IntValue(left & right), true
+
+	case scanner.LessLess, scanner.GreaterGreater:
+		if right < 0 || right > 63 {
+			e.error(expr.Right, "Shift amount '%d' is out of range", right)
+			return ast.Constant{}, true
+		}
+
+		if kind == scanner.LessLess {
+			return ast.IntValue(left << right), true
+		}
+
+		return ast.IntValue(left >> right), true
+
+	case scanner.EqualEqual:
+		return ast.BoolValue(left == right), true
+	case scanner.BangEqual:
+		return ast.BoolValue(left != right), true
+	case scanner.Less:
+		return ast.BoolValue(left < right), true
+	case scanner.LessEqual:
+		return ast.BoolValue(left <= right), true
+	case scanner.Greater:
+		return ast.BoolValue(left > right), true
+	case scanner.GreaterEqual:
+		return ast.BoolValue(left >= right), true
+
+	default:
+		return ast.Constant{}, false
+	}
+}
+
+func binaryFloat(kind scanner.TokenKind, left, right float64) (ast.Constant, bool) {
+	switch kind {
+	case scanner.Plus:
+		return ast.FloatValue(left + right), true
+	case scanner.Minus:
+		return ast.FloatValue(left - right), true
+	case scanner.Star:
+		return ast.FloatValue(left * right), true
+	case scanner.Slash:
+		return ast.FloatValue(left / right), true
+	case scanner.Percentage:
+		return ast.FloatValue(math.Mod(left, right)), true
+
+	case scanner.EqualEqual:
+		return ast.BoolValue(left == right), true
+	case scanner.BangEqual:
+		return ast.BoolValue(left != right), true
+	case scanner.Less:
+		return ast.BoolValue(left < right), true
+	case scanner.LessEqual:
+		return ast.BoolValue(left <= right), true
+	case scanner.Greater:
+		return ast.BoolValue(left > right), true
+	case scanner.GreaterEqual:
+		return ast.BoolValue(left >= right), true
+
+	default:
+		return ast.Constant{}, false
+	}
+}
+
+// isValidBinary returns false when the checker already reported an error for the operands of the expression.
+func (e *evaluator) isValidBinary(expr *ast.Binary) bool {
+	return !e.checked || expr.Result().Kind != ast.InvalidResultKind
+}
+
+func (e *evaluator) evaluateLogical(expr *ast.Logical) ast.Constant {
+	left := e.evaluate(expr.Left)
+	right := e.evaluate(expr.Right)
+
+	if !left.IsValid() || !right.IsValid() {
+		return ast.Constant{}
+	}
+
+	if left.Kind != ast.BoolConstant || right.Kind != ast.BoolConstant {
+		e.error(expr, "Operator '%s' cannot be applied to the constants '%s' and '%s'", expr.Operator, left, right)
+		return ast.Constant{}
+	}
+
+	if expr.Operator.Token().Kind == scanner.And {
+		return ast.BoolValue(left.Bool() && right.Bool())
+	}
+
+	return ast.BoolValue(left.Bool() || right.Bool())
+}
+
+func (e *evaluator) evaluateCast(expr *ast.Cast) ast.Constant {
+	if expr.Operator.Token().Kind != scanner.As || expr.Target == nil {
+		e.error(expr, "Expected a constant expression")
+		return ast.Constant{}
+	}
+
+	value := e.evaluate(expr.Value)
+	if !value.IsValid() {
+		return value
+	}
+
+	switch target := expr.Target.Resolved().(type) {
+	case *ast.Primitive:
+		switch {
+		case ast.IsInteger(target.Kind):
+			if value.Kind == ast.FloatConstant {
+				value = ast.IntValue(int64(value.Float))
+			}
+
+			if value.Kind == ast.IntConstant {
+				return ast.IntValue(truncate(value.Int, target.Kind))
+			}
+
+		case ast.IsFloating(target.Kind):
+			if value.Kind == ast.IntConstant {
+				value = ast.FloatValue(float64(value.Int))
+			}
+
+			if value.Kind == ast.FloatConstant {
+				if target.Kind == ast.F32 {
+					return ast.FloatValue(float64(float32(value.Float)))
+				}
+
+				return value
+			}
+
+		case target.Kind == ast.Bool:
+			if value.Kind == ast.BoolConstant {
+				return value
+			}
+		}
+
+	case *ast.Enum:
+		if value.Kind == ast.IntConstant && !target.IsTagged() && e.evaluateEnum(target) {
+			if primitive, ok := ast.As[*ast.Primitive](target.ActualType); ok {
+				return ast.IntValue(truncate(value.Int, primitive.Kind))
+			}
+		}
+	}
+
+	e.error(expr, "Expected a constant expression")
+	return ast.Constant{}
+}
+
+func (e *evaluator) evaluateTypeCall(expr *ast.TypeCall) ast.Constant {
+	if expr.Arg == nil {
+		return ast.Constant{}
+	}
+
+	type_ := e.prepareType(expr.Arg)
+
+	if type_ == nil {
+		e.error(expr.Arg, "Size of type '%s' is not known at compile time", ast.PrintType(expr.Arg))
+		return ast.Constant{}
+	}
+
+	switch expr.Callee.String() {
+	case "sizeof":
+		return ast.IntValue(int64(abi.GetTargetAbi().Size(type_)))
+	case "alignof":
+		return ast.IntValue(int64(abi.GetTargetAbi().Align(type_)))
+	case "countof":
+		if enum, ok := ast.As[*ast.Enum](type_); ok {
+			return ast.IntValue(int64(len(enum.Cases)))
+		}
+
+		return ast.Constant{}
+
+	default:
+		e.error(expr, "Expected a constant expression")
+		return ast.Constant{}
+	}
+}
+
+// Checker
+
+// getInteger returns the value of the expression if it is an integer constant, without reporting any errors.
+func (e *evaluator) getInteger(expr ast.Expr) (int64, bool) {
+	if expr == nil {
+		return 0, false
+	}
+
+	e.silent = true
+	value := e.evaluate(expr)
+	e.silent = false
+
+	return value.Int, value.Kind == ast.IntConstant
+}
+
+// getConstant returns the value of the expression converted to the type if it is a constant, without reporting any
+// errors.
+func (e *evaluator) getConstant(expr ast.Expr, type_ ast.Type) ast.Constant {
+	if expr == nil || type_ == nil {
+		return ast.Constant{}
+	}
+
+	e.silent = true
+	value := e.convert(expr, e.evaluate(expr), type_)
+	e.silent = false
+
+	return value
+}
+
+// checkLiteral reports an error if an integer literal does not fit inside the range of its type. Literals that are
+// part of a constant expression are only checked once the whole expression is evaluated.
+func (e *evaluator) checkLiteral(expr *ast.Literal, kind ast.PrimitiveKind) {
+	if isConstantContext(expr) {
+		return
+	}
+
+	e.silent = true
+	value := e.evaluateLiteral(expr)
+	e.silent = false
+
+	if value.Kind != ast.IntConstant {
+		return
+	}
+
+	if unary, ok := expr.Parent().(*ast.Unary); ok && unary.Prefix && unary.Operator.Token().Kind == scanner.Minus {
+		value.Int = -value.Int
+	}
+
+	e.checkRange(expr, value.Int, kind)
+}
+
+func isConstantContext(node ast.Node) bool {
+	for !ast.IsNil(node) {
+		switch node.(type) {
+		case *ast.Const, *ast.Array, *ast.EnumCase:
+			return true
+
+		case ast.Stmt, ast.Decl:
+			return false
+		}
+
+		node = node.Parent()
+	}
+
+	return false
+}
+
+// Names
+
+func (e *evaluator) evaluateName(expr ast.Expr) ast.Constant {
+	var node ast.Node
+
+	if e.checked {
+		if expr.Result().Kind == ast.ValueResultKind {
+			node = expr.Result().Value()
+		} else if expr.Result().Kind == ast.InvalidResultKind {
+			return ast.Constant{}
+		}
+	} else {
+		node = e.resolveName(expr)
+	}
+
+	switch node := node.(type) {
+	case *ast.Const:
+		return e.evaluateConst(node)
+
+	case *ast.EnumCase:
+		if _, ok := e.cases[node]; ok {
+			return ast.IntValue(node.ActualValue)
+		}
+
+		enum := ast.GetParent[*ast.Enum](node)
+
+		if len(node.Fields) == 0 && e.evaluateEnum(enum) {
+			return ast.IntValue(node.ActualValue)
+		}
+	}
+
+	e.error(expr, "Expected a constant expression")
+	return ast.Constant{}
+}
+
+// resolveName returns the constant or enum case an identifier or a member expression refers to, if any.
+func (e *evaluator) resolveName(expr ast.Expr) ast.Node {
+	resolver := e.getResolver(expr)
+	if resolver == nil {
+		return nil
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Identifier:
+		if expr.Name == nil {
+			return nil
+		}
+
+		if constant := getStructConstant(expr, expr.Name.String()); constant != nil {
+			return constant
+		}
+
+		if constant := resolver.GetConstant(expr.Name.String()); constant != nil {
+			return constant
+		}
+
+	case *ast.Member:
+		if expr.Name == nil {
+			return nil
+		}
+
+		childResolver, type_ := e.resolveScope(resolver, expr.Value)
+
+		if childResolver != nil {
+			if constant := childResolver.GetConstant(expr.Name.String()); constant != nil {
+				return constant
+			}
+		}
+
+		switch type_ := type_.(type) {
+		case ast.StructType:
+			if constant := type_.Underlying().GetConstant(expr.Name.String()); constant != nil {
+				return constant
+			}
+
+		case *ast.Enum:
+			if case_ := type_.GetCase(expr.Name.String()); case_ != nil {
+				return case_
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveScope returns the namespace or the type an expression refers to.
+func (e *evaluator) resolveScope(resolver ast.Resolver, expr ast.Expr) (ast.Resolver, ast.Type) {
+	switch expr := expr.(type) {
+	case *ast.Identifier:
+		if expr.Name == nil {
+			return nil, nil
+		}
+
+		if child := resolver.GetChild(expr.Name.String()); child != nil {
+			return child, nil
+		}
+
+		return nil, resolver.GetType(expr.Name.String())
+
+	case *ast.Member:
+		if expr.Name == nil {
+			return nil, nil
+		}
+
+		parent, _ := e.resolveScope(resolver, expr.Value)
+
+		if parent != nil {
+			if child := parent.GetChild(expr.Name.String()); child != nil {
+				return child, nil
+			}
+
+			return nil, parent.GetType(expr.Name.String())
+		}
+	}
+
+	return nil, nil
+}
+
+func (e *evaluator) getResolver(node ast.Node) ast.Resolver {
+	file := ast.GetParent[*ast.File](node)
+	if file == nil {
+		return nil
+	}
+
+	if resolver, ok := e.resolvers[file]; ok {
+		return resolver
+	}
+
+	root, ok := file.Resolver.(ast.RootResolver)
+	if !ok {
+		return nil
+	}
+
+	resolver := newFileResolver(root, file)
+	e.resolvers[file] = resolver
+
+	return resolver
+}
+
+// getStructConstant returns a constant declared by the struct that contains the node or whose methods contain it.
+func getStructConstant(node ast.Node, name string) *ast.Const {
+	if s := ast.GetParent[*ast.Struct](node); s != nil {
+		return s.GetConstant(name)
+	}
+
+	if impl := ast.GetParent[*ast.Impl](node); impl != nil && impl.Type != nil {
+		if s, ok := ast.As[*ast.Struct](impl.Type); ok {
+			return s.GetConstant(name)
+		}
+	}
+
+	return nil
+}
+
+// Types
+
+// prepareType evaluates the array sizes and enums that the size of a type depends on. It returns nil if the size of
+// the type is not known at compile time.
+func (e *evaluator) prepareType(type_ ast.Type) ast.Type {
+	// Struct specializations are only created after evaluating so they need to be created here
+	if resolvable, ok := type_.(*ast.Resolvable); ok && !e.checked && len(resolvable.GenericArgs) != 0 {
+		if s, ok := resolvable.Type.(*ast.Struct); ok && len(resolvable.GenericArgs) == len(s.GenericParams) {
+			if !e.prepareStruct(s, nil) {
+				return nil
+			}
+
+			for _, arg := range resolvable.GenericArgs {
+				if e.prepareType(arg) == nil {
+					return nil
+				}
+			}
+
+			type_ = s.Specialize(resolvable.GenericArgs)
+		}
+	}
+
+	if !e.prepareSize(type_, nil) {
+		return nil
+	}
+
+	return type_
+}
+
+func (e *evaluator) prepareSize(type_ ast.Type, visited []ast.Type) bool {
+	switch type_ := type_.Resolved().(type) {
+	case *ast.Array:
+		if type_.Size != nil && !e.evaluateArray(type_) {
+			return false
+		}
+
+		return e.prepareSize(type_.Base, visited)
+
+	case *ast.Enum:
+		if !e.evaluateEnum(type_) {
+			return false
+		}
+
+		for _, case_ := range type_.Cases {
+			for _, field := range case_.Fields {
+				if !e.prepareSize(field.Type(), visited) {
+					return false
+				}
+			}
+		}
+
+		return true
+
+	case *ast.Struct:
+		return e.prepareStruct(type_, visited)
+
+	case *ast.SpecializedStruct:
+		if !e.prepareStruct(type_.Underlying(), visited) {
+			return false
+		}
+
+		for i := 0; i < type_.FieldCount(); i++ {
+			if !e.prepareSize(type_.FieldIndex(i).Type(), visited) {
+				return false
+			}
+		}
+
+		return true
+
+	case *ast.Generic:
+		return false
+
+	default:
+		return true
+	}
+}
+
+func (e *evaluator) prepareStruct(s *ast.Struct, visited []ast.Type) bool {
+	for _, v := range visited {
+		if v == s {
+			return true
+		}
+	}
+
+	visited = append(visited, s)
+
+	for _, field := range s.Fields {
+		// Fields of generic types are checked once the struct is specialized
+		if _, ok := field.Type().Resolved().(*ast.Generic); ok {
+			continue
+		}
+
+		if !e.prepareSize(field.Type(), visited) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Conversions
+
+// convert converts a constant to the given type and checks that it fits inside its range.
+func (e *evaluator) convert(node ast.Node, value ast.Constant, type_ ast.Type) ast.Constant {
+	switch type_ := type_.Resolved().(type) {
+	case *ast.Primitive:
+		switch {
+		case ast.IsInteger(type_.Kind):
+			if value.Kind == ast.IntConstant && e.checkRange(node, value.Int, type_.Kind) {
+				return value
+			}
+
+		case ast.IsFloating(type_.Kind):
+			if value.Kind == ast.IntConstant {
+				value = ast.FloatValue(float64(value.Int))
+			}
+
+			if value.Kind == ast.FloatConstant {
+				if type_.Kind == ast.F32 {
+					return ast.FloatValue(float64(float32(value.Float)))
+				}
+
+				return value
+			}
+
+		case type_.Kind == ast.Bool:
+			if value.Kind == ast.BoolConstant {
+				return value
+			}
+		}
+
+	case *ast.Enum:
+		if value.Kind == ast.IntConstant && e.evaluateEnum(type_) {
+			if primitive, ok := ast.As[*ast.Primitive](type_.ActualType); ok && e.checkRange(node, value.Int, primitive.Kind) {
+				return value
+			}
+		}
+	}
+
+	return ast.Constant{}
+}
+
+// checkRange reports an error if the value does not fit inside the range of the integer kind.
+func (e *evaluator) checkRange(node ast.Node, value int64, kind ast.PrimitiveKind) bool {
+	min_, max_ := ast.GetRangeTrunc(kind)
+
+	if value < min_ || value > max_ {
+		e.error(node, "Value '%d' does not fit inside the range of '%s'", value, kind)
+		return false
+	}
+
+	return true
+}
+
+func truncate(value int64, kind ast.PrimitiveKind) int64 {
+	bits := ast.GetBitSize(kind)
+
+	if bits >= 64 {
+		return value
+	}
+
+	mask := int64(1)<<bits - 1
+	value &= mask
+
+	// Sign extend
+	if ast.IsSigned(kind) && value&(int64(1)<<(bits-1)) != 0 {
+		value -= int64(1) << bits
+	}
+
+	return value
+}
+
+// ast.Visitor
+
+func (e *evaluator) VisitNode(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.Const:
+		e.evaluateConst(node)
+
+	case *ast.Enum:
+		e.evaluateEnum(node)
+
+	case *ast.Array:
+		if node.Size != nil {
+			e.evaluateArray(node)
+		}
+	}
+
+	node.AcceptChildren(e)
+}
+
+// Diagnostics
+
+func (e *evaluator) error(node ast.Node, format string, args ...any) {
+	if e.silent || ast.IsNil(node) || ast.GetParent[*ast.File](node) != e.file {
+		return
+	}
+
+	e.reporter.Report(utils.Diagnostic{
+		Kind:    utils.ErrorKind,
+		Range:   node.Cst().Range,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
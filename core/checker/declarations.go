@@ -20,14 +20,27 @@ func (c *checker) VisitStruct(decl *ast.Struct) {
 		c.visitStructAttribute(attribute)
 	}
 
+	if decl.IsUnion() && len(decl.Fields) == 0 && decl.Name != nil {
+		c.error(decl.Name, "Unions need to have at least one field")
+	}
+
+	c.checkGenericParams(decl.GenericParams)
+
 	prevResolver := c.resolver
 	if len(decl.GenericParams) != 0 {
 		c.resolver = ast.NewGenericResolver(c.resolver, decl.GenericParams)
 	}
 
-	// Check static fields
+	// Check constants
 	fields := utils.NewSet[string]()
 
+	for _, constant := range decl.Constants {
+		if constant.Name != nil && !fields.Add(constant.Name.String()) {
+			c.error(constant.Name, "Constant with the name '%s' already exists", constant.Name)
+		}
+	}
+
+	// Check static fields
 	for _, field := range decl.StaticFields {
 		// Check name collision
 		if field.Name() != nil && !fields.Add(field.Name().String()) {
@@ -38,6 +51,18 @@ func (c *checker) VisitStruct(decl *ast.Struct) {
 		if ast.IsPrimitive(field.Type(), ast.Void) {
 			c.error(field.Name(), "Static field cannot be of type 'void'")
 		}
+
+		// Check attributes
+		for _, attribute := range field.Underlying().Attributes {
+			c.visitStaticFieldAttribute(attribute)
+		}
+
+		// Check initializer
+		if field.IsThreadLocal() && field.Value != nil {
+			c.error(field.Value, "Thread local variables can't have an initializer")
+		} else {
+			field.ActualValue = c.checkInitializer(field.Type(), field.Value)
+		}
 	}
 
 	// Check fields
@@ -46,25 +71,44 @@ func (c *checker) VisitStruct(decl *ast.Struct) {
 	for _, field := range decl.Fields {
 		// Check name collision
 		if field.Name() != nil && !fields.Add(field.Name().String()) {
-			c.error(field.Name(), "Field with the name '%s' already exists", field.Name)
+			c.error(field.Name(), "Field with the name '%s' already exists", field.Name())
 		}
 
 		// Check void type
 		if ast.IsPrimitive(field.Type(), ast.Void) {
 			c.error(field.Name(), "Field cannot be of type 'void'")
 		}
+
+		// Check function types, they are a code pointer and environment pair which C doesn't have
+		if decl.IsC() && containsFuncType(field.Type()) {
+			c.error(field.Name(), "Fields of C structs can't have a function type")
+		}
+
+		// Check attributes
+		for _, attribute := range field.Underlying().Attributes {
+			c.visitFieldAttribute(attribute)
+		}
 	}
 
 	c.resolver = prevResolver
 }
 
+func containsFuncType(type_ ast.Type) bool {
+	if array, ok := ast.As[*ast.Array](type_); ok {
+		return containsFuncType(array.Base)
+	}
+
+	_, ok := ast.As[ast.FuncType](type_)
+	return ok
+}
+
 func (c *checker) VisitImpl(decl *ast.Impl) {
 	// Implements
 	if decl.Implements != nil {
 		// Check interface
-		if inter, ok := ast.As[*ast.Interface](decl.Implements); ok {
-			// Check methods
-			count := 0
+		if inter, ok := ast.As[ast.InterfaceType](decl.Implements); ok {
+			// Check methods, including the ones inherited from other interfaces
+			implemented := utils.NewSet[string]()
 
 			for _, method := range decl.Methods {
 				if method.Name == nil {
@@ -73,15 +117,24 @@ func (c *checker) VisitImpl(decl *ast.Impl) {
 
 				interMethod, _ := inter.GetMethod(method.Name.String())
 
-				if interMethod != nil && interMethod.NameAndSignatureEquals(method) {
-					count++
+				if interMethod != nil && method.NameAndSignatureEquals(interMethod) {
+					implemented.Add(method.Name.String())
 				} else {
 					c.error(method, "Interface '%s' does not contain method '%s'", ast.PrintType(inter), ast.PrintTypeOptions(method, ast.TypePrintOptions{FuncNames: true}))
 				}
+
+				if method.Cst() != nil && method.Cst().Contains(scanner.Pub) {
+					c.error(method.Name, "Methods implementing an interface are always public")
+				}
 			}
 
-			if count != len(inter.Methods) {
-				c.error(decl.Struct, "Missing method from interface '%s'", ast.PrintType(decl.Implements))
+			for i := 0; i < inter.MethodCount(); i++ {
+				method := inter.MethodIndex(i).Underlying()
+
+				// Default methods don't need to be implemented
+				if !method.IsDefault() && !implemented.Contains(method.Name.String()) {
+					c.error(decl.Struct, "Missing method '%s' from interface '%s'", method.Name, ast.PrintType(decl.Implements))
+				}
 			}
 		} else {
 			c.error(decl.Implements, "'%s' is not an interface", ast.PrintType(decl.Implements))
@@ -93,7 +146,9 @@ func (c *checker) VisitImpl(decl *ast.Impl) {
 
 	if decl.Type != nil {
 		c.pushScope()
-		c.addVariable(&ast.Token{Token_: scanner.Token{Kind: scanner.Identifier, Lexeme: "this"}}, decl.Type, nil)
+		if v := c.addVariable(&ast.Token{Token_: scanner.Token{Kind: scanner.Identifier, Lexeme: "this"}}, decl.Type, nil); v != nil {
+			v.closure.outer = mayHoldClosure(decl.Type, nil)
+		}
 
 		s, _ := ast.As[*ast.Struct](decl.Type)
 		if len(s.GenericParams) > 0 {
@@ -115,32 +170,121 @@ func (c *checker) VisitEnum(decl *ast.Enum) {
 
 	c.checkNameCollision(decl, decl.Name)
 
+	// Check attributes
+	for _, attribute := range decl.Attributes {
+		c.visitEnumAttribute(attribute)
+	}
+
+	// Check case fields
+	for _, case_ := range decl.Cases {
+		fields := utils.NewSet[string]()
+
+		for _, field := range case_.Fields {
+			// Check name collision
+			if field.Name() != nil && !fields.Add(field.Name().String()) {
+				c.error(field.Name(), "Field with the name '%s' already exists", field.Name())
+			}
+
+			// Check void type
+			if ast.IsPrimitive(field.Type(), ast.Void) {
+				c.error(field.Name(), "Field cannot be of type 'void'")
+			}
+		}
+	}
+
 	// Check type
 	if decl.Type != nil {
 		if v, ok := ast.As[*ast.Primitive](decl.Type); !ok || !ast.IsInteger(v.Kind) {
 			c.error(decl.Type, "Invalid type '%s', can only be a signed or unsigned integer", ast.PrintType(decl.Type))
-		} else {
-			// Check if all cases fit inside the type
-			min_, max_ := ast.GetRangeTrunc(v.Kind)
+		}
+	}
 
-			for _, case_ := range decl.Cases {
-				if case_.ActualValue < min_ || case_.ActualValue > max_ {
-					c.error(case_.Name, "Value '%d' does not fit inside the range of '%s'", case_.Value, ast.PrintType(decl.Type))
-				}
+	// Check case values
+	for _, case_ := range decl.Cases {
+		if case_.Value != nil && case_.Value.Result().Kind == ast.ValueResultKind && !isIntegerType(case_.Value.Result().Type) {
+			// Flags can be combined from other cases of the same enum
+			if enum, ok := ast.As[*ast.Enum](case_.Value.Result().Type); ok && enum == decl && decl.IsFlags() {
+				continue
+			}
+
+			c.error(case_.Value, "Expected an integer but got a '%s'", ast.PrintType(case_.Value.Result().Type))
+		}
+	}
+
+	// Check flags
+	if decl.IsFlags() {
+		for _, case_ := range decl.Cases {
+			if len(case_.Fields) > 0 {
+				c.error(case_.Name, "Cases of flags enums can't have fields")
 			}
 		}
 	}
 }
 
 func (c *checker) VisitInterface(decl *ast.Interface) {
+	prevResolver := c.resolver
+	if len(decl.GenericParams) != 0 {
+		c.resolver = ast.NewGenericResolver(c.resolver, decl.GenericParams)
+	}
+
+	// Default methods get the interface value as this
+	c.pushScope()
+	c.addVariable(&ast.Token{Token_: scanner.Token{Kind: scanner.Identifier, Lexeme: "this"}}, decl, nil)
+
 	decl.AcceptChildren(c)
 
+	c.popScope()
+	c.resolver = prevResolver
+
 	c.checkNameCollision(decl, decl.Name)
+	c.checkGenericParams(decl.GenericParams)
+
+	for _, method := range decl.Methods {
+		if method.Cst() != nil && method.Cst().Contains(scanner.Pub) {
+			c.error(method.Name, "Interface methods are always public")
+		}
+	}
+
+	// Check inherited interfaces
+	inherited := make(map[string]ast.FuncType)
+
+	for _, parent := range decl.Inherits {
+		inter, ok := ast.As[ast.InterfaceType](parent)
+
+		if !ok {
+			c.error(parent, "'%s' is not an interface", ast.PrintType(parent))
+			continue
+		}
+
+		if inter.Underlying() == decl || ast.Inherits(inter, decl) {
+			c.error(parent, "Interface '%s' can't inherit itself", decl.Name)
+			continue
+		}
+
+		for i := 0; i < inter.MethodCount(); i++ {
+			method := inter.MethodIndex(i)
+			name := method.Underlying().Name.String()
+
+			if other, ok := inherited[name]; ok && other.Underlying() != method.Underlying() && !other.Underlying().NameAndSignatureEquals(method) {
+				c.error(parent, "Method '%s' conflicts with a method inherited from another interface", name)
+			}
+
+			inherited[name] = method
+		}
+	}
 
-	// Check method bodies
+	// Check methods
 	for _, method := range decl.Methods {
-		if method.Cst().Contains(scanner.LeftBrace) {
-			errorSlice(c, method.Body, "Interface methods can't have bodies")
+		if method.Name == nil {
+			continue
+		}
+
+		if _, ok := inherited[method.Name.String()]; ok {
+			c.error(method.Name, "Method '%s' is already inherited", method.Name)
+		}
+
+		if method.IsDefault() && len(decl.GenericParams) != 0 {
+			c.error(method.Name, "Generic interfaces can't have default methods")
 		}
 	}
 }
@@ -211,6 +355,8 @@ func (c *checker) VisitFunc(decl *ast.Func) {
 		c.error(decl.Name, "Intrinsic functions can't be generic")
 	}
 
+	c.checkGenericParams(decl.GenericParams)
+
 	// Check body
 	if decl.HasBody() {
 		if !decl.Cst().Contains(scanner.LeftBrace) {
@@ -231,6 +377,33 @@ func (c *checker) VisitFunc(decl *ast.Func) {
 		c.resolver = ast.NewGenericResolver(c.resolver, decl.GenericParams)
 	}
 
+	// Default values, checked before the parameters are in scope since they are evaluated at the call site
+	hasDefault := false
+
+	for _, param := range decl.Params {
+		if param.Value == nil {
+			if hasDefault && param.Name != nil {
+				c.error(param.Name, "Parameters without a default value can't follow parameters with one")
+			}
+
+			continue
+		}
+
+		hasDefault = true
+
+		c.VisitNode(param.Value)
+
+		if param.Value.Result().Kind != ast.InvalidResultKind {
+			if param.Value.Result().Kind != ast.ValueResultKind {
+				c.error(param.Value, "Invalid value")
+			} else {
+				c.checkRequired(param.Type, param.Value)
+			}
+		}
+
+		c.checkDefaultValueAccess(decl, param.Value)
+	}
+
 	// Params
 	for _, param := range decl.Params {
 		if c.hasVariableInScope(param.Name) {
@@ -238,6 +411,7 @@ func (c *checker) VisitFunc(decl *ast.Func) {
 		} else {
 			if v := c.addVariable(param.Name, param.Type, param); v != nil {
 				v.param = true
+				v.closure.outer = mayHoldClosure(param.Type, nil)
 			}
 		}
 	}
@@ -274,14 +448,118 @@ func (c *checker) VisitGlobalVar(decl *ast.GlobalVar) {
 
 	c.checkNameCollision(decl, decl.Name)
 
+	// Check attributes
+	for _, attribute := range decl.Attributes {
+		c.visitGlobalVarAttribute(attribute)
+	}
+
 	// Check void type
 	if ast.IsPrimitive(decl.Type, ast.Void) {
 		c.error(decl.Name, "Variable cannot be of type 'void'")
 	}
+
+	// Check initializer
+	if decl.IsThreadLocal() && decl.Value != nil {
+		c.error(decl.Value, "Thread local variables can't have an initializer")
+	} else {
+		decl.ActualValue = c.checkInitializer(decl.Type, decl.Value)
+	}
+}
+
+// checkInitializer checks the initializer of a global variable or a static field and returns its value if it can be
+// computed at compile time. Otherwise, the value is computed at runtime before main is called.
+func (c *checker) checkInitializer(type_ ast.Type, value ast.Expr) ast.Constant {
+	if value == nil || value.Result().Kind == ast.InvalidResultKind {
+		return ast.Constant{}
+	}
+
+	if value.Result().Kind != ast.ValueResultKind {
+		c.error(value, "Invalid value")
+		return ast.Constant{}
+	}
+
+	c.checkRequired(type_, value)
+
+	return c.evaluator.getConstant(value, type_)
+}
+
+func (c *checker) VisitConst(decl *ast.Const) {
+	decl.AcceptChildren(c)
+
+	// Struct constants are checked together with the static fields of the struct
+	if _, ok := decl.Parent().(*ast.Struct); !ok {
+		c.checkNameCollision(decl, decl.Name)
+	}
+
+	if decl.Type == nil || decl.Value == nil {
+		return
+	}
+
+	// Check type
+	switch type_ := decl.Type.Resolved().(type) {
+	case *ast.Primitive:
+		if !ast.IsNumber(type_.Kind) && type_.Kind != ast.Bool {
+			c.error(decl.Type, "Invalid type '%s', can only be a number, a bool or an enum", ast.PrintType(decl.Type))
+			return
+		}
+
+	case *ast.Enum:
+		if type_.IsTagged() {
+			c.error(decl.Type, "Constants cannot be of a tagged enum type")
+			return
+		}
+
+	default:
+		c.error(decl.Type, "Invalid type '%s', can only be a number, a bool or an enum", ast.PrintType(decl.Type))
+		return
+	}
+
+	// Check value
+	if decl.Value.Result().Kind == ast.InvalidResultKind {
+		return
+	}
+
+	if decl.Value.Result().Kind != ast.ValueResultKind {
+		c.error(decl.Value, "Invalid value")
+		return
+	}
+
+	// Integer values can be narrowed since the evaluator checks that the value fits inside the type
+	if isIntegerType(decl.Type) && isIntegerType(decl.Value.Result().Type) {
+		return
+	}
+
+	c.checkRequired(decl.Type, decl.Value)
 }
 
 // Utils
 
+func isIntegerType(type_ ast.Type) bool {
+	if v, ok := ast.As[*ast.Primitive](type_); ok {
+		return ast.IsInteger(v.Kind)
+	}
+
+	return false
+}
+
+func isFlagsType(type_ ast.Type) bool {
+	if v, ok := ast.As[*ast.Enum](type_); ok {
+		return v.IsFlags()
+	}
+
+	return false
+}
+
+func isFlagsOperator(kind scanner.TokenKind) bool {
+	switch kind {
+	case scanner.Pipe, scanner.PipeEqual, scanner.Ampersand, scanner.AmpersandEqual, scanner.Xor, scanner.XorEqual:
+		return true
+
+	default:
+		return false
+	}
+}
+
 func (c *checker) checkNameCollision(decl ast.Decl, name *ast.Token) {
 	if name == nil {
 		return
@@ -327,6 +605,8 @@ func (s *nameChecker) VisitSymbol(node ast.Node) {
 			name2 = node.Name
 		case *ast.GlobalVar:
 			name2 = node.Name
+		case *ast.Const:
+			name2 = node.Name
 		}
 
 		if name2 != nil && s.name.String() == name2.String() {
@@ -18,6 +18,15 @@ func (c *checker) VisitParen(expr *ast.Paren) {
 	}
 }
 
+func (c *checker) VisitNamedArg(expr *ast.NamedArg) {
+	expr.AcceptChildren(c)
+
+	if expr.Value != nil {
+		*expr.Result() = *expr.Value.Result()
+		expr.Result().Flags = 0
+	}
+}
+
 func (c *checker) VisitLiteral(expr *ast.Literal) {
 	expr.AcceptChildren(c)
 
@@ -103,6 +112,10 @@ func (c *checker) VisitLiteral(expr *ast.Literal) {
 
 	expr.Result().SetValue(&ast.Primitive{Kind: kind}, 0, nil)
 
+	if ast.IsInteger(kind) && !pointer {
+		c.evaluator.checkLiteral(expr, kind)
+	}
+
 	if pointer {
 		expr.Result().SetValue(&ast.Pointer{Pointee: expr.Result().Type}, 0, nil)
 	}
@@ -153,6 +166,8 @@ func (c *checker) VisitStructInitializer(expr *ast.StructInitializer) {
 			continue
 		}
 
+		c.checkAccessibleDecl(expr, initField.Name, initField.Name.String(), field)
+
 		// Check value result
 		if initField.Value != nil {
 			if initField.Value.Result().Kind == ast.InvalidResultKind {
@@ -160,7 +175,7 @@ func (c *checker) VisitStructInitializer(expr *ast.StructInitializer) {
 			}
 
 			if initField.Value.Result().Kind != ast.ValueResultKind {
-				c.error(initField.Value, "Cannot assign this value to a field with type '%s'", field.Type)
+				c.error(initField.Value, "Cannot assign this value to a field with type '%s'", ast.PrintType(field.Type()))
 				continue
 			}
 
@@ -168,6 +183,11 @@ func (c *checker) VisitStructInitializer(expr *ast.StructInitializer) {
 		}
 	}
 
+	// Check union
+	if struct_.Underlying().IsUnion() && len(expr.Fields) != 1 {
+		c.error(expr.Type, "Union initializers need to set exactly one field")
+	}
+
 	// Check malloc
 	if expr.New {
 		c.checkMalloc(expr)
@@ -274,6 +294,15 @@ func (c *checker) VisitUnary(expr *ast.Unary) {
 			c.expectPrimitiveValue(expr.Value, ast.Bool)
 			expr.Result().SetValue(&ast.Primitive{Kind: ast.Bool}, 0, nil)
 
+		case scanner.Tilde:
+			if result.Kind == ast.ValueResultKind && (isIntegerType(result.Type) || isFlagsType(result.Type)) {
+				expr.Result().SetValue(result.Type, 0, nil)
+				return
+			}
+
+			c.error(expr.Value, "Expected either an integer or a flags enum but got a '%s'", ast.PrintType(result.Type))
+			expr.Result().SetInvalid()
+
 		case scanner.Minus:
 			if result.Kind != ast.ValueResultKind {
 				c.error(expr.Value, "Cannot negate this value")
@@ -282,6 +311,14 @@ func (c *checker) VisitUnary(expr *ast.Unary) {
 				return
 			}
 
+			if _, ok := ast.As[ast.StructType](result.Type); ok {
+				if method := c.getOperatorMethod(expr, result.Type, "neg", expr.Operator.String(), 0); method != nil {
+					expr.Result().SetValue(method.Returns(), 0, method)
+				}
+
+				return
+			}
+
 			if v, ok := ast.As[*ast.Primitive](result.Type); ok {
 				if ast.IsFloating(v.Kind) || ast.IsSigned(v.Kind) {
 					expr.Result().SetValue(result.Type, 0, nil)
@@ -411,7 +448,9 @@ func (c *checker) VisitBinary(expr *ast.Binary) {
 	}
 
 	// Check
-	c.checkBinary(expr, expr.Left, expr.Right, expr.Operator, false)
+	if !c.checkBinaryOperator(expr, expr.Left, expr.Right, expr.Operator, false) {
+		c.checkBinary(expr, expr.Left, expr.Right, expr.Operator, false)
+	}
 }
 
 func (c *checker) VisitLogical(expr *ast.Logical) {
@@ -427,6 +466,116 @@ func (c *checker) VisitLogical(expr *ast.Logical) {
 	expr.Result().SetValue(&ast.Primitive{Kind: ast.Bool}, 0, nil)
 }
 
+func (c *checker) VisitClosure(expr *ast.Closure) {
+	if expr.Function == nil {
+		expr.Result().SetInvalid()
+		return
+	}
+
+	// Resolve captures
+	infos := make([]closureInfo, len(expr.Captures))
+
+	for i, capture := range expr.Captures {
+		if capture.Name == nil {
+			continue
+		}
+
+		variable := c.getVariable(capture.Name.String())
+
+		if variable == nil {
+			c.error(capture.Name, "Unknown variable '%s'", capture.Name)
+			continue
+		}
+
+		variable.used = true
+
+		capture.ActualType = variable.type_
+		infos[i] = variable.closure
+	}
+
+	// Save state, the body of a closure can only see captured variables
+	prevFunction := c.function
+	prevLoops := c.loops
+	prevLoopDepths := c.loopDepths
+	prevDeferDepth := c.deferDepth
+	prevScopes := c.scopes
+	prevVariables := c.variables
+	prevClosureVariables := c.closureVariables
+
+	c.closureVariables = append(append([]variable(nil), c.closureVariables...), c.variables...)
+
+	c.function = expr.Function
+	c.loops = nil
+	c.loopDepths = nil
+	c.deferDepth = 0
+	c.scopes = nil
+	c.variables = nil
+
+	c.pushScope()
+
+	// Captures
+	for i, capture := range expr.Captures {
+		if capture.ActualType == nil {
+			continue
+		}
+
+		if c.hasVariableInScope(capture.Name) {
+			c.error(capture.Name, "Variable '%s' is already captured", capture.Name)
+		} else if v := c.addVariable(capture.Name, capture.ActualType, capture); v != nil {
+			v.closure = infos[i]
+		}
+	}
+
+	// Params
+	for _, param := range expr.Function.Params {
+		if param.Value != nil {
+			c.error(param.Value, "Closure parameters can't have default values")
+		}
+
+		if c.hasVariableInScope(param.Name) {
+			c.error(param.Name, "Parameter with the name '%s' already exists", param.Name)
+		} else {
+			if v := c.addVariable(param.Name, param.Type, param); v != nil {
+				v.param = true
+				v.closure.outer = mayHoldClosure(param.Type, nil)
+			}
+		}
+	}
+
+	// Body
+	for _, stmt := range expr.Function.Body {
+		c.VisitNode(stmt)
+	}
+
+	// Restore state
+	c.popScope()
+
+	c.function = prevFunction
+	c.loops = prevLoops
+	c.loopDepths = prevLoopDepths
+	c.deferDepth = prevDeferDepth
+	c.scopes = prevScopes
+	c.variables = prevVariables
+	c.closureVariables = prevClosureVariables
+
+	// Check last return
+	if !ast.IsPrimitive(expr.Function.Returns(), ast.Void) {
+		valid := len(expr.Function.Body) > 0
+
+		if valid {
+			if _, ok := expr.Function.Body[len(expr.Function.Body)-1].(*ast.Return); !ok {
+				valid = false
+			}
+		}
+
+		if !valid {
+			c.error(expr, "Closure needs to return a '%s' value", ast.PrintType(expr.Function.Returns()))
+		}
+	}
+
+	expr.Result().SetValue(expr.Function, 0, nil)
+}
+
 func (c *checker) VisitIdentifier(expr *ast.Identifier) {
 	expr.AcceptChildren(c)
 
@@ -435,6 +584,8 @@ func (c *checker) VisitIdentifier(expr *ast.Identifier) {
 		return
 	}
 
+	defer c.checkAccessible(expr, expr.Name)
+
 	// Function / function pointer
 	if parentWantsFunction(expr) {
 		var function ast.FuncType
@@ -476,7 +627,12 @@ func (c *checker) VisitIdentifier(expr *ast.Identifier) {
 		}
 
 		// Error
-		c.error(expr, "Unknown function")
+		if c.isUncapturedVariable(expr.Name) {
+			c.error(expr, "Variable '%s' needs to be captured to be used inside the closure", expr.Name)
+		} else {
+			c.error(expr, "Unknown function")
+		}
+
 		expr.Result().SetInvalid()
 
 		return
@@ -520,8 +676,24 @@ func (c *checker) VisitIdentifier(expr *ast.Identifier) {
 		return
 	}
 
+	// Constant
+	if constant := getStructConstant(expr, expr.Name.String()); constant != nil {
+		expr.Result().SetValue(constant.Type, 0, constant)
+		return
+	}
+
+	if constant := c.resolver.GetConstant(expr.Name.String()); constant != nil {
+		expr.Result().SetValue(constant.Type, 0, constant)
+		return
+	}
+
 	// Error
-	c.error(expr, "Unknown identifier")
+	if c.isUncapturedVariable(expr.Name) {
+		c.error(expr, "Variable '%s' needs to be captured to be used inside the closure", expr.Name)
+	} else {
+		c.error(expr, "Unknown identifier")
+	}
+
 	expr.Result().SetInvalid()
 }
 
@@ -553,9 +725,12 @@ func (c *checker) VisitAssignment(expr *ast.Assignment) {
 	if expr.Operator.Token().Kind == scanner.Equal {
 		// Equal
 		c.checkRequired(expr.Assignee.Result().Type, expr.Value)
+		c.checkClosureAssignment(expr.Assignee, expr.Value)
 	} else {
 		// Binary
-		c.checkBinary(expr, expr.Assignee, expr.Value, expr.Operator, true)
+		if !c.checkBinaryOperator(expr, expr.Assignee, expr.Value, expr.Operator, true) {
+			c.checkBinary(expr, expr.Assignee, expr.Value, expr.Operator, true)
+		}
 
 		if expr.Result().Kind == ast.InvalidResultKind {
 			panic("checker.VisitAssignment() - Not implemented")
@@ -592,7 +767,7 @@ func (c *checker) VisitCast(expr *ast.Cast) {
 		expr.Result().SetValue(expr.Target, 0, nil)
 
 	case scanner.Is:
-		if _, ok := ast.As[*ast.Interface](expr.Value.Result().Type); !ok {
+		if _, ok := ast.As[ast.InterfaceType](expr.Value.Result().Type); !ok {
 			c.error(expr.Value, "Runtime type checking is only supported for interfaces")
 		}
 
@@ -606,20 +781,158 @@ func (c *checker) VisitCast(expr *ast.Cast) {
 func (c *checker) VisitTypeCall(expr *ast.TypeCall) {
 	expr.AcceptChildren(c)
 
-	expr.Result().SetValue(&ast.Primitive{Kind: ast.I32}, 0, nil)
+	switch expr.Callee.String() {
+	case "countof":
+		expr.Result().SetValue(&ast.Primitive{Kind: ast.I32}, 0, nil)
+		c.checkEnumArg(expr.Arg)
+
+	case "casesof":
+		enum := c.checkEnumArg(expr.Arg)
+
+		if enum == nil {
+			expr.Result().SetInvalid()
+			return
+		}
+
+		if enum.IsTagged() {
+			c.error(expr.Arg, "Cases of enums with fields can't be listed")
+		}
+
+		expr.Result().SetValue(&ast.Slice{Base: enum}, 0, nil)
+
+	default:
+		expr.Result().SetValue(&ast.Primitive{Kind: ast.I32}, 0, nil)
+	}
+}
+
+// checkEnumArg reports an error if the type argument of a type call is not an enum.
+func (c *checker) checkEnumArg(arg ast.Type) *ast.Enum {
+	if arg == nil {
+		return nil
+	}
+
+	enum, ok := ast.As[*ast.Enum](arg)
+	if !ok {
+		c.error(arg, "Expected an enum but got a '%s'", ast.PrintType(arg))
+		return nil
+	}
+
+	return enum
 }
 
 func (c *checker) VisitTypeof(expr *ast.Typeof) {
 	expr.AcceptChildren(c)
 
-	expr.Result().SetValue(&ast.Primitive{Kind: ast.U32}, 0, nil)
+	if expr.Callee.String() == "nameof" {
+		expr.Result().SetValue(&ast.Pointer{Pointee: &ast.Primitive{Kind: ast.U8}}, 0, nil)
+	} else {
+		expr.Result().SetValue(common.GetTypeInfo(c.root), 0, nil)
+	}
 
 	// Check arg
-	if expr.Arg != nil && expr.Arg.Result().Kind == ast.InvalidResultKind {
+	if expr.Arg == nil {
+		return
+	}
+
+	if expr.Arg.Result().Kind == ast.InvalidResultKind {
 		c.error(expr.Arg, "Invalid expression")
+		return
+	}
+
+	switch expr.Callee.String() {
+	case "nameof":
+		if expr.Arg.Result().Kind != ast.ValueResultKind {
+			c.error(expr.Arg, "Invalid value")
+		} else if _, ok := ast.As[*ast.Enum](expr.Arg.Result().Type); !ok {
+			c.error(expr.Arg, "Expected an enum but got a '%s'", ast.PrintType(expr.Arg.Result().Type))
+		}
+
+	case "dyntypeof":
+		if expr.Arg.Result().Kind != ast.ValueResultKind {
+			c.error(expr.Arg, "Invalid value")
+		} else if _, ok := ast.As[ast.InterfaceType](expr.Arg.Result().Type); !ok {
+			c.error(expr.Arg, "Dynamic type information is only supported for interfaces")
+		}
+	}
+}
+
+func (c *checker) VisitAsm(expr *ast.Asm) {
+	expr.AcceptChildren(c)
+
+	expr.Result().SetValue(&ast.Primitive{Kind: ast.Void}, 0, nil)
+
+	// Check operands
+	for _, output := range expr.Outputs {
+		c.checkAsmOperand(expr, output, true)
+	}
+
+	for _, input := range expr.Inputs {
+		c.checkAsmOperand(expr, input, false)
+	}
+
+	// Check clobbers
+	for i, clobber := range expr.Clobbers {
+		if expr.ClobberString(i) == "" {
+			c.error(clobber, "Clobber can't be empty")
+		}
 	}
 }
 
+func (c *checker) checkAsmOperand(expr *ast.Asm, operand *ast.AsmOperand, output bool) {
+	if operand.Constraint == nil {
+		return
+	}
+
+	// Check constraint
+	constraint := operand.ConstraintString()
+
+	if output {
+		if !strings.HasPrefix(constraint, "=") {
+			c.error(operand.Constraint, "Output constraint needs to start with '='")
+		}
+
+		constraint = strings.TrimPrefix(constraint, "=")
+	} else if strings.HasPrefix(constraint, "=") || strings.HasPrefix(constraint, "+") || strings.HasPrefix(constraint, "~") {
+		c.error(operand.Constraint, "Input constraint can't start with '%c'", constraint[0])
+	}
+
+	if constraint == "" {
+		c.error(operand.Constraint, "Constraint can't be empty")
+	} else if strings.Contains(constraint, "*") {
+		c.error(operand.Constraint, "Indirect operands are not supported")
+	} else if index, err := strconv.ParseUint(constraint, 10, 32); err == nil && !output && index >= uint64(len(expr.Outputs)) {
+		// Tied inputs refer to an output by its index
+		c.error(operand.Constraint, "Tied input refers to output %d which doesn't exist", index)
+	}
+
+	// Check value
+	if operand.Value == nil || operand.Value.Result().Kind == ast.InvalidResultKind {
+		return
+	}
+
+	if operand.Value.Result().Kind != ast.ValueResultKind {
+		c.error(operand.Value, "Invalid value")
+		return
+	}
+
+	if output && !operand.Value.Result().IsAssignable() {
+		c.error(operand.Value, "Cannot assign to this value")
+	}
+
+	if !isAsmOperandType(operand.Value.Result().Type) {
+		c.error(operand.Value, "Inline assembly operands need to be a number or a pointer but got a '%s'", ast.PrintType(operand.Value.Result().Type))
+	}
+}
+
+func isAsmOperandType(type_ ast.Type) bool {
+	if v, ok := ast.As[*ast.Primitive](type_); ok {
+		return ast.IsNumber(v.Kind)
+	}
+
+	_, ok := ast.As[*ast.Pointer](type_)
+	return ok
+}
+
 func (c *checker) VisitCall(expr *ast.Call) {
 	expr.AcceptChildren(c)
 
@@ -629,6 +942,20 @@ func (c *checker) VisitCall(expr *ast.Call) {
 		return
 	}
 
+	// Enum case
+	if expr.Callee.Result().Kind == ast.ValueResultKind {
+		if case_, ok := expr.Callee.Result().Value().(*ast.EnumCase); ok {
+			c.checkEnumCaseCall(expr, case_)
+			return
+		}
+
+		if enum, ok := expr.Callee.Result().Value().(*ast.Enum); ok {
+			c.checkFlagsCall(expr, enum)
+			return
+		}
+	}
+
+	// Function
 	var function ast.FuncType
 
 	if f, ok := ast.As[ast.FuncType](expr.Callee.Result().Type); ok {
@@ -639,25 +966,175 @@ func (c *checker) VisitCall(expr *ast.Call) {
 		return
 	}
 
+	// Match arguments to parameters
+	args := c.matchArgs(expr, function)
+
+	// Infer generic arguments
+	if sf, ok := function.(ast.SpecializableFunc); ok && len(sf.Generics()) != 0 && expr.Callee.Result().Kind == ast.CallableResultKind {
+		function = c.inferCall(expr, sf, args)
+
+		if function == nil {
+			expr.Result().SetInvalid()
+			return
+		}
+
+		expr.Callee.Result().SetCallable(function, function)
+	}
+
 	expr.Result().SetValue(function.Returns(), 0, nil)
+	expr.ActualArgs = args
+
+	// Check argument types
+	toCheck := min(function.ParameterCount(), len(args))
+
+	for i := 0; i < toCheck; i++ {
+		arg := args[i]
+		param := function.ParameterIndex(i)
+
+		// Default values are checked together with the function
+		if arg == nil || arg.Parent() != expr || arg.Result().Kind == ast.InvalidResultKind {
+			continue
+		}
+
+		if arg.Result().Kind != ast.ValueResultKind {
+			c.error(arg, "Invalid value")
+			continue
+		}
+
+		c.checkRequired(param.Type, arg)
+
+		// Extern functions receive function values as plain code pointers
+		if _, ok := ast.As[ast.FuncType](param.Type); ok && function.Underlying().ExternName() != "" && !isCodePointer(arg) {
+			c.error(arg, "Only function references and closures without captures can be passed to extern functions")
+		}
+	}
+
+	// Atomic intrinsics are lowered to instructions which need the memory order at compile time
+	if intrinsic := function.Underlying().IntrinsicName(); ast.IsAtomicIntrinsic(intrinsic) && len(args) > 0 {
+		if arg := args[len(args)-1]; arg != nil {
+			_, isDefault := arg.Parent().(*ast.Param)
+			expr.MemoryOrder = c.getMemoryOrder(intrinsic, arg, !isDefault)
+		}
+	}
+}
+
+// matchArgs returns the arguments of the call in the order of the function parameters. Named arguments are placed at
+// the index of their parameter and missing arguments are replaced with the default values of the parameters.
+func (c *checker) matchArgs(expr *ast.Call, function ast.FuncType) []ast.Expr {
+	args := make([]ast.Expr, function.ParameterCount())
+	positional := 0
+	named := false
+
+	for _, arg := range expr.Args {
+		// Named
+		if namedArg, ok := arg.(*ast.NamedArg); ok {
+			named = true
+
+			if namedArg.Name == nil {
+				continue
+			}
+
+			index := getParamIndex(function, namedArg.Name.String())
+
+			if index == -1 {
+				c.error(namedArg.Name, "Function does not have a parameter with the name '%s'", namedArg.Name)
+			} else if args[index] != nil {
+				c.error(namedArg.Name, "Parameter '%s' already has an argument", namedArg.Name)
+			} else {
+				args[index] = arg
+			}
+
+			continue
+		}
+
+		// Positional
+		if named {
+			c.error(arg, "Positional arguments can't follow named arguments")
+			continue
+		}
+
+		if positional < len(args) {
+			args[positional] = arg
+		} else {
+			args = append(args, arg)
+		}
+
+		positional++
+	}
 
 	// Check argument count
-	if function.Underlying().IsVariadic() {
-		if len(expr.Args) < function.ParameterCount() {
-			c.error(expr, "Got '%d' arguments but function takes at least '%d'", len(expr.Args), function.ParameterCount())
+	hasDefaults := false
+
+	for i := 0; i < function.ParameterCount(); i++ {
+		if function.ParameterIndex(i).Param.Value != nil {
+			hasDefaults = true
+			break
 		}
-	} else {
-		if function.ParameterCount() != len(expr.Args) {
-			c.error(expr, "Got '%d' arguments but function takes '%d'", len(expr.Args), function.ParameterCount())
+	}
+
+	if positional > function.ParameterCount() && !function.Underlying().IsVariadic() {
+		c.error(expr, "Got '%d' arguments but function takes '%d'", positional, function.ParameterCount())
+	}
+
+	if !named && !hasDefaults && positional < function.ParameterCount() {
+		if function.Underlying().IsVariadic() {
+			c.error(expr, "Got '%d' arguments but function takes at least '%d'", positional, function.ParameterCount())
+		} else {
+			c.error(expr, "Got '%d' arguments but function takes '%d'", positional, function.ParameterCount())
+		}
+
+		return args
+	}
+
+	// Default values
+	for i := 0; i < function.ParameterCount(); i++ {
+		if args[i] != nil {
+			continue
+		}
+
+		param := function.ParameterIndex(i).Param
+
+		if param.Value != nil {
+			args[i] = param.Value
+		} else if param.Name != nil {
+			c.error(expr, "Missing argument for parameter '%s'", param.Name)
+		}
+	}
+
+	return args
+}
+
+func getParamIndex(function ast.FuncType, name string) int {
+	for i := 0; i < function.ParameterCount(); i++ {
+		param := function.ParameterIndex(i).Param
+
+		if param.Name != nil && param.Name.String() == name {
+			return i
 		}
 	}
 
+	return -1
+}
+
+func (c *checker) checkEnumCaseCall(expr *ast.Call, case_ *ast.EnumCase) {
+	expr.Result().SetValue(expr.Callee.Result().Type, 0, nil)
+
+	for _, arg := range expr.Args {
+		if _, ok := arg.(*ast.NamedArg); ok {
+			c.error(arg, "Enum cases don't support named arguments")
+		}
+	}
+
+	// Check argument count
+	if len(case_.Fields) != len(expr.Args) {
+		c.error(expr, "Got '%d' arguments but enum case takes '%d'", len(expr.Args), len(case_.Fields))
+	}
+
 	// Check argument types
-	toCheck := min(function.ParameterCount(), len(expr.Args))
+	toCheck := min(len(case_.Fields), len(expr.Args))
 
 	for i := 0; i < toCheck; i++ {
 		arg := expr.Args[i]
-		param := function.ParameterIndex(i)
 
 		if arg.Result().Kind == ast.InvalidResultKind {
 			continue
@@ -668,10 +1145,44 @@ func (c *checker) VisitCall(expr *ast.Call) {
 			continue
 		}
 
-		c.checkRequired(param.Type, arg)
+		c.checkRequired(case_.Fields[i].Type(), arg)
 	}
 }
 
+// checkFlagsMember resolves the built-in methods of flags enums. The enum is stored as the node of the result so the
+// call can recognize them.
+func (c *checker) checkFlagsMember(expr *ast.Member, enum *ast.Enum) {
+	if expr.Name.String() != "has" {
+		c.error(expr.Name, "Flags enum '%s' does not contain method '%s'", ast.PrintType(enum), expr.Name)
+		return
+	}
+
+	if call, ok := expr.Parent().(*ast.Call); !ok || call.Callee != expr {
+		c.error(expr.Name, "Method '%s' of flags enums needs to be called", expr.Name)
+		return
+	}
+
+	expr.Result().SetValue(enum, 0, enum)
+}
+
+func (c *checker) checkFlagsCall(expr *ast.Call, enum *ast.Enum) {
+	expr.Result().SetValue(&ast.Primitive{Kind: ast.Bool}, 0, nil)
+
+	if len(expr.Args) != 1 {
+		c.error(expr, "Got '%d' arguments but function takes '1'", len(expr.Args))
+		return
+	}
+
+	arg := expr.Args[0]
+
+	if named, ok := arg.(*ast.NamedArg); ok {
+		c.error(named.Name, "Method 'has' doesn't support named arguments")
+		return
+	}
+
+	c.checkRequired(enum, arg)
+}
+
 func (c *checker) VisitIndex(expr *ast.Index) {
 	expr.AcceptChildren(c)
 
@@ -679,18 +1190,28 @@ func (c *checker) VisitIndex(expr *ast.Index) {
 		return // Do not cascade errors
 	}
 
+	// Struct
+	if expr.Value.Result().Kind == ast.ValueResultKind {
+		if _, ok := ast.As[ast.StructType](expr.Value.Result().Type); ok {
+			c.checkIndexOperator(expr)
+			return
+		}
+	}
+
 	// Check value
 	var base ast.Type
 
 	if expr.Value.Result().Kind == ast.ValueResultKind {
 		if v, ok := ast.As[*ast.Array](expr.Value.Result().Type); ok {
 			base = v.Base
+		} else if v, ok := ast.As[*ast.Slice](expr.Value.Result().Type); ok {
+			base = v.Base
 		} else if v, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); ok {
 			base = v.Pointee
 		}
 
 		if base == nil {
-			c.error(expr.Value, "Can only index into array and pointer types, not '%s'", ast.PrintType(expr.Value.Result().Type))
+			c.error(expr.Value, "Can only index into array, slice and pointer types, not '%s'", ast.PrintType(expr.Value.Result().Type))
 		}
 	} else {
 		c.error(expr.Value, "Invalid value")
@@ -727,6 +1248,101 @@ func (c *checker) VisitIndex(expr *ast.Index) {
 	}
 }
 
+func (c *checker) checkIndexOperator(expr *ast.Index) {
+	method := c.getOperatorMethod(expr, expr.Value.Result().Type, common.IndexMethod, "[]", 1)
+	if method == nil {
+		return
+	}
+
+	if expr.Index != nil && expr.Index.Result().Kind != ast.InvalidResultKind {
+		if expr.Index.Result().Kind == ast.ValueResultKind {
+			c.checkRequired(method.ParameterIndex(0).Type, expr.Index)
+		} else {
+			c.error(expr.Index, "Invalid value")
+		}
+	}
+
+	// Index methods returning a pointer produce an assignable value
+	if pointer, ok := ast.As[*ast.Pointer](method.Returns()); ok {
+		expr.Result().SetValue(pointer.Pointee, ast.AssignableFlag|ast.AddressableFlag, method)
+	} else {
+		expr.Result().SetValue(method.Returns(), 0, method)
+	}
+}
+
+func (c *checker) VisitSlicing(expr *ast.Slicing) {
+	expr.AcceptChildren(c)
+
+	if expr.Value == nil || expr.Value.Result().Kind == ast.InvalidResultKind {
+		return // Do not cascade errors
+	}
+
+	// Check value
+	var base ast.Type
+	count := -1
+
+	if expr.Value.Result().Kind == ast.ValueResultKind {
+		if v, ok := ast.As[*ast.Array](expr.Value.Result().Type); ok {
+			base = v.Base
+			count = int(v.Count)
+
+			if !expr.Value.Result().IsAddressable() {
+				c.error(expr.Value, "Cannot slice a temporary array")
+			}
+		} else if v, ok := ast.As[*ast.Slice](expr.Value.Result().Type); ok {
+			base = v.Base
+		} else if v, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); ok {
+			base = v.Pointee
+
+			if expr.High == nil {
+				c.error(expr, "Slicing a pointer requires an upper bound")
+			}
+		}
+
+		if base == nil {
+			c.error(expr.Value, "Can only slice array, slice and pointer types, not '%s'", ast.PrintType(expr.Value.Result().Type))
+		}
+	} else {
+		c.error(expr.Value, "Invalid value")
+	}
+
+	if base != nil {
+		expr.Result().SetValue(&ast.Slice{Base: base}, 0, nil)
+	} else {
+		expr.Result().SetInvalid()
+	}
+
+	// Check bounds
+	for _, bound := range []ast.Expr{expr.Low, expr.High} {
+		if bound != nil && bound.Result().Kind != ast.InvalidResultKind {
+			if bound.Result().Kind == ast.ValueResultKind {
+				if v, ok := ast.As[*ast.Primitive](bound.Result().Type); !ok || !ast.IsInteger(v.Kind) {
+					c.error(bound, "Can only slice using integer types, not '%s'", ast.PrintType(bound.Result().Type))
+				}
+			} else {
+				c.error(bound, "Invalid value")
+			}
+		}
+	}
+
+	// Check constant bounds
+	low, lowOk := c.evaluator.getInteger(expr.Low)
+	high, highOk := c.evaluator.getInteger(expr.High)
+
+	if lowOk && highOk && low > high {
+		c.error(expr, "Lower bound '%d' is greater than the upper bound '%d'", low, high)
+	}
+
+	if count != -1 {
+		if lowOk && low > int64(count) {
+			c.error(expr.Low, "Lower bound '%d' is out of range for an array of length '%d'", low, count)
+		}
+		if highOk && high > int64(count) {
+			c.error(expr.High, "Upper bound '%d' is out of range for an array of length '%d'", high, count)
+		}
+	}
+}
+
 func (c *checker) VisitMember(expr *ast.Member) {
 	expr.AcceptChildren(c)
 
@@ -736,13 +1352,15 @@ func (c *checker) VisitMember(expr *ast.Member) {
 		return
 	}
 
+	defer c.checkAccessible(expr, expr.Name)
+
 	switch expr.Value.Result().Kind {
 	case ast.TypeResultKind:
 		switch t := expr.Value.Result().Type.(type) {
 		case ast.StructType:
 			// Callable
 			if parentWantsFunction(expr) {
-				function := c.resolver.GetMethod(t, expr.Name.String(), true)
+				function := c.getMethod(t, expr.Name.String(), true)
 
 				if function != nil {
 					c.specializeFuncIfNeeded(expr, function, expr.GenericArgs)
@@ -768,6 +1386,12 @@ func (c *checker) VisitMember(expr *ast.Member) {
 				return
 			}
 
+			// Constant
+			if constant := t.Underlying().GetConstant(expr.Name.String()); constant != nil {
+				expr.Result().SetValue(constant.Type, 0, constant)
+				return
+			}
+
 			// Field
 			field := t.StaticFieldName(expr.Name.String())
 
@@ -787,6 +1411,18 @@ func (c *checker) VisitMember(expr *ast.Member) {
 				return
 			}
 
+			_, isArm := expr.Parent().(*ast.MatchArm)
+			call, isCall := expr.Parent().(*ast.Call)
+			isCall = isCall && call.Callee == expr
+
+			if len(case_.Fields) > 0 && !isCall && !isArm {
+				c.error(expr.Name, "Enum case '%s' carries a payload and needs to be constructed with arguments", expr.Name)
+				return
+			} else if len(case_.Fields) == 0 && isCall {
+				c.error(expr.Name, "Enum case '%s' does not carry a payload", expr.Name)
+				return
+			}
+
 			expr.Result().SetValue(t, 0, case_)
 			return
 
@@ -845,11 +1481,38 @@ func (c *checker) VisitMember(expr *ast.Member) {
 			return
 		}
 
+		// Constant
+		if constant := resolver.GetConstant(expr.Name.String()); constant != nil {
+			expr.Result().SetValue(constant.Type, 0, constant)
+			return
+		}
+
 		// Error
 		c.error(expr.Name, "Unknown identifier")
 		expr.Result().SetInvalid()
 
 	case ast.ValueResultKind:
+		// Slice
+		if slice, ok := ast.As[*ast.Slice](expr.Value.Result().Type); ok {
+			switch expr.Name.String() {
+			case "ptr":
+				expr.Result().SetValue(&ast.Pointer{Pointee: slice.Base}, 0, nil)
+			case "len":
+				expr.Result().SetValue(&ast.Primitive{Kind: ast.U64}, 0, nil)
+
+			default:
+				c.error(expr.Name, "Slice '%s' does not contain field '%s'", ast.PrintType(slice), expr.Name)
+			}
+
+			return
+		}
+
+		// Flags
+		if enum, ok := ast.As[*ast.Enum](expr.Value.Result().Type); ok && enum.IsFlags() {
+			c.checkFlagsMember(expr, enum)
+			return
+		}
+
 		// Get struct
 		var s ast.StructType
 
@@ -862,7 +1525,19 @@ func (c *checker) VisitMember(expr *ast.Member) {
 		}
 
 		if s == nil {
-			if inter, ok := ast.As[*ast.Interface](expr.Value.Result().Type); ok {
+			// Generic
+			generic, _ := expr.Value.Result().Type.Resolved().(*ast.Generic)
+
+			if v, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); ok {
+				generic, _ = v.Pointee.Resolved().(*ast.Generic)
+			}
+
+			if generic != nil {
+				c.checkGenericMember(expr, generic)
+				return
+			}
+
+			if inter, ok := ast.As[ast.InterfaceType](expr.Value.Result().Type); ok {
 				// Interface
 				method, _ := inter.GetMethod(expr.Name.String())
 
@@ -881,7 +1556,7 @@ func (c *checker) VisitMember(expr *ast.Member) {
 
 		// Callable
 		if parentWantsFunction(expr) {
-			function := c.resolver.GetMethod(s, expr.Name.String(), false)
+			function := c.getMethod(s, expr.Name.String(), false)
 
 			if function != nil {
 				c.specializeFuncIfNeeded(expr, function, expr.GenericArgs)
@@ -926,6 +1601,15 @@ func (c *checker) VisitMember(expr *ast.Member) {
 
 // Utils
 
+// getMethod returns a method of the struct, methods declared in the namespace of the struct are found without a using.
+func (c *checker) getMethod(s ast.StructType, name string, static bool) ast.FuncType {
+	if method := c.resolver.GetMethod(s, name, static); method != nil || s == nil {
+		return method
+	}
+
+	return getTypeResolver(s, c.resolver).GetMethod(s, name, static)
+}
+
 func parentWantsFunction(expr ast.Expr) bool {
 	switch parent := expr.Parent().(type) {
 	case *ast.Call:
@@ -939,21 +1623,63 @@ func parentWantsFunction(expr ast.Expr) bool {
 	}
 }
 
+func isCodePointer(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Paren:
+		return expr.Expr != nil && isCodePointer(expr.Expr)
+
+	case *ast.NamedArg:
+		return expr.Value != nil && isCodePointer(expr.Value)
+
+	case *ast.Unary:
+		return expr.Prefix && expr.Operator.Token().Kind == scanner.FuncPtr
+
+	case *ast.Closure:
+		return len(expr.Captures) == 0
+
+	default:
+		return false
+	}
+}
+
 func (c *checker) specializeFuncIfNeeded(expr ast.Expr, f ast.FuncType, genericArgs []ast.Type) {
-	if sf, ok := f.(ast.SpecializableFunc); ok {
-		if len(genericArgs) != len(sf.Generics()) {
-			if genericArgs != nil {
-				errorSlice(c, genericArgs, "Got '%d' generic arguments but function takes '%d'", len(genericArgs), len(sf.Generics()))
+	sf, ok := f.(ast.SpecializableFunc)
+	if !ok || (len(sf.Generics()) == 0 && len(genericArgs) == 0) {
+		expr.Result().SetCallable(f, f)
+		return
+	}
+
+	// Infer generic arguments
+	if len(genericArgs) == 0 {
+		switch parent := expr.Parent().(type) {
+		case *ast.Call:
+			// Generic arguments of calls are inferred after the arguments are checked
+			expr.Result().SetCallable(f, f)
+
+		case *ast.Unary:
+			if specialized := c.inferFuncPointer(expr, sf); specialized != nil {
+				expr.Result().SetCallable(specialized, specialized)
 			} else {
-				c.error(expr, "Got '%d' generic arguments but function takes '%d'", len(genericArgs), len(sf.Generics()))
+				parent.Result().SetInvalid()
+				expr.Result().SetInvalid()
 			}
 		}
 
-		specialized := sf.Specialize(genericArgs)
-		expr.Result().SetCallable(specialized, specialized)
-	} else {
-		expr.Result().SetCallable(f, f)
+		return
+	}
+
+	// Explicit generic arguments
+	if len(genericArgs) != len(sf.Generics()) {
+		errorSlice(c, genericArgs, "Got '%d' generic arguments but function takes '%d'", len(genericArgs), len(sf.Generics()))
+		expr.Result().SetInvalid()
+
+		return
 	}
+
+	c.checkGenericArgs(expr, sf.Generics(), genericArgs)
+
+	specialized := sf.Specialize(genericArgs)
+	expr.Result().SetCallable(specialized, specialized)
 }
 
 func (c *checker) checkBinary(expr, left, right ast.Expr, operator *ast.Token, assignment bool) {
@@ -991,12 +1717,22 @@ func (c *checker) checkBinary(expr, left, right ast.Expr, operator *ast.Token, a
 
 	// Equality
 	if !assignment && scanner.IsEquality(operator.Token().Kind) {
+		if _, ok := ast.As[*ast.Slice](castType); ok && castOk {
+			c.error(expr, "Operator '%s' cannot be applied to slices", operator.String())
+			return
+		}
+
+		if enum, ok := ast.As[*ast.Enum](castType); ok && castOk && enum.IsTagged() {
+			c.error(expr, "Operator '%s' cannot be applied to enum '%s' because its cases carry a payload, use a match statement instead", operator.String(), ast.PrintType(enum))
+			return
+		}
+
 		if castOk {
 			expr.Result().SetValue(&ast.Primitive{Kind: ast.Bool}, 0, nil)
 			return
 		}
 
-		if _, ok := ast.As[*ast.Interface](leftType); ok {
+		if _, ok := ast.As[ast.InterfaceType](leftType); ok {
 			if _, ok := ast.As[*ast.Pointer](rightType); ok {
 				expr.Result().SetValue(&ast.Primitive{Kind: ast.Bool}, 0, nil)
 				return
@@ -1022,6 +1758,19 @@ func (c *checker) checkBinary(expr, left, right ast.Expr, operator *ast.Token, a
 		return
 	}
 
+	// Flags
+	if isFlagsOperator(operator.Token().Kind) && isFlagsType(leftType) {
+		if left, ok := ast.As[*ast.Enum](leftType); ok {
+			if right, ok := ast.As[*ast.Enum](rightType); ok && left == right {
+				expr.Result().SetValue(leftType, 0, nil)
+				return
+			}
+		}
+
+		c.error(expr, "Operator '%s' cannot be applied to '%s' and '%s'", operator.String(), ast.PrintType(leftType), ast.PrintType(rightType))
+		return
+	}
+
 	// Bitwise
 	if !assignment && scanner.IsBitwise(operator.Token().Kind) {
 		if left, ok := ast.As[*ast.Primitive](leftType); ok {
@@ -1038,6 +1787,89 @@ func (c *checker) checkBinary(expr, left, right ast.Expr, operator *ast.Token, a
 	}
 }
 
+// checkBinaryOperator resolves a binary operator applied to a struct to the method implementing it. The method is stored
+// as the node of the expression result.
+func (c *checker) checkBinaryOperator(expr, left, right ast.Expr, operator *ast.Token, assignment bool) bool {
+	leftType := left.Result().Type
+
+	if _, ok := ast.As[ast.StructType](leftType); !ok {
+		return false
+	}
+
+	name := common.GetOperatorMethodName(operator.Token().Kind, false)
+	if name == "" {
+		return false
+	}
+
+	method := c.getOperatorMethod(expr, leftType, name, operator.String(), 1)
+	if method == nil {
+		return true
+	}
+
+	c.checkRequired(method.ParameterIndex(0).Type, right)
+
+	kind := operator.Token().Kind
+	returns := method.Returns()
+
+	switch {
+	case scanner.IsEquality(kind):
+		if !ast.IsPrimitive(returns, ast.Bool) {
+			c.error(expr, "Operator method '%s' of '%s' needs to return a 'bool'", name, ast.PrintType(leftType))
+			return true
+		}
+
+		returns = &ast.Primitive{Kind: ast.Bool}
+
+	case scanner.IsComparison(kind):
+		if primitive, ok := ast.As[*ast.Primitive](returns); !ok || !ast.IsSigned(primitive.Kind) {
+			c.error(expr, "Operator method '%s' of '%s' needs to return a signed integer", name, ast.PrintType(leftType))
+			return true
+		}
+
+		returns = &ast.Primitive{Kind: ast.Bool}
+
+	case ast.IsPrimitive(returns, ast.Void):
+		c.error(expr, "Operator method '%s' of '%s' needs to return a value", name, ast.PrintType(leftType))
+		return true
+
+	case assignment:
+		if _, ok := common.GetImplicitCast(returns, leftType); !ok {
+			c.error(expr, "Expected a '%s' but got a '%s'", ast.PrintType(leftType), ast.PrintType(returns))
+			return true
+		}
+
+		returns = leftType
+	}
+
+	expr.Result().SetValue(returns, 0, method)
+	return true
+}
+
+// getOperatorMethod returns the method of a struct that implements an operator.
+func (c *checker) getOperatorMethod(expr ast.Expr, type_ ast.Type, name string, operator string, paramCount int) ast.FuncType {
+	s, _ := ast.As[ast.StructType](type_)
+	method := c.getMethod(s, name, false)
+
+	if method == nil {
+		c.error(expr, "Operator '%s' cannot be applied to '%s', it needs to declare a '%s' method", operator, ast.PrintType(type_), name)
+		return nil
+	}
+
+	if sf, ok := method.(ast.SpecializableFunc); ok && len(sf.Generics()) != 0 {
+		c.error(expr, "Operator method '%s' of '%s' can't be generic", name, ast.PrintType(type_))
+		return nil
+	}
+
+	if method.ParameterCount() != paramCount {
+		c.error(expr, "Operator method '%s' of '%s' needs to take '%d' parameters", name, ast.PrintType(type_), paramCount)
+		return nil
+	}
+
+	c.checkAccessibleDecl(expr, expr, name, method)
+
+	return method
+}
+
 func (c *checker) checkMalloc(expr ast.Expr) {
 	function := c.resolver.GetFunction("malloc")
 
@@ -1053,4 +1885,6 @@ func (c *checker) checkMalloc(expr ast.Expr) {
 	if _, ok := ast.As[*ast.Pointer](function.Returns()); !ok {
 		c.error(expr, "Malloc needs to return a pointer")
 	}
+
+	c.checkAccessibleDecl(expr, expr, "malloc", function)
 }
@@ -0,0 +1,425 @@
+package checker
+
+import (
+	"fireball/core/ast"
+	"fireball/core/scanner"
+	"slices"
+	"strings"
+)
+
+// checkGenericParams checks that the bounds of generic parameters are interfaces.
+func (c *checker) checkGenericParams(params []*ast.Generic) {
+	for _, param := range params {
+		if param.Bound == nil {
+			continue
+		}
+
+		if _, ok := ast.As[ast.InterfaceType](param.Bound); !ok {
+			c.error(param.Bound, "Generic bound needs to be an interface, not '%s'", ast.PrintType(param.Bound))
+		}
+	}
+}
+
+// checkGenericArgs checks that the generic arguments implement the interfaces their parameters are bound to.
+func (c *checker) checkGenericArgs(node ast.Node, params []*ast.Generic, args []ast.Type) {
+	if len(params) != len(args) {
+		return
+	}
+
+	for i, param := range params {
+		// Bounds can use the generic parameters themselves
+		inter, ok := ast.As[ast.InterfaceType](ast.SpecializeType(params, args, param.Bound))
+		if !ok {
+			continue
+		}
+
+		// Inferred arguments are reported at the node that uses them
+		var argNode ast.Node = args[i]
+		if args[i].Cst() == nil || args[i].Parent() != node {
+			argNode = node
+		}
+
+		if !c.implements(args[i], inter) {
+			c.error(argNode, "Type '%s' does not implement interface '%s' required by generic parameter '%s'", ast.PrintType(args[i]), ast.PrintType(inter), param)
+		}
+	}
+}
+
+// implements returns true if the type implements the interface. Specialized methods of generic structs that are
+// needed to call the interface methods on the type are created here so that they are available to the code generator.
+func (c *checker) implements(type_ ast.Type, inter ast.InterfaceType) bool {
+	// Generic types implement the interface they are bound to
+	if generic, ok := type_.Resolved().(*ast.Generic); ok {
+		bound, ok := ast.As[ast.InterfaceType](generic.Bound)
+		return ok && bound.Equals(inter)
+	}
+
+	if inter.Equals(type_) {
+		return true
+	}
+
+	s, ok := ast.As[ast.StructType](type_)
+	if !ok {
+		return false
+	}
+
+	resolver := getTypeResolver(s, c.resolver)
+
+	if resolver.GetImpl(s.Underlying(), inter) == nil {
+		if c.resolver.GetImpl(s.Underlying(), inter) == nil {
+			return false
+		}
+
+		resolver = c.resolver
+	}
+
+	if _, ok := s.(*ast.SpecializedStruct); ok {
+		for i := 0; i < inter.MethodCount(); i++ {
+			if name := inter.MethodIndex(i).Underlying().Name; name != nil {
+				resolver.GetMethod(s, name.String(), false)
+			}
+		}
+	}
+
+	return true
+}
+
+// checkGenericMember checks a member access on a value of a generic type, only methods of the interface bound to the
+// generic type can be called.
+func (c *checker) checkGenericMember(expr *ast.Member, generic *ast.Generic) {
+	inter, ok := ast.As[ast.InterfaceType](generic.Bound)
+
+	if !ok {
+		c.error(expr.Name, "Generic type '%s' is not bound to an interface and does not have any members", generic)
+		return
+	}
+
+	method, _ := inter.GetMethod(expr.Name.String())
+
+	if method == nil {
+		c.error(expr.Name, "Interface '%s' does not contain method with the name '%s'", ast.PrintType(inter), expr.Name)
+		return
+	}
+
+	if !parentWantsFunction(expr) {
+		c.error(expr.Name, "Methods of generic type '%s' can only be called", generic)
+		return
+	}
+
+	expr.Result().SetCallable(method, method)
+}
+
+// getTypeResolver returns the resolver of the file the struct is declared in.
+func getTypeResolver(s ast.StructType, fallback ast.Resolver) ast.Resolver {
+	if file := ast.GetParent[*ast.File](s.Underlying()); file != nil && file.Resolver != nil {
+		return file.Resolver
+	}
+
+	return fallback
+}
+
+// Inference
+
+// inferCall infers the generic arguments of a called function from the types of the arguments and the expected type
+// of the call. It returns the specialized function or nil if the arguments could not be inferred.
+func (c *checker) inferCall(expr *ast.Call, f ast.SpecializableFunc, args []ast.Expr) ast.FuncType {
+	inferrer := newInferrer(f.Generics())
+	var literals []int
+
+	for i := 0; i < min(f.ParameterCount(), len(args)); i++ {
+		arg := args[i]
+
+		if arg != nil && arg.Parent() == expr && arg.Result().Kind == ast.ValueResultKind && !isNilLiteral(arg) {
+			if isUntypedLiteral(arg) {
+				literals = append(literals, i)
+			} else {
+				inferrer.unify(f.ParameterIndex(i).Type, arg.Result().Type)
+			}
+		}
+	}
+
+	// Number literals only use their default type if no other argument determines the generic argument
+	for _, i := range literals {
+		inferrer.fill(f.ParameterIndex(i).Type, args[i].Result().Type)
+	}
+
+	// The expected type is only used for generic parameters that do not appear in the parameters
+	if expected := c.getExpectedType(expr); expected != nil {
+		inferrer.fill(f.Returns(), expected)
+	}
+
+	function := c.specializeInferred(expr.Callee, f, inferrer)
+
+	if function != nil {
+		for _, i := range literals {
+			c.retypeLiteral(args[i], function.ParameterIndex(i).Type)
+		}
+	}
+
+	return function
+}
+
+// retypeLiteral changes the type of an integer literal to the integer type inferred from the other arguments.
+func (c *checker) retypeLiteral(expr ast.Expr, type_ ast.Type) {
+	target, ok := ast.As[*ast.Primitive](type_)
+	if !ok || !ast.IsInteger(target.Kind) {
+		return
+	}
+
+	if current, ok := ast.As[*ast.Primitive](expr.Result().Type); !ok || !ast.IsInteger(current.Kind) {
+		return
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Paren:
+		c.retypeLiteral(expr.Expr, type_)
+
+	case *ast.Unary:
+		if !ast.IsSigned(target.Kind) {
+			return
+		}
+
+		c.retypeLiteral(expr.Value, type_)
+
+	case *ast.Literal:
+		c.evaluator.checkLiteral(expr, target.Kind)
+
+	default:
+		return
+	}
+
+	expr.Result().SetValue(target, 0, nil)
+}
+
+// inferFuncPointer infers the generic arguments of a function reference from the function type it is assigned to.
+func (c *checker) inferFuncPointer(expr ast.Expr, f ast.SpecializableFunc) ast.FuncType {
+	inferrer := newInferrer(f.Generics())
+
+	if expected, ok := ast.As[ast.FuncType](c.getExpectedType(expr.Parent().(ast.Expr))); ok {
+		inferrer.unify(f, expected)
+	}
+
+	return c.specializeInferred(expr, f, inferrer)
+}
+
+func (c *checker) specializeInferred(node ast.Node, f ast.SpecializableFunc, inferrer *inferrer) ast.FuncType {
+	types := make([]ast.Type, len(f.Generics()))
+	ok := true
+
+	for i, generic := range f.Generics() {
+		candidates := inferrer.candidates[i]
+
+		switch len(candidates) {
+		case 0:
+			c.error(node, "Cannot infer generic argument '%s', specify it explicitly with '![...]'", generic)
+			ok = false
+
+		case 1:
+			types[i] = candidates[0]
+
+		default:
+			str := strings.Builder{}
+
+			for j, candidate := range candidates {
+				if j > 0 {
+					str.WriteString(", ")
+				}
+
+				str.WriteRune('\'')
+				str.WriteString(ast.PrintType(candidate))
+				str.WriteRune('\'')
+			}
+
+			c.error(node, "Cannot infer generic argument '%s', conflicting candidates: %s", generic, str.String())
+			ok = false
+		}
+	}
+
+	if !ok {
+		return nil
+	}
+
+	c.checkGenericArgs(node, f.Generics(), types)
+	return f.Specialize(types)
+}
+
+// getExpectedType returns the type that the value of the expression needs to have based on where it is used, or nil
+// if it is not known.
+func (c *checker) getExpectedType(expr ast.Expr) ast.Type {
+	switch parent := expr.Parent().(type) {
+	case *ast.Paren:
+		return c.getExpectedType(parent)
+
+	case *ast.Var:
+		if parent.Value == expr {
+			return parent.Type
+		}
+
+	case *ast.Assignment:
+		if parent.Value == expr && parent.Operator.Token().Kind == scanner.Equal && parent.Assignee.Result().Kind == ast.ValueResultKind {
+			return parent.Assignee.Result().Type
+		}
+
+	case *ast.Return:
+		if c.function != nil {
+			return c.function.Returns()
+		}
+
+	case *ast.InitField:
+		if initializer, ok := parent.Parent().(*ast.StructInitializer); ok && parent.Name != nil {
+			if s, ok := ast.As[ast.StructType](initializer.Type); ok {
+				if field := s.FieldName(parent.Name.String()); field != nil {
+					return field.Type()
+				}
+			}
+		}
+
+	case *ast.Call:
+		if f := getNonGenericCallee(parent); f != nil {
+			if i := slices.Index(parent.Args, expr); i != -1 && i < f.ParameterCount() {
+				return f.ParameterIndex(i).Type
+			}
+		}
+
+	case *ast.NamedArg:
+		if call, ok := parent.Parent().(*ast.Call); ok && parent.Name != nil {
+			if f := getNonGenericCallee(call); f != nil {
+				if i := getParamIndex(f, parent.Name.String()); i != -1 {
+					return f.ParameterIndex(i).Type
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func getNonGenericCallee(call *ast.Call) ast.FuncType {
+	if call.Callee == nil || call.Callee.Result().Kind == ast.InvalidResultKind {
+		return nil
+	}
+
+	if f, ok := ast.As[ast.FuncType](call.Callee.Result().Type); ok {
+		if sf, ok := f.(ast.SpecializableFunc); !ok || len(sf.Generics()) == 0 {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func isNilLiteral(expr ast.Expr) bool {
+	literal, ok := expr.(*ast.Literal)
+	return ok && literal.Token().Kind == scanner.Nil
+}
+
+// isUntypedLiteral returns true for number literals without a suffix, which can be implicitly cast to other number
+// types.
+func isUntypedLiteral(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Paren:
+		return expr.Expr != nil && isUntypedLiteral(expr.Expr)
+
+	case *ast.Unary:
+		return expr.Prefix && expr.Operator != nil && expr.Operator.Token().Kind == scanner.Minus && expr.Value != nil && isUntypedLiteral(expr.Value)
+
+	case *ast.Literal:
+		switch expr.Token().Kind {
+		case scanner.Hex, scanner.Binary:
+			return true
+
+		case scanner.Number:
+			last := expr.String()[len(expr.String())-1]
+			return last != 'u' && last != 'U' && last != 'f' && last != 'F'
+		}
+	}
+
+	return false
+}
+
+// inferrer collects the candidate types for generic parameters by matching types that contain them against types
+// that are known.
+type inferrer struct {
+	generics   []*ast.Generic
+	candidates [][]ast.Type
+}
+
+func newInferrer(generics []*ast.Generic) *inferrer {
+	return &inferrer{
+		generics:   generics,
+		candidates: make([][]ast.Type, len(generics)),
+	}
+}
+
+// fill matches the types but only adds candidates for generic parameters that do not have any yet.
+func (i *inferrer) fill(type_ ast.Type, known ast.Type) {
+	other := newInferrer(i.generics)
+	other.unify(type_, known)
+
+	for j, candidates := range other.candidates {
+		if len(i.candidates[j]) == 0 {
+			i.candidates[j] = candidates
+		}
+	}
+}
+
+func (i *inferrer) unify(type_ ast.Type, known ast.Type) {
+	if ast.IsNil(type_) || ast.IsNil(known) {
+		return
+	}
+
+	switch type_ := type_.Resolved().(type) {
+	case *ast.Generic:
+		if index := slices.Index(i.generics, type_); index != -1 {
+			i.add(index, known)
+		}
+
+	case *ast.Pointer:
+		if known, ok := ast.As[*ast.Pointer](known); ok {
+			i.unify(type_.Pointee, known.Pointee)
+		}
+
+	case *ast.Array:
+		if known, ok := ast.As[*ast.Array](known); ok {
+			i.unify(type_.Base, known.Base)
+		}
+
+	case *ast.Slice:
+		if known, ok := ast.As[*ast.Slice](known); ok {
+			i.unify(type_.Base, known.Base)
+		}
+
+	case *ast.SpecializedStruct:
+		if known, ok := ast.As[*ast.SpecializedStruct](known); ok && known.Underlying() == type_.Underlying() {
+			for j := 0; j < min(len(type_.Types), len(known.Types)); j++ {
+				i.unify(type_.Types[j], known.Types[j])
+			}
+		}
+
+	case *ast.SpecializedInterface:
+		if known, ok := ast.As[*ast.SpecializedInterface](known); ok && known.Underlying() == type_.Underlying() {
+			for j := 0; j < min(len(type_.Types), len(known.Types)); j++ {
+				i.unify(type_.Types[j], known.Types[j])
+			}
+		}
+
+	case ast.FuncType:
+		if known, ok := ast.As[ast.FuncType](known); ok && type_.ParameterCount() == known.ParameterCount() {
+			for j := 0; j < type_.ParameterCount(); j++ {
+				i.unify(type_.ParameterIndex(j).Type, known.ParameterIndex(j).Type)
+			}
+
+			i.unify(type_.Returns(), known.Returns())
+		}
+	}
+}
+
+func (i *inferrer) add(index int, type_ ast.Type) {
+	for _, candidate := range i.candidates[index] {
+		if candidate.Equals(type_) {
+			return
+		}
+	}
+
+	i.candidates[index] = append(i.candidates[index], type_)
+}
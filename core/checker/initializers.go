@@ -0,0 +1,234 @@
+package checker
+
+import (
+	"fireball/core/ast"
+	"fireball/core/utils"
+	"slices"
+	"strings"
+)
+
+// CheckInitializers reports global variables and static fields whose initializers depend on their own value and
+// computes the order in which the initializers run. It needs to run after all files of the project are checked since
+// initializers can depend on variables declared in other files.
+//
+// Initializers of a file run after the initializers they depend on, otherwise in declaration order. Files are
+// initialized after the files they depend on, otherwise in the order of their paths. Constant initializers are
+// emitted as static data so they don't run at all.
+func CheckInitializers(files map[*ast.File]utils.Reporter) {
+	i := initializers{
+		files:     make([]*ast.File, 0, len(files)),
+		variables: make(map[*ast.File][]ast.Node),
+		deps:      make(map[ast.Node][]ast.Node),
+	}
+
+	for file := range files {
+		i.files = append(i.files, file)
+	}
+
+	slices.SortFunc(i.files, func(a, b *ast.File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	// Collect variables with an initializer that is not a constant
+	for _, file := range i.files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GlobalVar:
+				if decl.Value != nil && !decl.ActualValue.IsValid() {
+					i.variables[file] = append(i.variables[file], decl)
+				}
+
+			case *ast.Struct:
+				for _, field := range decl.StaticFields {
+					if field.Value != nil && !field.ActualValue.IsValid() {
+						i.variables[file] = append(i.variables[file], field)
+					}
+				}
+			}
+		}
+	}
+
+	// Collect dependencies
+	for _, file := range i.files {
+		for _, variable := range i.variables[file] {
+			d := dependencies{
+				variables: utils.NewSet[ast.Node](),
+				functions: utils.NewSet[*ast.Func](),
+			}
+
+			d.VisitNode(getInitializer(variable))
+
+			for _, other := range i.files {
+				for _, dep := range i.variables[other] {
+					if d.variables.Contains(dep) {
+						i.deps[variable] = append(i.deps[variable], dep)
+					}
+				}
+			}
+		}
+	}
+
+	// Check cycles
+	for _, file := range i.files {
+		c := checker{reporter: files[file]}
+
+		for _, variable := range i.variables[file] {
+			if !i.dependsOn(variable, variable, utils.NewSet[ast.Node]()) {
+				continue
+			}
+
+			switch variable := variable.(type) {
+			case *ast.GlobalVar:
+				c.error(variable.Name, "Variable '%s' depends on its own value", variable.Name)
+
+			case *ast.Field:
+				c.error(variable.Name(), "Static field '%s' depends on its own value", variable.Name())
+			}
+		}
+	}
+
+	// Order initializers inside files
+	for _, file := range i.files {
+		file.Initializers = sortTopological(i.variables[file], func(variable ast.Node) []ast.Node {
+			return i.deps[variable]
+		})
+	}
+
+	// Order files
+	order := sortTopological(i.files, func(file *ast.File) []*ast.File {
+		var deps []*ast.File
+
+		for _, variable := range i.variables[file] {
+			for _, dep := range i.deps[variable] {
+				if depFile := ast.GetParent[*ast.File](dep); depFile != file && !slices.Contains(deps, depFile) {
+					deps = append(deps, depFile)
+				}
+			}
+		}
+
+		return deps
+	})
+
+	for index, file := range order {
+		file.InitPriority = uint16(min(minInitPriority+index, maxInitPriority))
+	}
+}
+
+// Priorities of static constructors below 101 are reserved for the C runtime
+const minInitPriority = 101
+const maxInitPriority = 65535
+
+type initializers struct {
+	files     []*ast.File
+	variables map[*ast.File][]ast.Node
+	deps      map[ast.Node][]ast.Node
+}
+
+func (i *initializers) dependsOn(variable ast.Node, target ast.Node, visited utils.Set[ast.Node]) bool {
+	if !visited.Add(variable) {
+		return false
+	}
+
+	for _, dep := range i.deps[variable] {
+		if dep == target || i.dependsOn(dep, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getInitializer(variable ast.Node) ast.Expr {
+	switch variable := variable.(type) {
+	case *ast.GlobalVar:
+		return variable.Value
+
+	case *ast.Field:
+		return variable.Value
+
+	default:
+		return nil
+	}
+}
+
+// sortTopological orders the nodes so that they come after their dependencies, otherwise the original order is kept.
+// Dependency cycles are broken in the original order.
+func sortTopological[T comparable](nodes []T, deps func(node T) []T) []T {
+	sorted := make([]T, 0, len(nodes))
+	done := utils.NewSet[T]()
+
+	ready := func(node T) bool {
+		for _, dep := range deps(node) {
+			if dep != node && slices.Contains(nodes, dep) && !done.Contains(dep) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for len(sorted) < len(nodes) {
+		next := -1
+
+		for index, node := range nodes {
+			if !done.Contains(node) && ready(node) {
+				next = index
+				break
+			}
+		}
+
+		if next == -1 {
+			for index, node := range nodes {
+				if !done.Contains(node) {
+					next = index
+					break
+				}
+			}
+		}
+
+		done.Add(nodes[next])
+		sorted = append(sorted, nodes[next])
+	}
+
+	return sorted
+}
+
+// dependencies collects the global variables and static fields read by an initializer, including the ones read by
+// the functions it calls.
+type dependencies struct {
+	variables utils.Set[ast.Node]
+	functions utils.Set[*ast.Func]
+}
+
+func (d *dependencies) VisitNode(node ast.Node) {
+	if ast.IsNil(node) {
+		return
+	}
+
+	if expr, ok := node.(ast.Expr); ok {
+		//goland:noinspection GoSwitchMissingCasesForIotaConsts
+		switch expr.Result().Kind {
+		case ast.ValueResultKind:
+			switch value := expr.Result().Value().(type) {
+			case *ast.GlobalVar:
+				d.variables.Add(value)
+
+			case ast.FieldLike:
+				if value.Underlying().IsStatic() {
+					d.variables.Add(value.Underlying())
+				}
+			}
+
+		case ast.CallableResultKind:
+			if function, ok := expr.Result().Callable().(ast.FuncType); ok {
+				if f := function.Underlying(); f != nil && d.functions.Add(f) {
+					for _, stmt := range f.Body {
+						d.VisitNode(stmt)
+					}
+				}
+			}
+		}
+	}
+
+	node.AcceptChildren(d)
+}
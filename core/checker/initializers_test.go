@@ -0,0 +1,76 @@
+package checker_test
+
+import (
+	"fireball/core/ast"
+	"fireball/core/workspace"
+	"testing"
+)
+
+func TestConstantInitializers(t *testing.T) {
+	text := `namespace Tests;
+
+const COUNT i32 = 4;
+
+struct Foo {
+    static size u8 = 3 as u8,
+    static runtime i32 = compute(),
+}
+
+var constant i32 = COUNT * 2;
+var scale f32 = 0.5f;
+var runtime i32 = compute();
+var derived i32 = constant + 1;
+
+func compute() i32 {
+    return 5;
+}
+`
+
+	project := workspace.NewEmptyProject(t.TempDir(), "Tests")
+
+	file := project.GetOrCreateFile("main.fb")
+	file.SetText(text, true)
+	file.EnsureChecked()
+
+	if diagnostics := file.Diagnostics(); len(diagnostics) > 0 {
+		t.Fatalf("expected no diagnostics but got %v", diagnostics)
+	}
+
+	var names []string
+
+	for _, node := range file.Ast.Initializers {
+		switch node := node.(type) {
+		case *ast.GlobalVar:
+			names = append(names, node.Name.String())
+
+		case *ast.Field:
+			names = append(names, node.Name().String())
+		}
+	}
+
+	if len(names) != 3 || names[0] != "runtime" || names[1] != "runtime" || names[2] != "derived" {
+		t.Errorf("expected only the runtime initializers but got %q", names)
+	}
+
+	for _, decl := range file.Ast.Decls {
+		switch decl := decl.(type) {
+		case *ast.GlobalVar:
+			switch decl.Name.String() {
+			case "constant":
+				if decl.ActualValue != ast.IntValue(8) {
+					t.Errorf("expected 'constant' to be 8 but got %s", decl.ActualValue)
+				}
+
+			case "scale":
+				if decl.ActualValue != ast.FloatValue(0.5) {
+					t.Errorf("expected 'scale' to be 0.5 but got %s", decl.ActualValue)
+				}
+			}
+
+		case *ast.Struct:
+			if value := decl.StaticFields[0].ActualValue; value != ast.IntValue(3) {
+				t.Errorf("expected 'size' to be 3 but got %s", value)
+			}
+		}
+	}
+}
@@ -18,6 +18,13 @@ func (r *resetter) VisitNode(node ast.Node) {
 	case *ast.Var:
 		node.ActualType = nil
 
+	case *ast.ForIn:
+		node.AcceptChildren(r)
+		node.ActualType = nil
+
+	case *ast.Capture:
+		node.ActualType = nil
+
 	case ast.Expr:
 		node.AcceptChildren(r)
 		node.Result().SetInvalid()
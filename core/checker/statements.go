@@ -2,6 +2,8 @@ package checker
 
 import (
 	"fireball/core/ast"
+	"fireball/core/common"
+	"strings"
 )
 
 func (c *checker) VisitBlock(stmt *ast.Block) {
@@ -56,8 +58,8 @@ func (c *checker) VisitVar(stmt *ast.Var) {
 	// Check name collision
 	if c.hasVariableInScope(stmt.Name) {
 		c.error(stmt.Name, "Variable with the name '%s' already exists in the current scope", stmt.Name)
-	} else {
-		c.addVariable(stmt.Name, stmt.ActualType, stmt)
+	} else if v := c.addVariable(stmt.Name, stmt.ActualType, stmt); v != nil && valueOk && stmt.Value != nil {
+		v.closure = c.getClosureInfo(stmt.Value)
 	}
 
 	// Check void type
@@ -74,9 +76,9 @@ func (c *checker) VisitIf(stmt *ast.If) {
 }
 
 func (c *checker) VisitWhile(stmt *ast.While) {
-	c.loopDepth++
+	c.pushLoop(stmt.Label)
 	stmt.AcceptChildren(c)
-	c.loopDepth--
+	c.popLoop()
 
 	// Check condition value
 	required := ast.Primitive{Kind: ast.Bool}
@@ -86,11 +88,11 @@ func (c *checker) VisitWhile(stmt *ast.While) {
 func (c *checker) VisitFor(stmt *ast.For) {
 	// Visit children
 	c.pushScope()
-	c.loopDepth++
+	c.pushLoop(stmt.Label)
 
 	stmt.AcceptChildren(c)
 
-	c.loopDepth--
+	c.popLoop()
 	c.popScope()
 
 	// Check condition value
@@ -98,9 +100,257 @@ func (c *checker) VisitFor(stmt *ast.For) {
 	c.checkRequired(&required, stmt.Condition)
 }
 
+func (c *checker) VisitForIn(stmt *ast.ForIn) {
+	// Check iterable
+	if stmt.Iterable != nil {
+		c.VisitNode(stmt.Iterable)
+
+		if stmt.Iterable.Result().Kind == ast.ValueResultKind {
+			stmt.ActualType = c.getForInType(stmt.Iterable)
+		} else if stmt.Iterable.Result().Kind != ast.InvalidResultKind {
+			c.error(stmt.Iterable, "Invalid value")
+		}
+	}
+
+	if stmt.ActualType == nil {
+		stmt.ActualType = &ast.Primitive{Kind: ast.Void}
+	}
+
+	// Visit body
+	c.pushScope()
+
+	if stmt.Name != nil && stmt.Name.String() != "_" {
+		c.addVariable(stmt.Name, stmt.ActualType, stmt.Name)
+	}
+
+	c.pushLoop(stmt.Label)
+
+	if stmt.Body != nil {
+		c.VisitNode(stmt.Body)
+	}
+
+	c.popLoop()
+	c.popScope()
+}
+
+func (c *checker) getForInType(iterable ast.Expr) ast.Type {
+	type_ := iterable.Result().Type
+
+	switch t := ast.Resolved(type_).(type) {
+	case *ast.Array:
+		return t.Base
+
+	case *ast.Slice:
+		return t.Base
+
+	case *ast.Pointer:
+		if _, ok := ast.As[*ast.Struct](t.Pointee); ok {
+			type_ = t.Pointee
+		}
+	}
+
+	// Iterator
+	method := common.GetIteratorMethod(type_)
+
+	if method == nil {
+		c.error(iterable, "Cannot iterate over a '%s', only arrays, slices and types implementing 'Iterator' are supported", ast.PrintType(iterable.Result().Type))
+		return nil
+	}
+
+	if method.ParameterCount() == 1 && ast.IsPrimitive(method.Returns(), ast.Bool) {
+		if pointer, ok := ast.As[*ast.Pointer](method.ParameterIndex(0).Type); ok && !ast.IsPrimitive(pointer.Pointee, ast.Void) {
+			return pointer.Pointee
+		}
+	}
+
+	c.error(iterable, "Iterator method 'next' of '%s' needs to have the signature 'func next(item *T) bool'", ast.PrintType(type_))
+	return nil
+}
+
+func (c *checker) VisitMatch(stmt *ast.Match) {
+	// Check value
+	var type_ ast.Type
+
+	if stmt.Value != nil {
+		c.VisitNode(stmt.Value)
+
+		if stmt.Value.Result().Kind == ast.ValueResultKind {
+			type_ = stmt.Value.Result().Type
+		} else if stmt.Value.Result().Kind != ast.InvalidResultKind {
+			c.error(stmt.Value, "Invalid value")
+		}
+	}
+
+	enum, isEnum := ast.As[*ast.Enum](type_)
+	primitive, isPrimitive := ast.As[*ast.Primitive](type_)
+
+	if type_ != nil && !isEnum && (!isPrimitive || !ast.IsInteger(primitive.Kind)) {
+		c.error(stmt.Value, "Cannot match on a '%s', only integers and enums are supported", ast.PrintType(type_))
+		type_ = nil
+	}
+
+	// Check arms
+	values := make(map[int64]struct{})
+	var default_ *ast.MatchArm
+
+	for _, arm := range stmt.Arms {
+		c.pushScope()
+
+		for _, value := range arm.Values {
+			c.VisitNode(value)
+		}
+
+		if type_ != nil {
+			if default_ != nil {
+				c.error(arm, "Unreachable match arm, it comes after the default arm")
+			} else if arm.Default {
+				default_ = arm
+			} else {
+				c.checkMatchArm(arm, type_, values)
+			}
+		}
+
+		if arm.Body != nil {
+			c.VisitNode(arm.Body)
+		}
+
+		c.popScope()
+	}
+
+	// Check exhaustiveness
+	if isEnum {
+		var missing []string
+
+		for _, case_ := range enum.Cases {
+			if _, ok := values[case_.ActualValue]; !ok {
+				missing = append(missing, case_.Name.String())
+			}
+		}
+
+		if default_ == nil && len(missing) > 0 {
+			c.error(stmt.Value, "Match is missing enum cases: %s", strings.Join(missing, ", "))
+		} else if default_ != nil && len(missing) == 0 {
+			c.warning(default_, "Unreachable default arm, all enum cases are already matched")
+		}
+	}
+}
+
+func (c *checker) checkMatchArm(arm *ast.MatchArm, type_ ast.Type, values map[int64]struct{}) {
+	enum, isEnum := ast.As[*ast.Enum](type_)
+	primitive, _ := ast.As[*ast.Primitive](type_)
+
+	// Check values
+	arm.ActualValues = make([]int64, len(arm.Values))
+
+	for i, value := range arm.Values {
+		if value.Result().Kind == ast.InvalidResultKind {
+			continue
+		}
+
+		var v int64
+
+		if isEnum {
+			case_, ok := value.Result().Value().(*ast.EnumCase)
+
+			if !ok || !value.Result().Type.Equals(enum) {
+				c.error(value, "Expected a case of enum '%s'", ast.PrintType(enum))
+				continue
+			}
+
+			v = case_.ActualValue
+		} else {
+			constant := c.evaluator.evaluate(value)
+
+			if !constant.IsValid() {
+				continue
+			}
+
+			if constant.Kind != ast.IntConstant {
+				c.error(value, "Expected an integer constant")
+				continue
+			}
+
+			if !c.evaluator.checkRange(value, constant.Int, primitive.Kind) {
+				continue
+			}
+
+			v = constant.Int
+		}
+
+		if _, ok := values[v]; ok {
+			c.error(value, "Duplicate match arm value")
+		} else {
+			values[v] = struct{}{}
+		}
+
+		arm.ActualValues[i] = v
+	}
+
+	// Check bindings
+	if len(arm.Bindings) == 0 {
+		return
+	}
+
+	if len(arm.Values) != 1 {
+		errorSlice(c, arm.Bindings, "Bindings can only be used in arms that match a single enum case")
+		return
+	}
+
+	if arm.Values[0].Result().Kind != ast.ValueResultKind {
+		return
+	}
+
+	case_, ok := arm.Values[0].Result().Value().(*ast.EnumCase)
+	if !ok || !isEnum {
+		return
+	}
+
+	if len(arm.Bindings) != len(case_.Fields) {
+		errorSlice(c, arm.Bindings, "Got '%d' bindings but enum case '%s' has '%d' fields", len(arm.Bindings), case_.Name, len(case_.Fields))
+	}
+
+	for i, binding := range arm.Bindings[:min(len(arm.Bindings), len(case_.Fields))] {
+		if binding.String() == "_" {
+			continue
+		}
+
+		if c.hasVariableInScope(binding) {
+			c.error(binding, "Variable with the name '%s' already exists in the current scope", binding)
+		} else {
+			c.addVariable(binding, case_.Fields[i].Type(), binding)
+		}
+	}
+}
+
+func (c *checker) VisitDefer(stmt *ast.Defer) {
+	// Control flow cannot leave a deferred statement
+	prevLoops := c.loops
+
+	c.loops = nil
+	c.deferDepth++
+
+	stmt.AcceptChildren(c)
+
+	c.deferDepth--
+	c.loops = prevLoops
+
+	// Check parent
+	switch stmt.Parent().(type) {
+	case *ast.Block, *ast.Func:
+	default:
+		c.error(stmt, "A 'defer' statement needs to be directly inside a block")
+	}
+}
+
 func (c *checker) VisitReturn(stmt *ast.Return) {
 	stmt.AcceptChildren(c)
 
+	// Check if return is inside a defer
+	if c.deferDepth > 0 {
+		c.error(stmt, "A 'return' statement cannot be inside a 'defer' statement")
+		return
+	}
+
 	// Check return value
 	if stmt.Value != nil {
 		if stmt.Value.Result().Kind != ast.ValueResultKind {
@@ -109,6 +359,10 @@ func (c *checker) VisitReturn(stmt *ast.Return) {
 		}
 
 		c.checkRequired(c.function.Returns(), stmt.Value)
+
+		if c.getClosureInfo(stmt.Value).captures {
+			c.error(stmt.Value, "A closure that captures local variables cannot escape the function")
+		}
 	} else {
 		type_ := ast.Primitive{Kind: ast.Void}
 
@@ -122,8 +376,10 @@ func (c *checker) VisitBreak(stmt *ast.Break) {
 	stmt.AcceptChildren(c)
 
 	// Check if break is inside a loop
-	if c.loopDepth == 0 {
+	if len(c.loops) == 0 {
 		c.error(stmt, "A 'break' statement needs to be inside a loop")
+	} else {
+		c.checkLoopLabel(stmt.Label)
 	}
 }
 
@@ -131,7 +387,39 @@ func (c *checker) VisitContinue(stmt *ast.Continue) {
 	stmt.AcceptChildren(c)
 
 	// Check if continue is inside a loop
-	if c.loopDepth == 0 {
+	if len(c.loops) == 0 {
 		c.error(stmt, "A 'continue' statement needs to be inside a loop")
+	} else {
+		c.checkLoopLabel(stmt.Label)
+	}
+}
+
+func (c *checker) pushLoop(label *ast.Token) {
+	if label != nil && c.getLoop(label) != nil {
+		c.error(label, "Loop with the label '%s' already exists", label)
+	}
+
+	c.loops = append(c.loops, label)
+	c.loopDepths = append(c.loopDepths, len(c.scopes))
+}
+
+func (c *checker) popLoop() {
+	c.loops = c.loops[:len(c.loops)-1]
+	c.loopDepths = c.loopDepths[:len(c.loopDepths)-1]
+}
+
+func (c *checker) checkLoopLabel(label *ast.Token) {
+	if label != nil && c.getLoop(label) == nil {
+		c.error(label, "No enclosing loop with the label '%s'", label)
+	}
+}
+
+func (c *checker) getLoop(label *ast.Token) *ast.Token {
+	for i := len(c.loops) - 1; i >= 0; i-- {
+		if c.loops[i] != nil && c.loops[i].String() == label.String() {
+			return c.loops[i]
+		}
 	}
+
+	return nil
 }
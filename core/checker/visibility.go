@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"fireball/core/ast"
+)
+
+// checkAccessible reports an error if the declaration resolved by the expression is private to another namespace.
+func (c *checker) checkAccessible(expr ast.Expr, name *ast.Token) {
+	if name != nil {
+		c.checkAccessibleDecl(expr, name, name.String(), getResultNode(expr))
+	}
+}
+
+// checkDefaultValueAccess checks the declarations used by the default value of a parameter. Default values are
+// evaluated at the call site, so the ones of public functions can't use private declarations and the functions they
+// call can be referenced from other files.
+func (c *checker) checkDefaultValueAccess(function *ast.Func, value ast.Expr) {
+	visitor := defaultValueAccess{c: c, function: function}
+	visitor.VisitNode(value)
+}
+
+type defaultValueAccess struct {
+	c        *checker
+	function *ast.Func
+}
+
+func (d *defaultValueAccess) VisitNode(node ast.Node) {
+	var name *ast.Token
+
+	switch node := node.(type) {
+	case *ast.Identifier:
+		name = node.Name
+
+	case *ast.Member:
+		name = node.Name
+	}
+
+	if name != nil {
+		if decl := getVisibleDecl(getResultNode(node.(ast.Expr))); decl != nil {
+			if f, ok := decl.(*ast.Func); ok {
+				f.UsedOutsideFile = true
+			}
+
+			if d.function.IsPublic() && !decl.IsPublic() {
+				d.c.error(name, "Default value of a public function can't use '%s' since it is private to its namespace", name)
+			}
+		}
+	}
+
+	node.AcceptChildren(d)
+}
+
+func getResultNode(expr ast.Expr) ast.Node {
+	switch expr.Result().Kind {
+	case ast.TypeResultKind:
+		return expr.Result().Type
+
+	case ast.ValueResultKind:
+		return expr.Result().Value()
+
+	case ast.CallableResultKind:
+		return expr.Result().Callable()
+
+	default:
+		return nil
+	}
+}
+
+// checkAccessibleDecl reports an error at the node if the declaration is private to another namespace.
+func (c *checker) checkAccessibleDecl(from ast.Node, report ast.Node, name string, node ast.Node) {
+	decl := getVisibleDecl(node)
+	if decl == nil {
+		return
+	}
+
+	if !ast.IsAccessible(decl, from) {
+		c.error(report, "'%s' is private to its namespace", name)
+	}
+
+	// Private functions are emitted with internal linkage unless other files reference them
+	if f, ok := decl.(*ast.Func); ok && ast.GetParent[*ast.File](f) != ast.GetParent[*ast.File](from) {
+		f.UsedOutsideFile = true
+	}
+}
+
+func getVisibleDecl(node ast.Node) ast.Visible {
+	switch node := node.(type) {
+	case ast.StructType:
+		return node.Underlying()
+
+	case ast.InterfaceType:
+		return node.Underlying()
+
+	case ast.FuncType:
+		if f := node.Underlying(); f != nil && f.Name != nil {
+			return f
+		}
+
+	case ast.FieldLike:
+		return node.Underlying()
+
+	case ast.Visible:
+		if !ast.IsNil(node) {
+			return node
+		}
+	}
+
+	return nil
+}
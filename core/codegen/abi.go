@@ -303,8 +303,10 @@ func (c *codegen) toAddressable(value exprValue, valueType ast.Type) exprValue {
 
 func typeIsAbiStruct(type_ ast.Type) bool {
 	switch type_.Resolved().(type) {
-	case ast.StructType, *ast.Interface:
+	case ast.StructType, ast.InterfaceType, *ast.Slice, ast.FuncType:
 		return true
+	case *ast.Enum:
+		return type_.Resolved().(*ast.Enum).IsTagged()
 	default:
 		return false
 	}
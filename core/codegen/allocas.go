@@ -17,9 +17,13 @@ func (a *allocas) reset() {
 }
 
 func (a *allocas) get(type_ ast.Type, name string) *ir.AllocaInst {
+	return a.getTyp(a.c.types.get(type_), abi.GetTargetAbi().Align(type_), name)
+}
+
+func (a *allocas) getTyp(typ ir.Type, align uint32, name string) *ir.AllocaInst {
 	alloca := &ir.AllocaInst{
-		Typ:   a.c.types.get(type_),
-		Align: abi.GetTargetAbi().Align(type_),
+		Typ:   typ,
+		Align: align,
 	}
 
 	if name == "" {
@@ -0,0 +1,116 @@
+package codegen
+
+import (
+	"fireball/core/ast"
+	"fireball/core/ir"
+	"fireball/core/scanner"
+	"strings"
+)
+
+// VisitAsm lowers inline assembly to a call of an inline asm value. The outputs are returned by the call, as a struct
+// if there are multiple of them, and stored into their values afterward. Fireball has no way to mark assembly as
+// volatile so all of it is treated as having side effects.
+func (c *codegen) VisitAsm(expr *ast.Asm) {
+	constraints := make([]string, 0, len(expr.Outputs)+len(expr.Inputs)+len(expr.Clobbers))
+
+	// Outputs
+	outputs := make([]exprValue, len(expr.Outputs))
+	outputTypes := make([]ir.Type, len(expr.Outputs))
+
+	for i, output := range expr.Outputs {
+		outputs[i] = c.acceptExpr(output.Value)
+		outputTypes[i] = c.types.get(output.Value.Result().Type)
+
+		constraints = append(constraints, output.ConstraintString())
+	}
+
+	// Inputs
+	type_ := &ir.FuncType{Returns: ir.Void}
+	args := make([]ir.Value, len(expr.Inputs))
+
+	for i, input := range expr.Inputs {
+		args[i] = c.loadExpr(input.Value).v
+		type_.Params = append(type_.Params, &ir.Param{Typ: args[i].Type()})
+
+		constraints = append(constraints, input.ConstraintString())
+	}
+
+	// Clobbers
+	for i := range expr.Clobbers {
+		constraints = append(constraints, "~{"+expr.ClobberString(i)+"}")
+	}
+
+	// Call
+	switch len(outputTypes) {
+	case 0:
+	case 1:
+		type_.Returns = outputTypes[0]
+	default:
+		type_.Returns = &ir.StructType{Fields: outputTypes}
+	}
+
+	call := c.block.Add(&ir.CallInst{
+		Typ: type_,
+		Callee: &ir.InlineAsm{
+			Typ:         type_,
+			Template:    unescapeAsmTemplate(expr.TemplateString()),
+			Constraints: strings.Join(constraints, ","),
+			SideEffect:  true,
+		},
+		Args: args,
+	})
+
+	c.setLocationMetaCst(call, expr, scanner.LeftParen)
+
+	// Store outputs
+	for i, output := range outputs {
+		value := ir.Value(call)
+
+		if len(outputs) > 1 {
+			value = c.block.Add(&ir.ExtractValueInst{
+				Value:   call,
+				Indices: []uint32{uint32(i)},
+			})
+		}
+
+		store := c.block.Add(&ir.StoreInst{
+			Pointer: output.v,
+			Value:   value,
+			Align:   c.getAlign(output, expr.Outputs[i].Value.Result().Type),
+		})
+
+		c.setLocationMeta(store, expr.Outputs[i])
+	}
+
+	c.exprResult = exprValue{v: call}
+}
+
+// unescapeAsmTemplate replaces the escape sequences of a string literal with the characters they represent.
+func unescapeAsmTemplate(s string) string {
+	sb := strings.Builder{}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case '0':
+			sb.WriteByte(0)
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package codegen
+
+import (
+	"fireball/core/abi"
+	"fireball/core/ast"
+	"fireball/core/ir"
+	"fireball/core/scanner"
+)
+
+// atomicCall lowers a call to an atomic intrinsic to the matching atomic instruction.
+func (c *codegen) atomicCall(expr *ast.Call, function ast.FuncType) {
+	intrinsic := function.Underlying().IntrinsicName()
+	ordering := getAtomicOrdering(expr.MemoryOrder)
+
+	// Fence
+	if intrinsic == "fence" {
+		inst := c.block.Add(&ir.FenceInst{Ordering: ordering})
+		c.setLocationMetaCst(inst, expr, scanner.LeftParen)
+
+		c.exprResult = exprValue{v: inst}
+		return
+	}
+
+	// Load arguments
+	args := make([]ir.Value, len(expr.ActualArgs)-1)
+
+	for i := range args {
+		args[i] = c.implicitCastLoadExpr(function.ParameterIndex(i).Type, expr.ActualArgs[i]).v
+	}
+
+	pointer, _ := ast.As[*ast.Pointer](function.ParameterIndex(0).Type)
+	pointee := pointer.Pointee
+	align := abi.GetTargetAbi().Align(pointee)
+
+	// Instruction
+	var inst ir.Inst
+
+	switch intrinsic {
+	case "atomic_load":
+		inst = c.block.Add(&ir.LoadInst{
+			Typ:      c.types.get(pointee),
+			Pointer:  args[0],
+			Align:    align,
+			Ordering: ordering,
+		})
+
+	case "atomic_store":
+		inst = c.block.Add(&ir.StoreInst{
+			Pointer:  args[0],
+			Value:    args[1],
+			Align:    align,
+			Ordering: ordering,
+		})
+
+	case "atomic_compare_exchange":
+		cmpxchg := c.block.Add(&ir.CmpXchgInst{
+			Pointer:         args[0],
+			Expected:        args[1],
+			Desired:         args[2],
+			Align:           align,
+			SuccessOrdering: ordering,
+			FailureOrdering: getFailureOrdering(ordering),
+		})
+
+		c.setLocationMetaCst(cmpxchg, expr, scanner.LeftParen)
+
+		inst = c.block.Add(&ir.ExtractValueInst{
+			Value:   cmpxchg,
+			Indices: []uint32{1},
+		})
+
+	default:
+		inst = c.block.Add(&ir.AtomicRMWInst{
+			Kind:     getAtomicRMWKind(intrinsic),
+			Pointer:  args[0],
+			Value:    args[1],
+			Align:    align,
+			Ordering: ordering,
+		})
+	}
+
+	c.setLocationMetaCst(inst, expr, scanner.LeftParen)
+	c.exprResult = exprValue{v: inst}
+}
+
+func getAtomicOrdering(order *ast.EnumCase) ir.AtomicOrdering {
+	if order != nil {
+		switch order.Name.String() {
+		case "Relaxed":
+			return ir.Monotonic
+		case "Acquire":
+			return ir.Acquire
+		case "Release":
+			return ir.Release
+		case "AcqRel":
+			return ir.AcquireRelease
+		}
+	}
+
+	return ir.SequentiallyConsistent
+}
+
+// getFailureOrdering returns the ordering of a failed compare exchange, which only loads the value so it can't have
+// release semantics.
+func getFailureOrdering(ordering ir.AtomicOrdering) ir.AtomicOrdering {
+	switch ordering {
+	case ir.Release:
+		return ir.Monotonic
+	case ir.AcquireRelease:
+		return ir.Acquire
+	default:
+		return ordering
+	}
+}
+
+func getAtomicRMWKind(intrinsic string) ir.AtomicRMWKind {
+	switch intrinsic {
+	case "atomic_exchange":
+		return ir.XchgRMW
+	case "atomic_add":
+		return ir.AddRMW
+	case "atomic_sub":
+		return ir.SubRMW
+	case "atomic_and":
+		return ir.AndRMW
+	case "atomic_or":
+		return ir.OrRMW
+	case "atomic_xor":
+		return ir.XorRMW
+	default:
+		panic("codegen.getAtomicRMWKind() - Invalid atomic intrinsic")
+	}
+}
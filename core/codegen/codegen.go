@@ -6,28 +6,37 @@ import (
 	"fireball/core/common"
 	"fireball/core/ir"
 	"fmt"
+	"slices"
 	"strings"
 )
 
 type codegen struct {
 	ctx      *Context
 	path     string
+	root     ast.RootResolver
 	resolver ast.Resolver
 
-	types   types
-	vtables vtables
-	scopes  scopes
-	allocas allocas
+	types     types
+	vtables   vtables
+	typeInfos typeInfos
+	enums     enums
+	scopes    scopes
+	allocas   allocas
 
 	staticVariables map[ast.Node]exprValue
 	functions       map[ast.FuncType]*ir.Func
 
+	closures     []*ast.Closure
+	closureCount int
+
 	astFunction ast.FuncType
 	function    *ir.Func
 	block       *ir.Block
 
-	loopSkip *ir.Block
-	loopEnd  *ir.Block
+	loopSkip   *ir.Block
+	loopEnd    *ir.Block
+	loopScope  int
+	loopLabels []loopLabel
 
 	exprResult exprValue
 	this       exprValue
@@ -38,6 +47,9 @@ type codegen struct {
 type exprValue struct {
 	v           ir.Value
 	addressable bool
+
+	// Alignment of an addressable value when it is smaller than the alignment of its type
+	align uint32
 }
 
 func Emit(ctx *Context, path string, root ast.RootResolver, file *ast.File) *ir.Module {
@@ -47,6 +59,7 @@ func Emit(ctx *Context, path string, root ast.RootResolver, file *ast.File) *ir.
 	c := &codegen{
 		ctx:      ctx,
 		path:     path,
+		root:     root,
 		resolver: resolver,
 
 		staticVariables: make(map[ast.Node]exprValue),
@@ -60,7 +73,12 @@ func Emit(ctx *Context, path string, root ast.RootResolver, file *ast.File) *ir.
 
 	c.types.c = c
 	c.types.structs = make(map[string]ir.Type)
+	c.types.fieldIndices = make(map[string][]int)
 	c.vtables.c = c
+	c.vtables.cache = make(map[string]ir.Value)
+	c.typeInfos.c = c
+	c.typeInfos.cache = make(map[string]ir.Value)
+	c.enums.c = c
 	c.scopes.c = c
 	c.allocas.c = c
 
@@ -106,6 +124,13 @@ func Emit(ctx *Context, path string, root ast.RootResolver, file *ast.File) *ir.
 				}
 			}
 
+		case *ast.Interface:
+			for _, method := range decl.Methods {
+				if method.IsDefault() {
+					c.defineOrDeclare(method)
+				}
+			}
+
 		case *ast.Func:
 			c.defineOrDeclare(decl)
 
@@ -119,6 +144,11 @@ func Emit(ctx *Context, path string, root ast.RootResolver, file *ast.File) *ir.
 		c.acceptDecl(decl)
 	}
 
+	// Initializers
+	if len(file.Initializers) > 0 {
+		c.genInitializers(file)
+	}
+
 	// Return
 	return c.module
 }
@@ -142,7 +172,12 @@ func (c *codegen) defineOrDeclare(f ast.FuncType) {
 }
 
 func (c *codegen) defineOrDeclareFunc(function ast.FuncType) {
-	t := c.types.get(function).(*ir.FuncType)
+	// Atomic intrinsics are lowered to instructions at the call site
+	if ast.IsAtomicIntrinsic(function.Underlying().IntrinsicName()) {
+		return
+	}
+
+	t := c.types.getFunc(function)
 	name := c.getMangledName(function)
 
 	if function.Underlying().HasBody() {
@@ -152,7 +187,7 @@ func (c *codegen) defineOrDeclareFunc(function ast.FuncType) {
 			LinkageName: name,
 			Scope:       c.scopes.getMeta(),
 			File:        c.scopes.file,
-			Type:        c.types.getMeta(function),
+			Type:        c.types.getFuncMeta(function),
 			Unit:        c.scopes.unitId,
 		}
 
@@ -173,6 +208,10 @@ func (c *codegen) defineOrDeclareFunc(function ast.FuncType) {
 		f := c.module.Define(name, t, flags)
 		f.SetMeta(c.module.Meta(meta))
 
+		if isInternal(function.Underlying()) {
+			f.Linkage = ir.InternalLinkage
+		}
+
 		c.functions[function] = f
 	} else {
 		// Declare
@@ -180,6 +219,25 @@ func (c *codegen) defineOrDeclareFunc(function ast.FuncType) {
 	}
 }
 
+// isInternal returns true if the function can only be called from the module defining it.
+func isInternal(function *ast.Func) bool {
+	if function.IsPublic() || function.UsedOutsideFile || function.ExternName() != "" {
+		return false
+	}
+
+	// Tests and the main function are called by the generated entrypoint
+	if ast.GetAttribute(function.Attributes, "Test") != nil {
+		return false
+	}
+
+	if _, ok := function.Parent().(*ast.File); ok && function.Name.String() == "main" {
+		return false
+	}
+
+	// Functions of the builtin namespace are called by generated code from any module
+	return !common.IsBuiltin(function)
+}
+
 func (c *codegen) getMangledName(function ast.FuncType) string {
 	// Intrinsic
 	intrinsic := function.Underlying().IntrinsicName()
@@ -227,9 +285,9 @@ func (c *codegen) load(value exprValue, valueType ast.Type) exprValue {
 	if value.addressable {
 		return exprValue{
 			v: c.block.Add(&ir.LoadInst{
-				Typ:     value.v.Type().(*ir.PointerType).Pointee,
+				Typ:     c.types.get(valueType),
 				Pointer: value.v,
-				Align:   abi.GetTargetAbi().Align(valueType),
+				Align:   c.getAlign(value, valueType),
 			}),
 			addressable: false,
 		}
@@ -238,6 +296,14 @@ func (c *codegen) load(value exprValue, valueType ast.Type) exprValue {
 	return value
 }
 
+func (c *codegen) getAlign(value exprValue, valueType ast.Type) uint32 {
+	if value.align != 0 {
+		return value.align
+	}
+
+	return abi.GetTargetAbi().Align(valueType)
+}
+
 func (c *codegen) loadExpr(expr ast.Expr) exprValue {
 	return c.load(c.acceptExpr(expr), expr.Result().Type)
 }
@@ -300,6 +366,11 @@ func (c *codegen) convertCast(value exprValue, kind common.CastKind, from, to as
 		} else {
 			signed := ast.IsSigned(to.Resolved().(*ast.Primitive).Kind)
 
+			// Enums are extended based on their underlying integer type
+			if enum, ok := ast.As[*ast.Enum](from); ok {
+				from = enum.ActualType
+			}
+
 			if from, ok := ast.As[*ast.Primitive](from); !ok || !ast.IsSigned(from.Kind) {
 				signed = false
 			}
@@ -353,6 +424,98 @@ func (c *codegen) convertCast(value exprValue, kind common.CastKind, from, to as
 
 		return exprValue{v: result}
 
+	case common.Interface2Pointer:
+		// The pointer is only returned when the dynamic type matches, otherwise the result is nil
+		pointee := to.Resolved().(*ast.Pointer).Pointee
+
+		equal := c.block.Add(&ir.ICmpInst{
+			Kind:  ir.Eq,
+			Left:  c.getDynamicTypeInfo(value.v),
+			Right: c.typeInfos.get(pointee),
+		})
+
+		data := c.block.Add(&ir.ExtractValueInst{
+			Value:   value.v,
+			Indices: []uint32{1},
+		})
+
+		result := c.block.Add(&ir.SelectInst{
+			Condition: equal,
+			True:      data,
+			False:     ir.Null,
+		})
+
+		c.setLocationMeta(result, location)
+		return exprValue{v: result}
+
+	case common.Interface2Interface:
+		// The vtable is looked up at runtime, types not implementing the interface result in a nil value
+		vtable := c.getInterfaceVtable(value.v, to)
+
+		null := c.block.Add(&ir.ICmpInst{
+			Kind:  ir.Eq,
+			Left:  vtable,
+			Right: ir.Null,
+		})
+
+		data := c.block.Add(&ir.SelectInst{
+			Condition: null,
+			True:      ir.Null,
+			False: c.block.Add(&ir.ExtractValueInst{
+				Value:   value.v,
+				Indices: []uint32{1},
+			}),
+		})
+
+		result := c.block.Add(&ir.InsertValueInst{
+			Value:   &ir.ZeroInitConst{Typ: c.types.get(to)},
+			Element: vtable,
+			Indices: []uint32{0},
+		})
+		c.setLocationMeta(result, location)
+
+		result = c.block.Add(&ir.InsertValueInst{
+			Value:   result,
+			Element: data,
+			Indices: []uint32{1},
+		})
+		c.setLocationMeta(result, location)
+
+		return exprValue{v: result}
+
+	case common.Interface2Parent:
+		// The vtables of inherited interfaces are stored at the end of the vtable
+		var vtable ir.Value = c.block.Add(&ir.ExtractValueInst{
+			Value:   value.v,
+			Indices: []uint32{0},
+		})
+
+		inter := from.Resolved().(ast.InterfaceType)
+
+		for _, index := range getParentPath(inter, to.Resolved().(ast.InterfaceType)) {
+			vtable = c.getParentVtable(vtable, inter, index)
+			inter = inter.Parents()[index]
+		}
+
+		result := c.block.Add(&ir.InsertValueInst{
+			Value:   value.v,
+			Element: vtable,
+			Indices: []uint32{0},
+		})
+
+		c.setLocationMeta(result, location)
+		return exprValue{v: result}
+
+	case common.Pointer2Func:
+		result := c.block.Add(&ir.InsertValueInst{
+			Value:   &ir.ZeroInitConst{Typ: c.types.get(to)},
+			Element: value.v,
+			Indices: []uint32{0},
+		})
+
+		c.setLocationMeta(result, location)
+		return exprValue{v: result}
+
 	default:
 		panic("codegen.convertAstCastKind() - Not implemented")
 	}
@@ -380,15 +543,63 @@ func (c *codegen) getGlobalVariable(variable *ast.GlobalVar) exprValue {
 	return c.createGlobalVariable(variable, true)
 }
 
+// getBoundMethod returns the method of the type argument that implements an interface method called on a value of a
+// bound generic type.
+func (c *codegen) getBoundMethod(type_ ast.Type, method ast.FuncType) ast.FuncType {
+	if pointer, ok := ast.As[*ast.Pointer](type_); ok {
+		type_ = pointer.Pointee
+	}
+
+	s, ok := ast.As[ast.StructType](type_)
+	if !ok {
+		return nil
+	}
+
+	name := method.Underlying().Name.String()
+
+	if file := ast.GetParent[*ast.File](s.Underlying()); file != nil && file.Resolver != nil {
+		if method := file.Resolver.GetMethod(s, name, false); method != nil {
+			return method
+		}
+	}
+
+	return c.resolver.GetMethod(s, name, false)
+}
+
+func (c *codegen) getConstant(constant *ast.Const) exprValue {
+	return exprValue{v: c.constantValue(constant.ActualValue, constant.Type)}
+}
+
+func (c *codegen) constantValue(value ast.Constant, type_ ast.Type) ir.Value {
+	typ := c.types.get(type_)
+
+	if value.Kind == ast.FloatConstant {
+		return &ir.FloatConst{Typ: typ, Value: value.Float}
+	}
+
+	return &ir.IntConst{Typ: typ, Value: ir.Signed(value.Int)}
+}
+
+// staticInitializer returns the value a global variable starts with, constant initializers are stored directly while
+// the other ones are zero until the initializers of the file run.
+func (c *codegen) staticInitializer(value ast.Constant, type_ ast.Type) ir.Value {
+	if value.IsValid() {
+		return c.constantValue(value, type_)
+	}
+
+	return &ir.ZeroInitConst{Typ: c.types.get(type_)}
+}
+
 func (c *codegen) createStaticVariable(field ast.FieldLike, external bool) exprValue {
 	type_ := c.types.get(field.Type())
 	var initializer ir.Value
 
 	if !external {
-		initializer = &ir.ZeroInitConst{Typ: type_}
+		initializer = c.staticInitializer(field.Underlying().ActualValue, field.Type())
 	}
 
 	global := c.module.Global(field.Underlying().MangledName(), type_, initializer)
+	global.ThreadLocal = field.Underlying().IsThreadLocal()
 
 	if !external {
 		meta := &ir.GlobalVarMeta{
@@ -425,10 +636,11 @@ func (c *codegen) createGlobalVariable(variable *ast.GlobalVar, external bool) e
 	var initializer ir.Value
 
 	if !external {
-		initializer = &ir.ZeroInitConst{Typ: type_}
+		initializer = c.staticInitializer(variable.ActualValue, variable.Type)
 	}
 
 	global := c.module.Global(variable.MangledName(), type_, initializer)
+	global.ThreadLocal = variable.IsThreadLocal()
 
 	if !external {
 		meta := &ir.GlobalVarMeta{
@@ -464,8 +676,13 @@ func (c *codegen) createGlobalVariable(variable *ast.GlobalVar, external bool) e
 
 func (c *codegen) getFunction(function ast.FuncType) exprValue {
 	// Get function already in this module
+	if value, ok := c.functions[function]; ok {
+		return exprValue{v: value}
+	}
+
 	for f, value := range c.functions {
-		if f.Equals(function) {
+		// Closures are anonymous and only match by identity, otherwise any closure with the same signature would match
+		if f.Underlying().Name != nil && f.Equals(function) {
 			return exprValue{v: value}
 		}
 	}
@@ -479,7 +696,7 @@ func (c *codegen) getFunction(function ast.FuncType) exprValue {
 		}
 	}
 
-	value := c.module.Declare(c.getMangledName(function), c.types.get(function).(*ir.FuncType))
+	value := c.module.Declare(c.getMangledName(function), c.types.getFunc(function))
 	c.functions[function] = value
 
 	return exprValue{v: value}
@@ -489,6 +706,32 @@ func (c *codegen) beginBlock(block *ir.Block) {
 	c.block = block
 }
 
+// runDefers emits the deferred statements of all scopes starting at the given depth, innermost first.
+func (c *codegen) runDefers(depth int) {
+	for i := len(c.scopes.scopes) - 1; i >= depth; i-- {
+		defers := c.scopes.scopes[i].defers
+
+		for j := len(defers) - 1; j >= 0; j-- {
+			// Only variables declared before the defer statement are visible to it
+			variables := c.scopes.variables
+			c.scopes.variables = slices.Clone(variables[:defers[j].variables])
+
+			c.acceptStmt(defers[j].stmt.Stmt)
+
+			c.scopes.variables = variables
+		}
+	}
+}
+
+// popScope runs the deferred statements of the innermost scope if its end is reachable and then pops it.
+func (c *codegen) popScope() {
+	if c.block != nil {
+		c.runDefers(len(c.scopes.scopes) - 1)
+	}
+
+	c.scopes.pop()
+}
+
 func callNeedsTempVariable(expr *ast.Call) bool {
 	function := expr.Callee.Result().Type.(ast.FuncType)
 
@@ -529,6 +772,14 @@ func isSigned(type_ ast.Type) bool {
 	return false
 }
 
+func isInteger(type_ ast.Type) bool {
+	if v, ok := ast.As[*ast.Primitive](type_); ok {
+		return ast.IsInteger(v.Kind)
+	}
+
+	return false
+}
+
 func isFloating(type_ ast.Type) bool {
 	if v, ok := ast.As[*ast.Primitive](type_); ok {
 		return ast.IsFloating(v.Kind)
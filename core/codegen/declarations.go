@@ -6,6 +6,7 @@ import (
 	"fireball/core/cst"
 	"fireball/core/ir"
 	"fireball/core/scanner"
+	"strings"
 )
 
 func (c *codegen) VisitNamespace(_ *ast.Namespace) {}
@@ -49,7 +50,13 @@ func (c *codegen) VisitImpl(decl *ast.Impl) {
 
 func (c *codegen) VisitEnum(_ *ast.Enum) {}
 
-func (c *codegen) VisitInterface(_ *ast.Interface) {}
+func (c *codegen) VisitInterface(decl *ast.Interface) {
+	for _, method := range decl.Methods {
+		if method.IsDefault() {
+			c.VisitFunc(method)
+		}
+	}
+}
 
 func (c *codegen) VisitFunc(decl *ast.Func) {
 	if !decl.HasBody() {
@@ -106,6 +113,12 @@ func (c *codegen) genFunc(f ast.FuncType) {
 		index++
 	}
 
+	// Add captured variables
+	if closure, ok := decl.Parent().(*ast.Closure); ok && len(closure.Captures) > 0 {
+		c.bindCaptures(closure, function.Typ.Params[index])
+		index++
+	}
+
 	// Add this variable
 	if receiver := f.Receiver(); receiver != nil {
 		name := scanner.Token{Kind: scanner.Identifier, Lexeme: "this"}
@@ -139,19 +152,155 @@ func (c *codegen) genFunc(f ast.FuncType) {
 		c.acceptStmt(stmt)
 	}
 
-	// Add return if needed
-	if ast.IsPrimitive(f.Returns(), ast.Void) {
-		c.block.Add(&ir.RetInst{})
+	// Run defers and add return if needed
+	if c.block != nil {
+		c.runDefers(0)
+
+		if ast.IsPrimitive(f.Returns(), ast.Void) {
+			c.block.Add(&ir.RetInst{})
+		}
 	}
 
 	// Reset state
-	c.resolver = prevResolver
-
 	c.scopes.pop()
 
 	c.block = nil
 	c.function = nil
 	c.astFunction = nil
+
+	// Closures
+	closures := c.closures
+	c.closures = nil
+
+	for _, closure := range closures {
+		c.genFunc(closure.Function)
+	}
+
+	c.resolver = prevResolver
+}
+
+func (c *codegen) bindCaptures(closure *ast.Closure, env ir.Value) {
+	envTyp, _ := c.getClosureEnv(closure)
+
+	for i, capture := range closure.Captures {
+		pointer := c.block.Add(&ir.GetElementPtrInst{
+			PointerTyp: &ir.PointerType{Pointee: envTyp.Fields[i]},
+			Typ:        envTyp,
+			Pointer:    env,
+			Indices: []ir.Value{
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(i))},
+			},
+			Inbounds: true,
+		})
+
+		// Variables captured by reference are stored as pointers in the environment
+		if capture.Reference {
+			pointer = c.block.Add(&ir.LoadInst{
+				Typ:     envTyp.Fields[i],
+				Pointer: pointer,
+				Align:   c.getCaptureAlign(capture),
+			})
+		}
+
+		c.setLocationMeta(pointer, capture)
+		c.scopes.addVariable(capture.Name, capture.ActualType, pointer, 0)
+	}
 }
 
 func (c *codegen) VisitGlobalVar(_ *ast.GlobalVar) {}
+
+// genInitializers emits a function running the initializers of the global variables and static fields of the file and
+// registers it as a static constructor so that it runs before main.
+func (c *codegen) genInitializers(file *ast.File) {
+	// Define function
+	name := strings.Builder{}
+	name.WriteString("fb$")
+	file.Namespace.Name.WriteTo(&name)
+	name.WriteString(":init")
+
+	void := ast.Primitive{Kind: ast.Void}
+
+	meta := &ir.SubprogamMeta{
+		Name:        "init",
+		LinkageName: name.String(),
+		Scope:       c.scopes.file,
+		File:        c.scopes.file,
+		Type:        c.module.Meta(&ir.SubroutineTypeMeta{Returns: c.types.getMeta(&void)}),
+		Unit:        c.scopes.unitId,
+	}
+
+	function := c.module.Define(name.String(), &ir.FuncType{Returns: ir.Void}, 0)
+	function.Linkage = ir.InternalLinkage
+	function.SetMeta(c.module.Meta(meta))
+
+	// Setup state
+	c.function = function
+	c.beginBlock(function.Block("entry"))
+
+	c.scopes.push(function.Meta())
+	c.allocas.reset()
+
+	// Initializers
+	for _, node := range file.Initializers {
+		var type_ ast.Type
+		var value ast.Expr
+
+		switch node := node.(type) {
+		case *ast.GlobalVar:
+			type_ = node.Type
+			value = node.Value
+
+		case *ast.Field:
+			type_ = node.Type()
+			value = node.Value
+		}
+
+		initializer := c.implicitCastLoadExpr(type_, value)
+
+		store := c.block.Add(&ir.StoreInst{
+			Pointer: c.staticVariables[node].v,
+			Value:   initializer.v,
+			Align:   abi.GetTargetAbi().Align(type_),
+		})
+
+		c.setLocationMeta(store, node)
+	}
+
+	c.block.Add(&ir.RetInst{})
+
+	// Reset state
+	c.scopes.pop()
+
+	c.block = nil
+	c.function = nil
+
+	// Closures
+	closures := c.closures
+	c.closures = nil
+
+	for _, closure := range closures {
+		c.genFunc(closure.Function)
+	}
+
+	// Register static constructor
+	ptr := &ir.PointerType{Pointee: ir.I8}
+	ctorTyp := &ir.StructType{Fields: []ir.Type{ir.I32, ptr, ptr}}
+	ctorsTyp := &ir.ArrayType{Count: 1, Base: ctorTyp}
+
+	ctors := c.module.Global("llvm.global_ctors", ctorsTyp, &ir.ArrayConst{
+		Typ: ctorsTyp,
+		Values: []ir.Value{&ir.StructConst{
+			Typ: ctorTyp,
+			Fields: []ir.Value{
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(file.InitPriority))},
+				function,
+				ir.Null,
+			},
+		}},
+	})
+
+	ctors.Linkage = ir.AppendingLinkage
+}
+
+func (c *codegen) VisitConst(_ *ast.Const) {}
@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"fireball/core/abi"
+	"fireball/core/ast"
+	"fireball/core/ir"
+)
+
+type enumInfo struct {
+	enum *ast.Enum
+
+	names []ir.Value
+	cases ir.Value
+}
+
+type enums struct {
+	c *codegen
+
+	cache []*enumInfo
+}
+
+func (e *enums) get(enum *ast.Enum) *enumInfo {
+	// Check cache
+	for _, info := range e.cache {
+		if info.enum == enum {
+			return info
+		}
+	}
+
+	// Create
+	info := &enumInfo{
+		enum:  enum,
+		names: make([]ir.Value, len(enum.Cases)),
+	}
+
+	for i, case_ := range enum.Cases {
+		info.names[i] = e.c.module.Constant("", convertString(case_.Name.String()))
+	}
+
+	e.cache = append(e.cache, info)
+	return info
+}
+
+// getCases returns a global array containing the values of all cases, created on first use.
+func (e *enums) getCases(enum *ast.Enum) ir.Value {
+	info := e.get(enum)
+
+	if info.cases == nil {
+		typ := e.c.types.get(enum.ActualType)
+		values := make([]ir.Value, len(enum.Cases))
+
+		for i, case_ := range enum.Cases {
+			values[i] = &ir.IntConst{Typ: typ, Value: ir.Signed(case_.ActualValue)}
+		}
+
+		info.cases = e.c.module.Constant("", &ir.ArrayConst{
+			Typ:    &ir.ArrayType{Count: uint32(len(values)), Base: typ},
+			Values: values,
+		})
+	}
+
+	return info.cases
+}
+
+// nameof returns a pointer to the name of the case matching the value of the enum, or nil for unknown values.
+func (c *codegen) nameof(expr ast.Expr, enum *ast.Enum) ir.Value {
+	var value ir.Value
+
+	if enum.IsTagged() {
+		// The tag is stored at the start of a tagged enum
+		pointer := c.toAddressable(c.acceptExpr(expr), enum)
+
+		value = c.block.Add(&ir.LoadInst{
+			Typ:     c.types.get(enum.ActualType),
+			Pointer: pointer.v,
+			Align:   abi.GetTargetAbi().Align(enum.ActualType),
+		})
+	} else {
+		value = c.loadExpr(expr).v
+	}
+
+	info := c.enums.get(enum)
+	var result ir.Value = ir.Null
+
+	// Walk the cases backwards so the first case wins when several cases share a value
+	for i := len(enum.Cases) - 1; i >= 0; i-- {
+		equal := c.block.Add(&ir.ICmpInst{
+			Kind:  ir.Eq,
+			Left:  value,
+			Right: &ir.IntConst{Typ: value.Type(), Value: ir.Signed(enum.Cases[i].ActualValue)},
+		})
+
+		result = c.block.Add(&ir.SelectInst{
+			Condition: equal,
+			True:      info.names[i],
+			False:     result,
+		})
+	}
+
+	return result
+}
@@ -7,6 +7,7 @@ import (
 	"fireball/core/ir"
 	"fireball/core/scanner"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -15,6 +16,10 @@ func (c *codegen) VisitParen(expr *ast.Paren) {
 	c.acceptExpr(expr.Expr)
 }
 
+func (c *codegen) VisitNamedArg(expr *ast.NamedArg) {
+	c.acceptExpr(expr.Value)
+}
+
 func (c *codegen) VisitLiteral(expr *ast.Literal) {
 	// Convert fireball constant into a LLVM IR constant
 	var value ir.Value
@@ -35,10 +40,9 @@ func (c *codegen) VisitLiteral(expr *ast.Literal) {
 		last := raw[len(raw)-1]
 
 		if last == 'u' || last == 'U' {
-			v, _ := strconv.ParseUint(raw, 10, 32)
+			v, _ := strconv.ParseUint(raw[:len(raw)-1], 10, 32)
 			value = &ir.IntConst{Typ: type_, Value: ir.Unsigned(v)}
-		}
-		if last == 'f' || last == 'F' {
+		} else if last == 'f' || last == 'F' {
 			v, _ := strconv.ParseFloat(raw[:len(raw)-1], 32)
 			value = &ir.FloatConst{Typ: type_, Value: v}
 		} else if strings.ContainsRune(raw, '.') {
@@ -164,18 +168,22 @@ func (c *codegen) VisitStructInitializer(expr *ast.StructInitializer) {
 
 	var result ir.Value = &ir.ZeroInitConst{Typ: type_}
 
-	for _, initField := range expr.Fields {
-		field, i := getField(fields, initField.Name)
-		element := c.implicitCastLoadExpr(field.Type(), initField.Value)
-
-		r := c.block.Add(&ir.InsertValueInst{
-			Value:   result,
-			Element: element.v,
-			Indices: []uint32{uint32(i)},
-		})
+	if struct_.Underlying().IsUnion() {
+		result = c.unionInitializer(expr, struct_, fields)
+	} else {
+		for _, initField := range expr.Fields {
+			field, i := getField(fields, initField.Name)
+			element := c.implicitCastLoadExpr(field.Type(), initField.Value)
+
+			r := c.block.Add(&ir.InsertValueInst{
+				Value:   result,
+				Element: element.v,
+				Indices: []uint32{uint32(c.types.getFieldIndex(struct_, i))},
+			})
 
-		c.setLocationMeta(r, initField)
-		result = r
+			c.setLocationMeta(r, initField)
+			result = r
+		}
 	}
 
 	c.exprResult = exprValue{v: result}
@@ -203,6 +211,36 @@ func (c *codegen) VisitStructInitializer(expr *ast.StructInitializer) {
 	}
 }
 
+// unionInitializer zeroes the memory of the union before storing the single initialized field into it.
+func (c *codegen) unionInitializer(expr *ast.StructInitializer, union ast.StructType, fields []ast.FieldLike) ir.Value {
+	pointer := c.allocas.get(union, "")
+
+	c.block.Add(&ir.StoreInst{
+		Pointer: pointer,
+		Value:   &ir.ZeroInitConst{Typ: c.types.get(union)},
+		Align:   abi.GetTargetAbi().Align(union),
+	})
+
+	for _, initField := range expr.Fields {
+		field, _ := getField(fields, initField.Name)
+		element := c.implicitCastLoadExpr(field.Type(), initField.Value)
+
+		store := c.block.Add(&ir.StoreInst{
+			Pointer: pointer,
+			Value:   element.v,
+			Align:   min(abi.GetTargetAbi().Align(field.Type()), abi.GetTargetAbi().Align(union)),
+		})
+
+		c.setLocationMeta(store, initField)
+	}
+
+	return c.block.Add(&ir.LoadInst{
+		Typ:     c.types.get(union),
+		Pointer: pointer,
+		Align:   abi.GetTargetAbi().Align(union),
+	})
+}
+
 func (c *codegen) VisitArrayInitializer(expr *ast.ArrayInitializer) {
 	baseType := expr.Result().Type.(*ast.Array).Base
 	type_ := c.types.get(expr.Result().Type)
@@ -257,26 +295,42 @@ func (c *codegen) VisitUnary(expr *ast.Unary) {
 				Right: c.load(value, expr.Value.Result().Type).v,
 			})
 
+		case scanner.Tilde:
+			result = c.block.Add(&ir.XorInst{
+				Left:  &ir.IntConst{Typ: c.types.get(expr.Value.Result().Type), Value: ir.Signed(-1)},
+				Right: c.load(value, expr.Value.Result().Type).v,
+			})
+
 		case scanner.Minus:
+			if method := getOperatorMethod(expr); method != nil {
+				c.exprResult = c.callOperator(method, value, expr.Value.Result().Type, nil, expr)
+				return
+			}
+
 			if v, ok := ast.As[*ast.Primitive](expr.Value.Result().Type); ok {
 				value := c.load(value, expr.Value.Result().Type)
 
+				zero := &ir.IntConst{
+					Typ:   c.types.get(expr.Value.Result().Type),
+					Value: ir.Unsigned(0),
+				}
+
 				if ast.IsFloating(v.Kind) {
 					// floating
 					result = c.block.Add(&ir.FNegInst{Value: value.v})
+				} else if c.ctx.SafetyChecks && ast.IsSigned(v.Kind) {
+					// checked, the minimum value has no positive counterpart
+					result = c.checkedArithmetic(expr.Operator, "sub", zero, value.v, expr.Value.Result().Type)
 				} else {
 					// signed
 					result = c.block.Add(&ir.SubInst{
-						Left: &ir.IntConst{
-							Typ:   c.types.get(expr.Value.Result().Type),
-							Value: ir.Unsigned(0),
-						},
+						Left:  zero,
 						Right: value.v,
 					})
 				}
 			}
 
-		case scanner.Ampersand, scanner.FuncPtr:
+		case scanner.Ampersand:
 			c.exprResult = exprValue{
 				v:           value.v,
 				addressable: false,
@@ -284,12 +338,30 @@ func (c *codegen) VisitUnary(expr *ast.Unary) {
 
 			return
 
+		case scanner.FuncPtr:
+			// Function values can be referenced directly
+			if _, ok := expr.Value.Result().Callable().(ast.FuncType); !ok {
+				c.exprResult = c.load(value, expr.Value.Result().Type)
+				return
+			}
+
+			c.exprResult = exprValue{v: &ir.StructConst{
+				Typ:    c.types.get(expr.Result().Type),
+				Fields: []ir.Value{value.v, ir.Null},
+			}}
+
+			return
+
 		case scanner.Star:
 			result = c.load(value, expr.Value.Result().Type).v
 
+			if c.ctx.SafetyChecks {
+				c.checkNil(expr.Value, result)
+			}
+
 			if _, ok := expr.Parent().(*ast.Assignment); !ok {
 				result = c.block.Add(&ir.LoadInst{
-					Typ:     result.Type().(*ir.PointerType).Pointee,
+					Typ:     c.types.get(expr.Result().Type),
 					Pointer: result,
 					Align:   abi.GetTargetAbi().Align(expr.Result().Type),
 				})
@@ -320,7 +392,7 @@ func (c *codegen) VisitUnary(expr *ast.Unary) {
 			c.block.Add(&ir.StoreInst{
 				Pointer: value.v,
 				Value:   newValue.v,
-				Align:   abi.GetTargetAbi().Align(expr.Value.Result().Type),
+				Align:   c.getAlign(value, expr.Value.Result().Type),
 			})
 
 			result = newValue.v
@@ -362,7 +434,7 @@ func (c *codegen) VisitUnary(expr *ast.Unary) {
 			c.block.Add(&ir.StoreInst{
 				Pointer: value.v,
 				Value:   newValue.v,
-				Align:   abi.GetTargetAbi().Align(expr.Value.Result().Type),
+				Align:   c.getAlign(value, expr.Value.Result().Type),
 			})
 
 			result = prevValue.v
@@ -376,6 +448,11 @@ func (c *codegen) VisitUnary(expr *ast.Unary) {
 }
 
 func (c *codegen) VisitBinary(expr *ast.Binary) {
+	if method := getOperatorMethod(expr); method != nil {
+		c.exprResult = c.binaryOperator(method, c.acceptExpr(expr.Left), expr.Left.Result().Type, expr.Right, expr.Operator)
+		return
+	}
+
 	c.exprResult = c.binaryLoad(expr.Left, expr.Right, expr.Operator)
 }
 
@@ -465,6 +542,118 @@ func (c *codegen) VisitLogical(expr *ast.Logical) {
 	}
 }
 
+func (c *codegen) VisitClosure(expr *ast.Closure) {
+	// Define function, it is generated after the current function
+	typ := c.types.getFunc(expr.Function)
+	if len(expr.Captures) > 0 {
+		typ = c.types.getFuncEnv(expr.Function)
+	}
+
+	name := c.function.Name() + ".closure." + strconv.Itoa(c.closureCount)
+	c.closureCount++
+
+	meta := &ir.SubprogamMeta{
+		Name:        name,
+		LinkageName: name,
+		Scope:       c.scopes.file,
+		File:        c.scopes.file,
+		Type:        c.types.getFuncMeta(expr.Function),
+		Unit:        c.scopes.unitId,
+	}
+
+	if expr.Cst() != nil {
+		meta.Line = uint32(expr.Cst().Range.Start.Line)
+	}
+
+	function := c.module.Define(name, typ, 0)
+	function.SetMeta(c.module.Meta(meta))
+
+	c.functions[expr.Function] = function
+	c.closures = append(c.closures, expr)
+
+	value := &ir.StructConst{
+		Typ:    c.types.get(expr.Function),
+		Fields: []ir.Value{function, ir.Null},
+	}
+
+	if len(expr.Captures) == 0 {
+		c.exprResult = exprValue{v: value}
+		return
+	}
+
+	// Environment
+	envTyp, align := c.getClosureEnv(expr)
+	env := c.allocas.getTyp(envTyp, align, "closure.env")
+
+	for i, capture := range expr.Captures {
+		variable := c.scopes.getVariable(capture.Name)
+
+		var field ir.Value
+
+		if capture.Reference {
+			field = c.toAddressable(variable.value, capture.ActualType).v
+		} else {
+			field = c.load(variable.value, capture.ActualType).v
+		}
+
+		pointer := c.block.Add(&ir.GetElementPtrInst{
+			PointerTyp: &ir.PointerType{Pointee: envTyp.Fields[i]},
+			Typ:        envTyp,
+			Pointer:    env,
+			Indices: []ir.Value{
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(i))},
+			},
+			Inbounds: true,
+		})
+
+		store := c.block.Add(&ir.StoreInst{
+			Pointer: pointer,
+			Value:   field,
+			Align:   c.getCaptureAlign(capture),
+		})
+
+		c.setLocationMeta(store, capture)
+	}
+
+	result := c.block.Add(&ir.InsertValueInst{
+		Value:   value,
+		Element: env,
+		Indices: []uint32{1},
+	})
+
+	c.setLocationMeta(result, expr)
+	c.exprResult = exprValue{v: result}
+}
+
+// getClosureEnv returns the type of the environment that stores the captured variables of a closure.
+func (c *codegen) getClosureEnv(expr *ast.Closure) (*ir.StructType, uint32) {
+	fields := make([]ir.Type, len(expr.Captures))
+	align := uint32(1)
+
+	for i, capture := range expr.Captures {
+		if capture.Reference {
+			pointer := ast.Pointer{Pointee: capture.ActualType}
+			fields[i] = c.types.get(&pointer)
+		} else {
+			fields[i] = c.types.get(capture.ActualType)
+		}
+
+		align = max(align, c.getCaptureAlign(capture))
+	}
+
+	return &ir.StructType{Fields: fields}, align
+}
+
+func (c *codegen) getCaptureAlign(capture *ast.Capture) uint32 {
+	if capture.Reference {
+		pointer := ast.Pointer{Pointee: capture.ActualType}
+		return abi.GetTargetAbi().Align(&pointer)
+	}
+
+	return abi.GetTargetAbi().Align(capture.ActualType)
+}
+
 func (c *codegen) VisitIdentifier(expr *ast.Identifier) {
 	switch expr.Result().Kind {
 	case ast.TypeResultKind, ast.ResolverResultKind:
@@ -475,6 +664,9 @@ func (c *codegen) VisitIdentifier(expr *ast.Identifier) {
 		case *ast.GlobalVar:
 			c.exprResult = c.getGlobalVariable(node)
 
+		case *ast.Const:
+			c.exprResult = c.getConstant(node)
+
 		default:
 			if v := c.scopes.getVariable(expr.Name); v != nil {
 				c.exprResult = v.value
@@ -489,7 +681,7 @@ func (c *codegen) VisitIdentifier(expr *ast.Identifier) {
 		case *ast.GlobalVar:
 			c.exprResult = c.getGlobalVariable(node)
 
-		case *ast.Var, *ast.Param:
+		case *ast.Var, *ast.Param, *ast.Token, *ast.Capture:
 			if v := c.scopes.getVariable(expr.Name); v != nil {
 				c.exprResult = v.value
 			}
@@ -505,22 +697,34 @@ func (c *codegen) VisitAssignment(expr *ast.Assignment) {
 	assignee := c.acceptExpr(expr.Assignee)
 
 	// Value
-	value := c.implicitCastLoadExpr(expr.Assignee.Result().Type, expr.Value)
-
-	if expr.Operator.Token().Kind != scanner.Equal {
-		value = c.binary(
-			expr.Operator,
-			c.load(assignee, expr.Assignee.Result().Type),
-			value,
-			expr.Assignee.Result().Type,
-		)
+	var value exprValue
+
+	if method := getOperatorMethod(expr); method != nil {
+		value = c.binaryOperator(method, assignee, expr.Assignee.Result().Type, expr.Value, expr.Operator)
+
+		if needsImplicitCast(method.Returns(), expr.Assignee.Result().Type) {
+			value = c.cast(value, method.Returns(), expr.Assignee.Result().Type, expr)
+		}
+
+		value = c.load(value, expr.Assignee.Result().Type)
+	} else {
+		value = c.implicitCastLoadExpr(expr.Assignee.Result().Type, expr.Value)
+
+		if expr.Operator.Token().Kind != scanner.Equal {
+			value = c.binary(
+				expr.Operator,
+				c.load(assignee, expr.Assignee.Result().Type),
+				value,
+				expr.Assignee.Result().Type,
+			)
+		}
 	}
 
 	// Store
 	store := c.block.Add(&ir.StoreInst{
 		Pointer: assignee.v,
 		Value:   value.v,
-		Align:   abi.GetTargetAbi().Align(expr.Result().Type),
+		Align:   c.getAlign(assignee, expr.Result().Type),
 	})
 
 	c.setLocationMeta(store, expr)
@@ -535,43 +739,31 @@ func (c *codegen) VisitCast(expr *ast.Cast) {
 
 	case scanner.Is:
 		value := c.loadExpr(expr.Value)
+		var result ir.MetaValue
 
-		// Get vtable pointer
-		vtablePtr := c.block.Add(&ir.ExtractValueInst{
-			Value:   value.v,
-			Indices: []uint32{uint32(0)},
-		})
-
-		// Get type id
-		typ := c.vtables.getType(expr.Value.Result().Type.Resolved().(*ast.Interface))
-		typPtr := &ir.PointerType{Pointee: typ}
-
-		typeIdPtr := c.block.Add(&ir.GetElementPtrInst{
-			PointerTyp: typPtr,
-			Typ:        typ,
-			Pointer:    vtablePtr,
-			Indices: []ir.Value{
-				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
-				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
-			},
-			Inbounds: true,
-		})
+		if _, ok := ast.As[ast.InterfaceType](expr.Target); ok {
+			// Interfaces are checked by looking for the vtable of the interface
+			result = c.block.Add(&ir.ICmpInst{
+				Kind:   ir.Ne,
+				Signed: false,
+				Left:   c.getInterfaceVtable(value.v, expr.Target),
+				Right:  ir.Null,
+			})
+		} else {
+			// Interface values always store pointers so checking for a struct or a pointer to it is the same
+			target := expr.Target
 
-		typeId := c.block.Add(&ir.LoadInst{
-			Typ:     ir.I32,
-			Pointer: typeIdPtr,
-		})
+			if pointer, ok := ast.As[*ast.Pointer](target); ok {
+				target = pointer.Pointee
+			}
 
-		// Compare
-		result := c.block.Add(&ir.ICmpInst{
-			Kind:   ir.Eq,
-			Signed: false,
-			Left:   typeId,
-			Right: &ir.IntConst{
-				Typ:   ir.I32,
-				Value: ir.Unsigned(uint64(c.ctx.GetTypeID(expr.Target))),
-			},
-		})
+			result = c.block.Add(&ir.ICmpInst{
+				Kind:   ir.Eq,
+				Signed: false,
+				Left:   c.getDynamicTypeInfo(value.v),
+				Right:  c.typeInfos.get(target),
+			})
+		}
 
 		c.setLocationMeta(result, expr.Operator)
 		c.exprResult = exprValue{v: result}
@@ -589,6 +781,22 @@ func (c *codegen) VisitTypeCall(expr *ast.TypeCall) {
 		value = abi.GetTargetAbi().Size(expr.Arg)
 	case "alignof":
 		value = abi.GetTargetAbi().Align(expr.Arg)
+	case "countof":
+		value = uint32(len(expr.Arg.Resolved().(*ast.Enum).Cases))
+
+	case "casesof":
+		enum := expr.Arg.Resolved().(*ast.Enum)
+		typ := c.types.get(expr.Result().Type).(*ir.StructType)
+
+		c.exprResult = exprValue{v: &ir.StructConst{
+			Typ: typ,
+			Fields: []ir.Value{
+				c.enums.getCases(enum),
+				&ir.IntConst{Typ: typ.Fields[1], Value: ir.Unsigned(uint64(len(enum.Cases)))},
+			},
+		}}
+
+		return
 
 	default:
 		panic("codegen.VisitTypeCall() - Not implemented")
@@ -601,25 +809,93 @@ func (c *codegen) VisitTypeCall(expr *ast.TypeCall) {
 }
 
 func (c *codegen) VisitTypeof(expr *ast.Typeof) {
-	c.exprResult = exprValue{v: &ir.IntConst{
-		Typ:   c.types.get(expr.Result().Type),
-		Value: ir.Unsigned(uint64(c.ctx.GetTypeID(expr.Arg.Result().Type))),
-	}}
+	switch expr.Callee.String() {
+	case "nameof":
+		c.exprResult = exprValue{v: c.nameof(expr.Arg, ast.Resolved(expr.Arg.Result().Type).(*ast.Enum))}
+	case "dyntypeof":
+		c.exprResult = exprValue{v: c.getDynamicTypeInfo(c.loadExpr(expr.Arg).v)}
+
+	default:
+		c.exprResult = exprValue{v: c.typeInfos.get(expr.Arg.Result().Type)}
+	}
+}
+
+// argToParams evaluates the argument of the parameter at the index and converts it to the values passed to the function.
+func (c *codegen) argToParams(function ast.FuncType, funcAbi abi.Abi, i int, arg ast.Expr) []ir.Value {
+	if i >= function.ParameterCount() {
+		return []ir.Value{c.loadExpr(arg).v}
+	}
+
+	param := function.ParameterIndex(i)
+	var value exprValue
+
+	if needsImplicitCast(arg.Result().Type, param.Type) {
+		value = c.implicitCastLoadExpr(param.Type, arg)
+	} else {
+		value = c.acceptExpr(arg)
+	}
+
+	// Extern functions receive only the code pointer of function values
+	paramType := getAbiType(function, param.Type)
+
+	if paramType != param.Type {
+		value = exprValue{v: c.block.Add(&ir.ExtractValueInst{
+			Value:   c.load(value, param.Type).v,
+			Indices: []uint32{0},
+		})}
+	}
+
+	return c.valueToParams(funcAbi, value, paramType, nil)
 }
 
 func (c *codegen) VisitCall(expr *ast.Call) {
+	// Enum case
+	if expr.Callee.Result().Kind == ast.ValueResultKind {
+		if case_, ok := expr.Callee.Result().Value().(*ast.EnumCase); ok {
+			c.enumCaseCall(expr, case_)
+			return
+		}
+
+		if enum, ok := expr.Callee.Result().Value().(*ast.Enum); ok {
+			c.flagsCall(expr, enum)
+			return
+		}
+	}
+
+	// Atomic intrinsic
+	if expr.Callee.Result().Kind == ast.CallableResultKind {
+		if f, ok := expr.Callee.Result().Callable().(ast.FuncType); ok && ast.IsAtomicIntrinsic(f.Underlying().IntrinsicName()) {
+			c.atomicCall(expr, f)
+			return
+		}
+	}
+
 	// Get type
 	callee := c.acceptExpr(expr.Callee)
 
 	var function ast.FuncType
+	var env ir.Value
 
-	if _, ok := expr.Callee.Result().Callable().(*ast.Func); ok {
-		function = expr.Callee.Result().Type.(ast.FuncType)
+	if expr.Callee.Result().Kind == ast.CallableResultKind {
+		if _, ok := expr.Callee.Result().Callable().(ast.FuncType); ok {
+			function = expr.Callee.Result().Type.(ast.FuncType)
+		}
 	}
 
 	if f, ok := ast.As[ast.FuncType](expr.Callee.Result().Type); ok && function == nil {
+		// Function values contain the code pointer and the environment of closures
 		function = f
-		callee = c.load(callee, expr.Callee.Result().Type)
+		value := c.load(callee, expr.Callee.Result().Type)
+
+		callee = exprValue{v: c.block.Add(&ir.ExtractValueInst{
+			Value:   value.v,
+			Indices: []uint32{0},
+		})}
+
+		env = c.block.Add(&ir.ExtractValueInst{
+			Value:   value.v,
+			Indices: []uint32{1},
+		})
 	}
 
 	// Load arguments
@@ -628,7 +904,7 @@ func (c *codegen) VisitCall(expr *ast.Call) {
 		hasReceiver = true
 	}
 
-	argCount := len(expr.Args)
+	argCount := len(expr.ActualArgs)
 	if hasReceiver {
 		argCount++
 	}
@@ -651,21 +927,24 @@ func (c *codegen) VisitCall(expr *ast.Call) {
 		args = append(args, c.this.v)
 	}
 
-	for i, arg := range expr.Args {
-		if i >= function.ParameterCount() {
-			args = append(args, c.loadExpr(arg).v)
-		} else {
-			param := function.ParameterIndex(i)
-			var value exprValue
+	// Arguments are already matched to parameters, missing ones are filled with their default values. Arguments are
+	// evaluated in source order, default values after them.
+	params := make([][]ir.Value, len(expr.ActualArgs))
+	evaluated := make([]bool, len(expr.ActualArgs))
 
-			if needsImplicitCast(arg.Result().Type, param.Type) {
-				value = c.implicitCastLoadExpr(param.Type, arg)
-			} else {
-				value = c.acceptExpr(arg)
-			}
+	for _, arg := range expr.Args {
+		if i := slices.Index(expr.ActualArgs, arg); i != -1 {
+			params[i] = c.argToParams(function, funcAbi, i, arg)
+			evaluated[i] = true
+		}
+	}
 
-			args = c.valueToParams(funcAbi, value, param.Type, args)
+	for i, arg := range expr.ActualArgs {
+		if !evaluated[i] {
+			params[i] = c.argToParams(function, funcAbi, i, arg)
 		}
+
+		args = append(args, params[i]...)
 	}
 
 	// Intrinsic
@@ -676,13 +955,20 @@ func (c *codegen) VisitCall(expr *ast.Call) {
 	}
 
 	// Call
-	call := c.block.Add(&ir.CallInst{
-		Typ:    c.types.get(function).(*ir.FuncType),
-		Callee: callee.v,
-		Args:   args,
-	})
+	var call ir.Value
 
-	c.setLocationMetaCst(call, expr, scanner.LeftParen)
+	if env != nil {
+		call = c.callValue(expr, function, callee.v, env, args, hasReturnPtr)
+	} else {
+		inst := c.block.Add(&ir.CallInst{
+			Typ:    c.types.getFunc(function),
+			Callee: callee.v,
+			Args:   args,
+		})
+
+		c.setLocationMetaCst(inst, expr, scanner.LeftParen)
+		call = inst
+	}
 
 	if ast.IsPrimitive(function.Returns(), ast.Void) {
 		c.exprResult = exprValue{v: call}
@@ -696,7 +982,17 @@ func (c *codegen) VisitCall(expr *ast.Call) {
 			}
 		}
 
-		c.exprResult = c.returnValueToValue(funcAbi, result, function.Returns())
+		returnType := getAbiType(function, function.Returns())
+		c.exprResult = c.returnValueToValue(funcAbi, result, returnType)
+
+		// Extern functions return only the code pointer of function values
+		if returnType != function.Returns() {
+			c.exprResult = exprValue{v: c.block.Add(&ir.InsertValueInst{
+				Value:   &ir.ZeroInitConst{Typ: c.types.get(function.Returns())},
+				Element: c.exprResult.v,
+				Indices: []uint32{0},
+			})}
+		}
 	}
 
 	// If the function returns a constant-sized array and the array is immediately indexed then store it in an alloca first
@@ -716,16 +1012,191 @@ func (c *codegen) VisitCall(expr *ast.Call) {
 	}
 }
 
+// callValue calls the code pointer of a function value. Closures with an environment receive it as an extra parameter
+// after the return pointer so both cases are handled at runtime.
+func (c *codegen) callValue(expr *ast.Call, function ast.FuncType, code, env ir.Value, args []ir.Value, hasReturnPtr bool) ir.Value {
+	plain := c.function.Block("call.plain")
+	closure := c.function.Block("call.closure")
+	end := c.function.Block("call.end")
+
+	// Check environment
+	isNull := c.block.Add(&ir.ICmpInst{
+		Kind:   ir.Eq,
+		Signed: false,
+		Left:   env,
+		Right:  ir.Null,
+	})
+
+	c.block.Add(&ir.BrInst{
+		Condition: isNull,
+		True:      plain,
+		False:     closure,
+	})
+
+	// Plain function
+	c.beginBlock(plain)
+
+	plainCall := c.block.Add(&ir.CallInst{
+		Typ:    c.types.getFunc(function),
+		Callee: code,
+		Args:   args,
+	})
+
+	c.setLocationMetaCst(plainCall, expr, scanner.LeftParen)
+	c.block.Add(&ir.BrInst{True: end})
+
+	// Closure
+	c.beginBlock(closure)
+
+	index := 0
+	if hasReturnPtr {
+		index++
+	}
+
+	closureArgs := make([]ir.Value, 0, len(args)+1)
+	closureArgs = append(closureArgs, args[:index]...)
+	closureArgs = append(closureArgs, env)
+	closureArgs = append(closureArgs, args[index:]...)
+
+	closureCall := c.block.Add(&ir.CallInst{
+		Typ:    c.types.getFuncEnv(function),
+		Callee: code,
+		Args:   closureArgs,
+	})
+
+	c.setLocationMetaCst(closureCall, expr, scanner.LeftParen)
+	c.block.Add(&ir.BrInst{True: end})
+
+	// End
+	c.beginBlock(end)
+
+	if c.types.getFunc(function).Returns.Equals(ir.Void) {
+		return plainCall
+	}
+
+	return c.block.Add(&ir.PhiInst{Incs: []ir.Incoming{
+		{
+			Value: plainCall,
+			Label: plain,
+		},
+		{
+			Value: closureCall,
+			Label: closure,
+		},
+	}})
+}
+
+func (c *codegen) flagsCall(expr *ast.Call, enum *ast.Enum) {
+	// value.has(flag) == (value & flag) == flag
+	value := c.loadExpr(expr.Callee.(*ast.Member).Value)
+	flag := c.implicitCastLoadExpr(enum, expr.Args[0])
+
+	and := c.block.Add(&ir.AndInst{
+		Left:  value.v,
+		Right: flag.v,
+	})
+
+	result := c.block.Add(&ir.ICmpInst{
+		Kind:   ir.Eq,
+		Signed: false,
+		Left:   and,
+		Right:  flag.v,
+	})
+
+	c.setLocationMeta(result, expr)
+	c.exprResult = exprValue{v: result}
+}
+
+func (c *codegen) enumCaseCall(expr *ast.Call, case_ *ast.EnumCase) {
+	enum := case_.Parent().(*ast.Enum)
+	layout := abi.GetEnumLayout(abi.GetTargetAbi(), enum)
+	fields, offsets := abi.GetEnumCaseFields(abi.GetTargetAbi(), case_)
+
+	result := exprValue{
+		v:           c.allocas.get(enum, ""),
+		addressable: true,
+	}
+
+	// Tag
+	store := c.block.Add(&ir.StoreInst{
+		Pointer: result.v,
+		Value: &ir.IntConst{
+			Typ:   c.types.get(enum.ActualType),
+			Value: ir.Signed(case_.ActualValue),
+		},
+		Align: abi.GetTargetAbi().Align(enum.ActualType),
+	})
+
+	c.setLocationMetaCst(store, expr, scanner.LeftParen)
+
+	// Payload
+	for i, field := range case_.Fields {
+		value := c.implicitCastLoadExpr(field.Type(), expr.Args[i])
+		pointer := c.enumFieldPointer(result, field.Type(), layout.PayloadOffset+offsets[slices.Index(fields, field)])
+
+		c.block.Add(&ir.StoreInst{
+			Pointer: pointer,
+			Value:   value.v,
+			Align:   abi.GetTargetAbi().Align(field.Type()),
+		})
+	}
+
+	c.exprResult = result
+}
+
+func (c *codegen) enumFieldPointer(value exprValue, type_ ast.Type, offset uint32) ir.Value {
+	ptrType := ast.Pointer{Pointee: type_}
+
+	return c.block.Add(&ir.GetElementPtrInst{
+		PointerTyp: c.types.get(&ptrType),
+		Typ:        ir.I8,
+		Pointer:    value.v,
+		Indices:    []ir.Value{&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(offset))}},
+		Inbounds:   true,
+	})
+}
+
 func (c *codegen) VisitIndex(expr *ast.Index) {
 	value := c.acceptExpr(expr.Value)
+
+	if method := getOperatorMethod(expr); method != nil {
+		result := c.callOperator(method, value, expr.Value.Result().Type, expr.Index, expr)
+
+		// Index methods returning a pointer produce an addressable value
+		if _, ok := ast.As[*ast.Pointer](method.Returns()); ok {
+			result = exprValue{
+				v:           c.load(result, method.Returns()).v,
+				addressable: true,
+			}
+		}
+
+		c.exprResult = result
+		return
+	}
+
 	index := c.loadExpr(expr.Index)
 
-	if pointer, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); ok {
-		value = exprValue{v: c.block.Add(&ir.LoadInst{
-			Typ:     value.v.Type().(*ir.PointerType).Pointee,
-			Pointer: value.v,
-			Align:   abi.GetTargetAbi().Align(pointer.Pointee),
-		})}
+	switch type_ := ast.Resolved(expr.Value.Result().Type).(type) {
+	case *ast.Array:
+		if c.ctx.SafetyChecks {
+			c.checkBounds(expr.Index, index, expr.Index.Result().Type, &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(uint64(type_.Count))})
+		}
+
+	case *ast.Pointer:
+		value = c.load(value, type_)
+
+		if c.ctx.SafetyChecks {
+			c.checkNil(expr.Value, value.v)
+		}
+
+	case *ast.Slice:
+		slice := c.load(value, type_).v
+		value = exprValue{v: c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{0}})}
+
+		if c.ctx.SafetyChecks {
+			length := c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{1}})
+			c.checkBounds(expr.Index, index, expr.Index.Result().Type, length)
+		}
 	}
 
 	ptrType := ast.Pointer{Pointee: expr.Result().Type}
@@ -743,9 +1214,79 @@ func (c *codegen) VisitIndex(expr *ast.Index) {
 	c.exprResult = exprValue{
 		v:           result,
 		addressable: true,
+		align:       value.align,
 	}
 }
 
+func (c *codegen) VisitSlicing(expr *ast.Slicing) {
+	value := c.acceptExpr(expr.Value)
+	u64 := ast.Primitive{Kind: ast.U64}
+
+	// Get pointer and length
+	var pointer ir.Value
+	var length ir.Value
+
+	switch type_ := ast.Resolved(expr.Value.Result().Type).(type) {
+	case *ast.Array:
+		pointer = value.v
+		length = &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(uint64(type_.Count))}
+
+	case *ast.Pointer:
+		pointer = c.load(value, type_).v
+
+	case *ast.Slice:
+		slice := c.load(value, type_).v
+
+		pointer = c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{0}})
+		length = c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{1}})
+	}
+
+	// Bounds
+	var low ir.Value = &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(0)}
+	high := length
+
+	if expr.Low != nil {
+		low = c.cast(c.loadExpr(expr.Low), expr.Low.Result().Type, &u64, expr.Low).v
+	}
+	if expr.High != nil {
+		high = c.cast(c.loadExpr(expr.High), expr.High.Result().Type, &u64, expr.High).v
+	}
+
+	if c.ctx.SafetyChecks {
+		c.checkSliceBounds(expr, low, high, length)
+	}
+
+	// Create slice
+	base := expr.Result().Type.(*ast.Slice).Base
+	ptrType := ast.Pointer{Pointee: base}
+
+	data := c.block.Add(&ir.GetElementPtrInst{
+		PointerTyp: c.types.get(&ptrType),
+		Typ:        c.types.get(base),
+		Pointer:    pointer,
+		Indices:    []ir.Value{low},
+		Inbounds:   true,
+	})
+
+	c.setLocationMetaCst(data, expr, scanner.LeftBracket)
+
+	var result ir.Value = &ir.ZeroInitConst{Typ: c.types.get(expr.Result().Type)}
+
+	result = c.block.Add(&ir.InsertValueInst{
+		Value:   result,
+		Element: data,
+		Indices: []uint32{0},
+	})
+
+	result = c.block.Add(&ir.InsertValueInst{
+		Value:   result,
+		Element: c.block.Add(&ir.SubInst{Left: high, Right: low}),
+		Indices: []uint32{1},
+	})
+
+	c.exprResult = exprValue{v: result}
+}
+
 func (c *codegen) VisitMember(expr *ast.Member) {
 	value := c.acceptExpr(expr.Value)
 
@@ -754,56 +1295,56 @@ func (c *codegen) VisitMember(expr *ast.Member) {
 		// Nothing
 
 	case ast.ValueResultKind:
+		// Slice
+		if _, ok := ast.As[*ast.Slice](expr.Value.Result().Type); ok {
+			index := uint32(0)
+			if expr.Name.String() == "len" {
+				index = 1
+			}
+
+			result := c.block.Add(&ir.ExtractValueInst{
+				Value:   c.load(value, expr.Value.Result().Type).v,
+				Indices: []uint32{index},
+			})
+
+			c.setLocationMeta(result, expr.Name)
+			c.exprResult = exprValue{v: result}
+
+			return
+		}
+
 		switch node := expr.Result().Value().(type) {
 		case *ast.EnumCase:
-			c.exprResult = exprValue{v: &ir.IntConst{
-				Typ:   c.types.get(node.Parent().(*ast.Enum).ActualType),
+			enum := node.Parent().(*ast.Enum)
+
+			tag := &ir.IntConst{
+				Typ:   c.types.get(enum.ActualType),
 				Value: ir.Signed(node.ActualValue),
-			}}
+			}
+
+			if enum.IsTagged() {
+				typ := c.types.get(enum).(*ir.StructType)
+
+				c.exprResult = exprValue{v: &ir.StructConst{
+					Typ:    typ,
+					Fields: []ir.Value{tag, &ir.ZeroInitConst{Typ: typ.Fields[1]}},
+				}}
+			} else {
+				c.exprResult = exprValue{v: tag}
+			}
 
 		case ast.FieldLike:
 			if node.Underlying().IsStatic() {
 				c.exprResult = c.getStaticVariable(node)
 			} else {
-				struct_ := node.Struct()
-				fields, _ := abi.GetStructLayout(struct_.Underlying()).Fields(abi.GetTargetAbi(), struct_)
-				_, i := getField(fields, node.Name())
-
-				value, s := c.memberLoad(expr.Value.Result().Type, value)
-
-				if value.addressable {
-					ptrType := ast.Pointer{Pointee: node.Type()}
-
-					result := c.block.Add(&ir.GetElementPtrInst{
-						PointerTyp: c.types.get(&ptrType),
-						Typ:        c.types.get(s),
-						Pointer:    value.v,
-						Indices: []ir.Value{
-							&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
-							&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(i))},
-						},
-						Inbounds: true,
-					})
-
-					c.setLocationMeta(result, expr.Name)
-
-					c.exprResult = exprValue{
-						v:           result,
-						addressable: true,
-					}
-				} else {
-					result := c.block.Add(&ir.ExtractValueInst{
-						Value:   value.v,
-						Indices: []uint32{uint32(i)},
-					})
-
-					c.setLocationMeta(result, expr.Name)
-					c.exprResult = exprValue{v: result}
-				}
+				c.exprResult = c.fieldValue(expr, node, value)
 			}
 
 		case *ast.GlobalVar:
 			c.exprResult = c.getGlobalVariable(node)
+
+		case *ast.Const:
+			c.exprResult = c.getConstant(node)
 		}
 
 	case ast.CallableResultKind:
@@ -812,15 +1353,19 @@ func (c *codegen) VisitMember(expr *ast.Member) {
 			if node.IsStatic() {
 				c.exprResult = c.getStaticVariable(node)
 			} else {
-				value, _ := c.memberLoad(expr.Value.Result().Type, value)
-
-				c.exprResult = c.getFunction(expr.Result().Type.(ast.FuncType))
-				c.this = value
+				c.exprResult = c.fieldValue(expr, node, value)
 			}
 
 		case ast.FuncType:
+			// Generic, the method of the type argument is called directly
+			if _, ok := node.Parent().(*ast.Interface); ok {
+				if method := c.getBoundMethod(expr.Value.Result().Type, node); method != nil {
+					node = method
+				}
+			}
+
 			// Interface
-			if inter, ok := ast.As[*ast.Interface](expr.Value.Result().Type); ok {
+			if inter, ok := ast.As[ast.InterfaceType](expr.Value.Result().Type); ok {
 				if value.addressable {
 					value = c.load(value, expr.Value.Result().Type)
 				}
@@ -889,6 +1434,11 @@ func (c *codegen) VisitMember(expr *ast.Member) {
 				}
 			}
 
+			// Default methods not implemented by the type argument receive an interface value
+			if inter, ok := node.Parent().(*ast.Interface); ok {
+				value = c.defaultMethodReceiver(value, expr.Value.Result().Type, inter)
+			}
+
 			c.exprResult = c.getFunction(node)
 			c.this = value
 
@@ -904,6 +1454,65 @@ func (c *codegen) VisitMember(expr *ast.Member) {
 	}
 }
 
+func (c *codegen) fieldValue(expr *ast.Member, field ast.FieldLike, value exprValue) exprValue {
+	struct_, _ := ast.As[ast.StructType](field.Struct())
+	fields, offsets := abi.GetStructLayout(struct_.Underlying()).Fields(abi.GetTargetAbi(), struct_)
+	_, i := getField(fields, field.Name())
+
+	value, s := c.memberLoad(expr.Value.Result().Type, value)
+
+	if _, ok := ast.As[*ast.Pointer](expr.Value.Result().Type); ok && c.ctx.SafetyChecks {
+		c.checkNil(expr.Value, value.v)
+	}
+
+	// Fields of unions don't match the stored type so they are always read through memory
+	if s.Underlying().IsUnion() {
+		value = c.toAddressable(value, s)
+	}
+
+	if value.addressable {
+		ptrType := ast.Pointer{Pointee: field.Type()}
+
+		result := c.block.Add(&ir.GetElementPtrInst{
+			PointerTyp: c.types.get(&ptrType),
+			Typ:        c.types.get(s),
+			Pointer:    value.v,
+			Indices: []ir.Value{
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
+				&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(c.types.getFieldIndex(s, i)))},
+			},
+			Inbounds: true,
+		})
+
+		c.setLocationMeta(result, expr.Name)
+
+		// Fields of packed structs can be placed at offsets smaller than their natural alignment
+		align := c.getAlign(value, s)
+
+		if offsets[i] != 0 {
+			align = min(align, offsets[i]&-offsets[i])
+		}
+
+		if align >= abi.GetTargetAbi().Align(field.Type()) {
+			align = 0
+		}
+
+		return exprValue{
+			v:           result,
+			addressable: true,
+			align:       align,
+		}
+	}
+
+	result := c.block.Add(&ir.ExtractValueInst{
+		Value:   value.v,
+		Indices: []uint32{uint32(c.types.getFieldIndex(s, i))},
+	})
+
+	c.setLocationMeta(result, expr.Name)
+	return exprValue{v: result}
+}
+
 func (c *codegen) memberLoad(type_ ast.Type, value exprValue) (exprValue, ast.StructType) {
 	if s, ok := ast.As[ast.StructType](type_); ok {
 		return value, s
@@ -911,6 +1520,13 @@ func (c *codegen) memberLoad(type_ ast.Type, value exprValue) (exprValue, ast.St
 
 	if v, ok := ast.As[*ast.Pointer](type_); ok {
 		if v, ok := ast.As[ast.StructType](v.Pointee); ok {
+			if !value.addressable {
+				return exprValue{
+					v:           value.v,
+					addressable: true,
+				}, v
+			}
+
 			load := c.block.Add(&ir.LoadInst{
 				Typ:     value.v.Type().(*ir.PointerType).Pointee,
 				Pointer: value.v,
@@ -942,7 +1558,7 @@ func getField(fields []ast.FieldLike, name ast.Node) (ast.FieldLike, int) {
 func (c *codegen) binaryLoad(left, right ast.Expr, operator *ast.Token) exprValue {
 	// Interface == Pointer
 	if operator.Token().Kind == scanner.EqualEqual || operator.Token().Kind == scanner.BangEqual {
-		if _, ok := ast.As[*ast.Interface](left.Result().Type); ok {
+		if _, ok := ast.As[ast.InterfaceType](left.Result().Type); ok {
 			if _, ok := ast.As[*ast.Pointer](right.Result().Type); ok {
 				left := c.loadExpr(left)
 				right := c.loadExpr(right)
@@ -996,32 +1612,142 @@ func (c *codegen) binaryLoad(left, right ast.Expr, operator *ast.Token) exprValu
 	panic("codegen.binaryLoad() - Not implemented")
 }
 
+// binaryOperator calls the method implementing a binary operator applied to a struct. Equality and comparison operators
+// turn the result of the 'eq' and 'compare' methods into a bool.
+func (c *codegen) binaryOperator(method ast.FuncType, left exprValue, leftType ast.Type, right ast.Expr, operator *ast.Token) exprValue {
+	result := c.callOperator(method, left, leftType, right, operator)
+	kind := operator.Token().Kind
+
+	switch {
+	case kind == scanner.BangEqual:
+		inst := c.block.Add(&ir.XorInst{
+			Left:  ir.True,
+			Right: c.load(result, method.Returns()).v,
+		})
+
+		c.setLocationMeta(inst, operator)
+		return exprValue{v: inst}
+
+	case scanner.IsComparison(kind):
+		return c.binary(operator, c.load(result, method.Returns()), exprValue{v: &ir.IntConst{
+			Typ:   c.types.get(method.Returns()),
+			Value: ir.Signed(0),
+		}}, method.Returns())
+
+	default:
+		return result
+	}
+}
+
+// callOperator calls the method implementing an operator with the struct operand as the receiver.
+func (c *codegen) callOperator(method ast.FuncType, this exprValue, thisType ast.Type, arg ast.Expr, location ast.Node) exprValue {
+	funcAbi := abi.GetFuncAbi(method.Underlying())
+	returnArgs := funcAbi.Classify(method.Returns(), nil)
+
+	args := make([]ir.Value, 0, 3)
+	hasReturnPtr := false
+
+	if len(returnArgs) == 1 && returnArgs[0].Class == abi.Memory {
+		pointer := c.allocas.get(method.Returns(), "")
+		pointer.TypPtr.SRet = c.types.get(method.Returns())
+
+		args = append(args, pointer)
+		hasReturnPtr = true
+	}
+
+	args = append(args, c.toAddressable(this, thisType).v)
+
+	if arg != nil {
+		param := method.ParameterIndex(0)
+		var value exprValue
+
+		if needsImplicitCast(arg.Result().Type, param.Type) {
+			value = c.implicitCastLoadExpr(param.Type, arg)
+		} else {
+			value = c.acceptExpr(arg)
+		}
+
+		args = c.valueToParams(funcAbi, value, param.Type, args)
+	}
+
+	call := c.block.Add(&ir.CallInst{
+		Typ:    c.types.getFunc(method),
+		Callee: c.getFunction(method).v,
+		Args:   args,
+	})
+
+	c.setLocationMeta(call, location)
+
+	result := exprValue{v: call}
+
+	if hasReturnPtr {
+		result = exprValue{
+			v:           args[0],
+			addressable: true,
+		}
+	}
+
+	return c.returnValueToValue(funcAbi, result, method.Returns())
+}
+
+// getOperatorMethod returns the method implementing the operator of the expression, if any.
+func getOperatorMethod(expr ast.Expr) ast.FuncType {
+	if expr.Result().Kind == ast.ValueResultKind {
+		if method, ok := expr.Result().Value().(ast.FuncType); ok {
+			return method
+		}
+	}
+
+	return nil
+}
+
 func (c *codegen) binary(op ast.Node, left exprValue, right exprValue, type_ ast.Type) exprValue {
 	left = c.load(left, type_)
 	right = c.load(right, type_)
 
 	var result ir.MetaValue
+	checked := c.ctx.SafetyChecks && isInteger(type_)
 
 	switch op.Token().Kind {
 	case scanner.Plus, scanner.PlusEqual, scanner.PlusPlus:
+		if checked {
+			return exprValue{v: c.checkedArithmetic(op, "add", left.v, right.v, type_)}
+		}
+
 		result = c.block.Add(&ir.AddInst{
 			Left:  left.v,
 			Right: right.v,
 		})
 
 	case scanner.Minus, scanner.MinusEqual, scanner.MinusMinus:
+		if checked {
+			return exprValue{v: c.checkedArithmetic(op, "sub", left.v, right.v, type_)}
+		}
+
 		result = c.block.Add(&ir.SubInst{
 			Left:  left.v,
 			Right: right.v,
 		})
 
 	case scanner.Star, scanner.StarEqual:
+		if checked {
+			return exprValue{v: c.checkedArithmetic(op, "mul", left.v, right.v, type_)}
+		}
+
 		result = c.block.Add(&ir.MulInst{
 			Left:  left.v,
 			Right: right.v,
 		})
 
 	case scanner.Slash, scanner.SlashEqual:
+		if checked {
+			c.checkDivisionByZero(op, right.v, type_)
+
+			if isSigned(type_) {
+				c.checkDivisionOverflow(op, left.v, right.v, type_)
+			}
+		}
+
 		if isFloating(type_) {
 			result = c.block.Add(&ir.FDivInst{
 				Left:  left.v,
@@ -1036,6 +1762,14 @@ func (c *codegen) binary(op ast.Node, left exprValue, right exprValue, type_ ast
 		}
 
 	case scanner.Percentage, scanner.PercentageEqual:
+		if checked {
+			c.checkDivisionByZero(op, right.v, type_)
+
+			if isSigned(type_) {
+				c.checkDivisionOverflow(op, left.v, right.v, type_)
+			}
+		}
+
 		if isFloating(type_) {
 			result = c.block.Add(&ir.FRemInst{
 				Left:  left.v,
@@ -0,0 +1,191 @@
+package codegen
+
+import (
+	"fireball/core/ast"
+	"fireball/core/ir"
+	"fmt"
+)
+
+// Runtime safety checks, each failed check prints the location of the check and traps
+
+func (c *codegen) checkedArithmetic(op ast.Node, operation string, left, right ir.Value, type_ ast.Type) ir.MetaValue {
+	typ := c.types.get(type_)
+
+	name := fmt.Sprintf("llvm.%s%s.with.overflow.i%d", ternary(isSigned(type_), "s", "u"), operation, typ.(*ir.IntType).BitSize)
+	returns := &ir.StructType{Fields: []ir.Type{typ, ir.I1}}
+
+	function := c.getRuntimeFunction(name, returns, typ, typ)
+
+	call := c.block.Add(&ir.CallInst{
+		Typ:    function.Typ,
+		Callee: function,
+		Args:   []ir.Value{left, right},
+	})
+
+	c.setLocationMeta(call, op)
+
+	overflow := c.block.Add(&ir.ExtractValueInst{Value: call, Indices: []uint32{1}})
+	c.safetyCheck(overflow, op, "Integer overflow")
+
+	return c.block.Add(&ir.ExtractValueInst{Value: call, Indices: []uint32{0}})
+}
+
+func (c *codegen) checkDivisionByZero(op ast.Node, right ir.Value, type_ ast.Type) {
+	zero := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  right,
+		Right: &ir.IntConst{Typ: c.types.get(type_), Value: ir.Unsigned(0)},
+	})
+
+	c.safetyCheck(zero, op, "Division by zero")
+}
+
+// checkDivisionOverflow checks for the minimum value divided by -1, the only signed division that overflows.
+func (c *codegen) checkDivisionOverflow(op ast.Node, left, right ir.Value, type_ ast.Type) {
+	typ := c.types.get(type_)
+	min_ := int64(-1) << (typ.(*ir.IntType).BitSize - 1)
+
+	isMin := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  left,
+		Right: &ir.IntConst{Typ: typ, Value: ir.Signed(min_)},
+	})
+
+	isMinusOne := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  right,
+		Right: &ir.IntConst{Typ: typ, Value: ir.Signed(-1)},
+	})
+
+	overflow := c.block.Add(&ir.AndInst{
+		Left:  isMin,
+		Right: isMinusOne,
+	})
+
+	c.safetyCheck(overflow, op, "Integer overflow")
+}
+
+func (c *codegen) checkBounds(node ast.Node, index exprValue, indexType ast.Type, length ir.Value) {
+	u64 := ast.Primitive{Kind: ast.U64}
+
+	if !ast.IsPrimitive(indexType, ast.U64) {
+		index = c.cast(index, indexType, &u64, node)
+	}
+
+	// Negative indices wrap around to large unsigned values
+	outOfBounds := c.block.Add(&ir.ICmpInst{
+		Kind:   ir.Ge,
+		Signed: false,
+		Left:   index.v,
+		Right:  length,
+	})
+
+	c.safetyCheck(outOfBounds, node, "Index out of bounds")
+}
+
+// checkSliceBounds checks that the high bound of a slicing expression is inside the sliced value and that the low
+// bound is not after it. The length is nil when slicing a pointer, bounds are missing when they are not specified.
+func (c *codegen) checkSliceBounds(expr *ast.Slicing, low, high, length ir.Value) {
+	if expr.High != nil && length != nil {
+		outOfBounds := c.block.Add(&ir.ICmpInst{
+			Kind:   ir.Gt,
+			Signed: false,
+			Left:   high,
+			Right:  length,
+		})
+
+		c.safetyCheck(outOfBounds, expr.High, "Slice bounds out of range")
+	}
+
+	if expr.Low != nil {
+		outOfBounds := c.block.Add(&ir.ICmpInst{
+			Kind:   ir.Gt,
+			Signed: false,
+			Left:   low,
+			Right:  high,
+		})
+
+		c.safetyCheck(outOfBounds, expr.Low, "Slice bounds out of range")
+	}
+}
+
+func (c *codegen) checkNil(node ast.Node, pointer ir.Value) {
+	null := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  pointer,
+		Right: ir.Null,
+	})
+
+	c.safetyCheck(null, node, "Nil pointer dereference")
+}
+
+// safetyCheck branches to a block printing the message and trapping when the condition is true.
+func (c *codegen) safetyCheck(failed ir.Value, node ast.Node, message string) {
+	fail := c.function.Block("safety.fail")
+	ok := c.function.Block("safety.ok")
+
+	c.setLocationMeta(c.block.Add(&ir.BrInst{Condition: failed, True: fail, False: ok}), node)
+
+	// Fail
+	c.beginBlock(fail)
+
+	if node.Cst() != nil {
+		pos := node.Cst().Range.Start
+		message = fmt.Sprintf("PANIC   [%s:%d:%d] %s", c.path, pos.Line, pos.Column+1, message)
+	}
+
+	ptr := &ir.PointerType{Pointee: ir.I8}
+
+	puts := c.getRuntimeFunction("puts", ir.I32, ptr)
+	c.block.Add(&ir.CallInst{Typ: puts.Typ, Callee: puts, Args: []ir.Value{c.module.Constant("", rawString(message))}})
+
+	// Flush all output streams since the trap skips the usual cleanup
+	fflush := c.getRuntimeFunction("fflush", ir.I32, ptr)
+	c.block.Add(&ir.CallInst{Typ: fflush.Typ, Callee: fflush, Args: []ir.Value{ir.Null}})
+
+	trapFunc := c.getRuntimeFunction("llvm.trap", ir.Void)
+	trap := c.block.Add(&ir.CallInst{Typ: trapFunc.Typ, Callee: trapFunc})
+
+	c.setLocationMeta(trap, node)
+	c.block.Add(&ir.UnreachableInst{})
+
+	// Ok
+	c.beginBlock(ok)
+}
+
+// getRuntimeFunction returns the declaration of an external function, reusing an existing one with the same name.
+func (c *codegen) getRuntimeFunction(name string, returns ir.Type, params ...ir.Type) *ir.Func {
+	for _, function := range c.module.Functions {
+		if function.Name() == name {
+			return function
+		}
+	}
+
+	typ := &ir.FuncType{Returns: returns}
+
+	for _, param := range params {
+		typ.Params = append(typ.Params, &ir.Param{Typ: param})
+	}
+
+	return c.module.Declare(name, typ)
+}
+
+// rawString converts text into a null terminated string constant without processing escape sequences.
+func rawString(s string) *ir.StringConst {
+	b := &ir.StringConst{
+		Length: uint32(len(s)) + 1,
+		Value:  make([]byte, 0, len(s)+3),
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.Value = append(b.Value, []byte(fmt.Sprintf("\\%02X", s[i]))...)
+		} else {
+			b.Value = append(b.Value, s[i])
+		}
+	}
+
+	b.Value = append(b.Value, '\\', '0', '0')
+
+	return b
+}
@@ -8,6 +8,13 @@ import (
 type scope struct {
 	variableI     int
 	variableCount int
+
+	defers []deferred
+}
+
+type deferred struct {
+	stmt      *ast.Defer
+	variables int
 }
 
 type variable struct {
@@ -123,7 +130,7 @@ func (s *scopes) pop() {
 	s.variables = s.variables[:s.get().variableI]
 	s.scopes = s.scopes[:len(s.scopes)-1]
 
-	s.scopesMeta = s.scopesMeta[:len(s.scopesMeta)-0]
+	s.scopesMeta = s.scopesMeta[:len(s.scopesMeta)-1]
 }
 
 func (s *scopes) get() *scope {
@@ -134,6 +141,17 @@ func (s *scopes) getMeta() ir.MetaID {
 	return s.scopesMeta[len(s.scopesMeta)-1]
 }
 
+// Defers
+
+func (s *scopes) addDefer(stmt *ast.Defer) {
+	scope := s.get()
+
+	scope.defers = append(scope.defers, deferred{
+		stmt:      stmt,
+		variables: len(s.variables),
+	})
+}
+
 // Variables
 
 func (s *scopes) getVariable(name *ast.Token) *variable {
@@ -157,10 +175,6 @@ func (s *scopes) addVariable(name ast.Node, type_ ast.Type, value ir.Value, arg
 			}})
 	}
 
-	if _, ok := ast.As[ast.FuncType](type_); ok {
-		type_ = &ast.Pointer{Pointee: type_}
-	}
-
 	meta := &ir.LocalVarMeta{
 		Name:  name.String(),
 		Type:  s.c.types.getMeta(type_),
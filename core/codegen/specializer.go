@@ -14,11 +14,18 @@ type structReplacement struct {
 	ptr   *ast.Type
 }
 
+type interfaceReplacement struct {
+	i     *ast.Interface
+	types []ast.Type
+	ptr   *ast.Type
+}
+
 type specializer struct {
 	generics []*ast.Generic
 
-	direct  []directReplacement
-	structs []structReplacement
+	direct     []directReplacement
+	structs    []structReplacement
+	interfaces []interfaceReplacement
 }
 
 func (s *specializer) prepare(node ast.Node, generics []*ast.Generic) {
@@ -28,6 +35,7 @@ func (s *specializer) prepare(node ast.Node, generics []*ast.Generic) {
 	s.generics = generics
 	s.direct = finder.direct
 	s.structs = finder.structs
+	s.interfaces = finder.interfaces
 }
 
 func (s *specializer) specialize(types []ast.Type) {
@@ -38,23 +46,30 @@ func (s *specializer) specialize(types []ast.Type) {
 
 	// Structs
 	for _, rep := range s.structs {
-		// Create new specialized types slice
-		specTypes := make([]ast.Type, len(rep.types))
-		copy(specTypes, rep.types)
+		*rep.ptr = rep.s.Specialize(s.replaceGenerics(rep.types, types))
+	}
 
-		// Replace generics
-		for typeI, type_ := range specTypes {
-			for genericI, generic := range s.generics {
-				if generic.Equals(type_) {
-					specTypes[typeI] = types[genericI]
-					break
-				}
+	// Interfaces
+	for _, rep := range s.interfaces {
+		*rep.ptr = rep.i.Specialize(s.replaceGenerics(rep.types, types))
+	}
+}
+
+// replaceGenerics creates a new slice of types where the generic parameters are replaced with the types.
+func (s *specializer) replaceGenerics(original []ast.Type, types []ast.Type) []ast.Type {
+	specTypes := make([]ast.Type, len(original))
+	copy(specTypes, original)
+
+	for typeI, type_ := range specTypes {
+		for genericI, generic := range s.generics {
+			if generic.Equals(type_) {
+				specTypes[typeI] = types[genericI]
+				break
 			}
 		}
-
-		// Specialize
-		*rep.ptr = rep.s.Specialize(specTypes)
 	}
+
+	return specTypes
 }
 
 func (s *specializer) finish() {
@@ -67,6 +82,11 @@ func (s *specializer) finish() {
 	for _, rep := range s.structs {
 		*rep.ptr = nil
 	}
+
+	// Interfaces
+	for _, rep := range s.interfaces {
+		*rep.ptr = nil
+	}
 }
 
 // Finder
@@ -74,8 +94,9 @@ func (s *specializer) finish() {
 type specializationFinder struct {
 	generics []*ast.Generic
 
-	direct  []directReplacement
-	structs []structReplacement
+	direct     []directReplacement
+	structs    []structReplacement
+	interfaces []interfaceReplacement
 }
 
 func (s *specializationFinder) VisitNode(node ast.Node) {
@@ -102,6 +123,12 @@ func (s *specializationFinder) VisitNode(node ast.Node) {
 			}
 		}
 
+		if spec, ok := node.Type.(*ast.SpecializedInterface); ok {
+			if s.visitSpecInterface(spec) {
+				return
+			}
+		}
+
 		s.VisitNode(node.Type)
 
 	case *ast.Generic:
@@ -118,8 +145,11 @@ func (s *specializationFinder) VisitNode(node ast.Node) {
 	case *ast.SpecializedStruct:
 		s.visitSpecStruct(node)
 
+	case *ast.SpecializedInterface:
+		s.visitSpecInterface(node)
+
 	case ast.Expr:
-		s.VisitNode(node.Result().Type)
+		s.visitType(node.Result().Type)
 
 		node.AcceptChildren(s)
 
@@ -128,6 +158,24 @@ func (s *specializationFinder) VisitNode(node ast.Node) {
 	}
 }
 
+// visitType visits the type of an expression, only the signature of functions and interfaces can reference generic
+// types, visiting their bodies would also never end for functions calling themselves.
+func (s *specializationFinder) visitType(type_ ast.Type) {
+	switch type_ := type_.(type) {
+	case *ast.Func:
+		for _, param := range type_.Params {
+			s.VisitNode(param.Type)
+		}
+
+		s.VisitNode(type_.Returns_)
+
+	case *ast.Interface:
+
+	default:
+		s.VisitNode(type_)
+	}
+}
+
 func (s *specializationFinder) visitStruct(struct_ *ast.Struct) bool {
 	var types []ast.Type
 
@@ -139,6 +187,8 @@ func (s *specializationFinder) visitStruct(struct_ *ast.Struct) bool {
 			type_ = fieldType.Pointee
 		case *ast.Array:
 			type_ = fieldType.Base
+		case *ast.Slice:
+			type_ = fieldType.Base
 		case *ast.Resolvable:
 			type_ = fieldType.Type
 		default:
@@ -180,6 +230,17 @@ func (s *specializationFinder) visitSpecStruct(spec *ast.SpecializedStruct) bool
 	return false
 }
 
+func (s *specializationFinder) visitSpecInterface(spec *ast.SpecializedInterface) bool {
+	for _, type_ := range spec.Types {
+		if s.getGenericI(type_) != -1 {
+			s.addSpecInterface(spec)
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *specializationFinder) getGenericI(type_ ast.Type) int {
 	for i, generic := range s.generics {
 		if generic.Equals(type_) {
@@ -231,3 +292,17 @@ func (s *specializationFinder) addSpecStruct(spec *ast.SpecializedStruct) {
 		ptr:   &spec.Type,
 	})
 }
+
+func (s *specializationFinder) addSpecInterface(spec *ast.SpecializedInterface) {
+	for _, rep := range s.interfaces {
+		if rep.ptr == &spec.Type {
+			return
+		}
+	}
+
+	s.interfaces = append(s.interfaces, interfaceReplacement{
+		i:     spec.Underlying(),
+		types: spec.Types,
+		ptr:   &spec.Type,
+	})
+}
@@ -3,7 +3,9 @@ package codegen
 import (
 	"fireball/core/abi"
 	"fireball/core/ast"
+	"fireball/core/common"
 	"fireball/core/ir"
+	"slices"
 )
 
 func (c *codegen) VisitBlock(stmt *ast.Block) {
@@ -13,7 +15,7 @@ func (c *codegen) VisitBlock(stmt *ast.Block) {
 		c.acceptStmt(s)
 	}
 
-	c.scopes.pop()
+	c.popScope()
 }
 
 func (c *codegen) VisitExpression(stmt *ast.Expression) {
@@ -83,6 +85,7 @@ func (c *codegen) VisitWhile(stmt *ast.While) {
 	// Get blocks
 	prevLoopSkip := c.loopSkip
 	prevLoopEnd := c.loopEnd
+	prevLoopScope := c.loopScope
 
 	c.loopSkip = c.function.Block("while.condition")
 	body := c.function.Block("while.body")
@@ -100,23 +103,29 @@ func (c *codegen) VisitWhile(stmt *ast.While) {
 
 	// Body
 	c.beginBlock(body)
+	c.loopScope = len(c.scopes.scopes)
+	c.pushLoopLabel(stmt.Label)
 
 	if c.acceptStmt(stmt.Body) {
 		c.block.Add(&ir.BrInst{True: c.loopSkip})
 	}
 
+	c.popLoopLabel(stmt.Label)
+
 	// End
 	c.beginBlock(c.loopEnd)
 
 	// Reset basic block names
 	c.loopSkip = prevLoopSkip
 	c.loopEnd = prevLoopEnd
+	c.loopScope = prevLoopScope
 }
 
 func (c *codegen) VisitFor(stmt *ast.For) {
 	// Get blocks
 	prevLoopSkip := c.loopSkip
 	prevLoopEnd := c.loopEnd
+	prevLoopScope := c.loopScope
 
 	condition := c.function.Block("for.condition")
 	body := c.function.Block("for.body")
@@ -144,11 +153,15 @@ func (c *codegen) VisitFor(stmt *ast.For) {
 
 	// Body
 	c.beginBlock(body)
+	c.loopScope = len(c.scopes.scopes)
+	c.pushLoopLabel(stmt.Label)
 
 	if c.acceptStmt(stmt.Body) {
 		c.block.Add(&ir.BrInst{True: c.loopSkip})
 	}
 
+	c.popLoopLabel(stmt.Label)
+
 	// Increment
 	c.beginBlock(c.loopSkip)
 
@@ -162,11 +175,290 @@ func (c *codegen) VisitFor(stmt *ast.For) {
 	// Reset basic block names
 	c.loopSkip = prevLoopSkip
 	c.loopEnd = prevLoopEnd
+	c.loopScope = prevLoopScope
+}
+
+func (c *codegen) VisitForIn(stmt *ast.ForIn) {
+	// Get blocks
+	prevLoopSkip := c.loopSkip
+	prevLoopEnd := c.loopEnd
+	prevLoopScope := c.loopScope
+
+	condition := c.function.Block("for.condition")
+	body := c.function.Block("for.body")
+	c.loopEnd = c.function.Block("for.end")
+
+	// Iterable
+	iterable := c.acceptExpr(stmt.Iterable)
+	type_ := stmt.Iterable.Result().Type
+
+	// Variable
+	c.scopes.pushBlock(stmt)
+
+	variable := c.allocas.get(stmt.ActualType, stmt.Name.String()+".var")
+	c.setLocationMeta(variable, stmt.Name)
+
+	c.scopes.addVariable(stmt.Name, stmt.ActualType, variable, 0)
+
+	// Condition
+	switch t := ast.Resolved(type_).(type) {
+	case *ast.Array:
+		iterable = c.toAddressable(iterable, t)
+		length := &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(uint64(t.Count))}
+
+		c.forInIndexed(stmt, iterable.v, length, variable, condition, body)
+
+	case *ast.Slice:
+		slice := c.load(iterable, t).v
+
+		pointer := c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{0}})
+		length := c.block.Add(&ir.ExtractValueInst{Value: slice, Indices: []uint32{1}})
+
+		c.forInIndexed(stmt, pointer, length, variable, condition, body)
+
+	default:
+		c.forInIterator(stmt, iterable, variable, condition, body)
+	}
+
+	// Body
+	c.beginBlock(body)
+	c.loopScope = len(c.scopes.scopes)
+	c.pushLoopLabel(stmt.Label)
+
+	if c.acceptStmt(stmt.Body) {
+		c.block.Add(&ir.BrInst{True: c.loopSkip})
+	}
+
+	c.popLoopLabel(stmt.Label)
+
+	// End
+	c.scopes.pop()
+	c.beginBlock(c.loopEnd)
+
+	// Reset basic block names
+	c.loopSkip = prevLoopSkip
+	c.loopEnd = prevLoopEnd
+	c.loopScope = prevLoopScope
+}
+
+func (c *codegen) forInIndexed(stmt *ast.ForIn, pointer, length, variable ir.Value, condition, body *ir.Block) {
+	u64 := ast.Primitive{Kind: ast.U64}
+
+	index := c.allocas.get(&u64, "for.index")
+	c.loopSkip = c.function.Block("for.increment")
+
+	c.block.Add(&ir.StoreInst{
+		Pointer: index,
+		Value:   &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(0)},
+		Align:   abi.GetTargetAbi().Align(&u64),
+	})
+
+	c.block.Add(&ir.BrInst{True: condition})
+
+	// Condition
+	c.beginBlock(condition)
+
+	value := c.block.Add(&ir.LoadInst{
+		Typ:     ir.I64,
+		Pointer: index,
+		Align:   abi.GetTargetAbi().Align(&u64),
+	})
+
+	result := c.block.Add(&ir.ICmpInst{
+		Kind:   ir.Lt,
+		Signed: false,
+		Left:   value,
+		Right:  length,
+	})
+
+	c.setLocationMeta(c.block.Add(&ir.BrInst{Condition: result, True: body, False: c.loopEnd}), stmt)
+
+	// Copy the element into the variable at the start of the body
+	c.beginBlock(body)
+
+	ptrType := ast.Pointer{Pointee: stmt.ActualType}
+	align := abi.GetTargetAbi().Align(stmt.ActualType)
+
+	element := c.block.Add(&ir.GetElementPtrInst{
+		PointerTyp: c.types.get(&ptrType),
+		Typ:        c.types.get(stmt.ActualType),
+		Pointer:    pointer,
+		Indices:    []ir.Value{value},
+		Inbounds:   true,
+	})
+
+	c.block.Add(&ir.StoreInst{
+		Pointer: variable,
+		Value: c.block.Add(&ir.LoadInst{
+			Typ:     c.types.get(stmt.ActualType),
+			Pointer: element,
+			Align:   align,
+		}),
+		Align: align,
+	})
+
+	// Increment
+	c.beginBlock(c.loopSkip)
+
+	c.block.Add(&ir.StoreInst{
+		Pointer: index,
+		Value: c.block.Add(&ir.AddInst{
+			Left:  value,
+			Right: &ir.IntConst{Typ: ir.I64, Value: ir.Unsigned(1)},
+		}),
+		Align: abi.GetTargetAbi().Align(&u64),
+	})
+
+	c.block.Add(&ir.BrInst{True: condition})
+}
+
+func (c *codegen) forInIterator(stmt *ast.ForIn, iterable exprValue, variable ir.Value, condition, body *ir.Block) {
+	type_ := stmt.Iterable.Result().Type
+
+	// Get pointer to the iterator
+	if pointer, ok := ast.As[*ast.Pointer](type_); ok {
+		iterable = c.load(iterable, pointer)
+		type_ = pointer.Pointee
+	} else {
+		iterable = c.toAddressable(iterable, type_)
+	}
+
+	c.loopSkip = condition
+	c.block.Add(&ir.BrInst{True: condition})
+
+	// Condition
+	c.beginBlock(condition)
+
+	method := common.GetIteratorMethod(type_)
+	funcAbi := abi.GetFuncAbi(method)
+
+	args := []ir.Value{iterable.v}
+	args = c.valueToParams(funcAbi, exprValue{v: variable}, method.ParameterIndex(0).Type, args)
+
+	call := c.block.Add(&ir.CallInst{
+		Typ:    c.types.getFunc(method),
+		Callee: c.getFunction(method).v,
+		Args:   args,
+	})
+
+	c.setLocationMeta(call, stmt.Iterable)
+
+	result := c.returnValueToValue(funcAbi, exprValue{v: call}, method.Returns())
+	c.block.Add(&ir.BrInst{Condition: result.v, True: body, False: c.loopEnd})
+}
+
+func (c *codegen) VisitMatch(stmt *ast.Match) {
+	// Get blocks
+	arms := make([]*ir.Block, len(stmt.Arms))
+	end := c.function.Block("match.end")
+	default_ := end
+
+	for i, arm := range stmt.Arms {
+		arms[i] = c.function.Block("match.arm")
+
+		if arm.Default {
+			default_ = arms[i]
+		}
+	}
+
+	// Value
+	var value exprValue
+	var pointer exprValue
+
+	if enum, ok := ast.As[*ast.Enum](stmt.Value.Result().Type); ok && enum.IsTagged() {
+		// The tag is stored at the start of a tagged enum
+		pointer = c.toAddressable(c.acceptExpr(stmt.Value), enum)
+
+		value = exprValue{v: c.block.Add(&ir.LoadInst{
+			Typ:     c.types.get(enum.ActualType),
+			Pointer: pointer.v,
+			Align:   abi.GetTargetAbi().Align(enum.ActualType),
+		})}
+	} else {
+		value = c.loadExpr(stmt.Value)
+	}
+
+	switch_ := &ir.SwitchInst{
+		Value:   value.v,
+		Default: default_,
+	}
+
+	for i, arm := range stmt.Arms {
+		// Arm values are evaluated by the checker
+		for _, constant := range arm.ActualValues {
+			switch_.Cases = append(switch_.Cases, ir.SwitchCase{
+				Value: &ir.IntConst{Typ: value.v.Type(), Value: ir.Signed(constant)},
+				Label: arms[i],
+			})
+		}
+	}
+
+	c.setLocationMeta(c.block.Add(switch_), stmt)
+
+	// Arms
+	for i, arm := range stmt.Arms {
+		c.beginBlock(arms[i])
+		c.scopes.pushBlock(arm)
+
+		if len(arm.Bindings) > 0 {
+			c.matchBindings(arm, pointer)
+		}
+
+		if c.acceptStmt(arm.Body) {
+			c.block.Add(&ir.BrInst{True: end})
+		}
+
+		c.scopes.pop()
+	}
+
+	// End
+	c.beginBlock(end)
+}
+
+func (c *codegen) matchBindings(arm *ast.MatchArm, pointer exprValue) {
+	case_ := arm.Values[0].Result().Value().(*ast.EnumCase)
+	layout := abi.GetEnumLayout(abi.GetTargetAbi(), case_.Parent().(*ast.Enum))
+	fields, offsets := abi.GetEnumCaseFields(abi.GetTargetAbi(), case_)
+
+	for i, binding := range arm.Bindings {
+		if binding.String() == "_" {
+			continue
+		}
+
+		field := case_.Fields[i]
+		align := abi.GetTargetAbi().Align(field.Type())
+
+		// Copy the field out of the payload
+		fieldPointer := c.enumFieldPointer(pointer, field.Type(), layout.PayloadOffset+offsets[slices.Index(fields, field)])
+
+		load := c.block.Add(&ir.LoadInst{
+			Typ:     c.types.get(field.Type()),
+			Pointer: fieldPointer,
+			Align:   align,
+		})
+
+		variable := c.allocas.get(field.Type(), binding.String()+".var")
+		c.setLocationMeta(variable, binding)
+
+		c.block.Add(&ir.StoreInst{
+			Pointer: variable,
+			Value:   load,
+			Align:   align,
+		})
+
+		c.scopes.addVariable(binding, field.Type(), variable, 0)
+	}
+}
+
+func (c *codegen) VisitDefer(stmt *ast.Defer) {
+	c.scopes.addDefer(stmt)
 }
 
 func (c *codegen) VisitReturn(stmt *ast.Return) {
 	if stmt.Value == nil {
 		// Void
+		c.runDefers(0)
+
 		c.setLocationMeta(
 			c.block.Add(&ir.RetInst{}),
 			stmt,
@@ -178,6 +470,8 @@ func (c *codegen) VisitReturn(stmt *ast.Return) {
 		value := c.implicitCastLoadExpr(c.astFunction.Returns(), stmt.Value)
 		value = c.valueToReturnValue(funcAbi, value, c.astFunction.Returns(), c.function.Typ.Params)
 
+		c.runDefers(0)
+
 		if value.v == nil {
 			c.setLocationMeta(
 				c.block.Add(&ir.RetInst{}),
@@ -195,8 +489,11 @@ func (c *codegen) VisitReturn(stmt *ast.Return) {
 }
 
 func (c *codegen) VisitBreak(stmt *ast.Break) {
+	loop := c.getLoopLabel(stmt.Label)
+	c.runDefers(loop.scope)
+
 	c.setLocationMeta(
-		c.block.Add(&ir.BrInst{True: c.loopEnd}),
+		c.block.Add(&ir.BrInst{True: loop.end}),
 		stmt,
 	)
 
@@ -204,10 +501,57 @@ func (c *codegen) VisitBreak(stmt *ast.Break) {
 }
 
 func (c *codegen) VisitContinue(stmt *ast.Continue) {
+	loop := c.getLoopLabel(stmt.Label)
+	c.runDefers(loop.scope)
+
 	c.setLocationMeta(
-		c.block.Add(&ir.BrInst{True: c.loopSkip}),
+		c.block.Add(&ir.BrInst{True: loop.skip}),
 		stmt,
 	)
 
 	c.block = nil
 }
+
+// Loop labels
+
+type loopLabel struct {
+	name string
+
+	skip  *ir.Block
+	end   *ir.Block
+	scope int
+}
+
+func (c *codegen) pushLoopLabel(label *ast.Token) {
+	if label != nil {
+		c.loopLabels = append(c.loopLabels, loopLabel{
+			name:  label.String(),
+			skip:  c.loopSkip,
+			end:   c.loopEnd,
+			scope: c.loopScope,
+		})
+	}
+}
+
+func (c *codegen) popLoopLabel(label *ast.Token) {
+	if label != nil {
+		c.loopLabels = c.loopLabels[:len(c.loopLabels)-1]
+	}
+}
+
+// getLoopLabel returns the blocks of the loop with the label, or of the innermost loop if there is no label.
+func (c *codegen) getLoopLabel(label *ast.Token) loopLabel {
+	if label != nil {
+		for i := len(c.loopLabels) - 1; i >= 0; i-- {
+			if c.loopLabels[i].name == label.String() {
+				return c.loopLabels[i]
+			}
+		}
+	}
+
+	return loopLabel{
+		skip:  c.loopSkip,
+		end:   c.loopEnd,
+		scope: c.loopScope,
+	}
+}
@@ -0,0 +1,437 @@
+package codegen
+
+import (
+	"fireball/core/abi"
+	"fireball/core/ast"
+	"fireball/core/common"
+	"fireball/core/ir"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type typeInfos struct {
+	c *codegen
+
+	cache map[string]ir.Value
+}
+
+// get returns the descriptor of a type, the descriptors are named after the type so that the copies emitted by
+// different modules are merged into a single one when linking.
+func (t *typeInfos) get(type_ ast.Type) ir.Value {
+	type_ = ast.Resolved(type_)
+	name := getTypeInfoName(type_)
+
+	// Check cache
+	if value, ok := t.cache[name]; ok {
+		return value
+	}
+
+	// Create, the global is cached before its value is created because types can reference themselves
+	info := common.GetBuiltinType(t.c.root, "TypeInfo").(ast.StructType)
+
+	global := t.c.module.Constant(name, &ir.ZeroInitConst{Typ: t.c.types.get(info)})
+	global.Linkage = ir.LinkOnceODRLinkage
+
+	t.cache[name] = global
+	global.Value = t.create(info, type_)
+
+	return global
+}
+
+func (t *typeInfos) create(info ast.StructType, type_ ast.Type) ir.Value {
+	values := map[string]ir.Value{
+		"name":  t.c.module.Constant("", convertString(getTypeInfoDisplayName(type_))),
+		"size":  &ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(abi.GetTargetAbi().Size(type_)))},
+		"align": &ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(abi.GetTargetAbi().Align(type_)))},
+	}
+
+	kind := ""
+
+	switch type_ := type_.(type) {
+	case *ast.Primitive:
+		switch {
+		case type_.Kind == ast.Void:
+			kind = "Void"
+		case type_.Kind == ast.Bool:
+			kind = "Bool"
+		case ast.IsFloating(type_.Kind):
+			kind = "Float"
+
+		default:
+			kind = "Integer"
+			values["signed"] = ternary(ast.IsSigned(type_.Kind), ir.True, ir.False)
+		}
+
+	case *ast.Pointer:
+		kind = "Pointer"
+		values["base"] = t.get(type_.Pointee)
+
+	case *ast.Array:
+		kind = "Array"
+		values["count"] = &ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(type_.Count))}
+		values["base"] = t.get(type_.Base)
+
+	case *ast.Slice:
+		kind = "Slice"
+		values["base"] = t.get(type_.Base)
+
+	case ast.StructType:
+		kind = "Struct"
+		values["fields"] = t.getFields(type_)
+		values["methods"], values["interfaces"] = t.getImpls(type_)
+
+	case *ast.Enum:
+		kind = "Enum"
+		values["base"] = t.get(type_.ActualType)
+
+	case ast.InterfaceType:
+		kind = "Interface"
+		methods := make([]ast.FuncType, type_.MethodCount())
+
+		for i := range methods {
+			methods[i] = type_.MethodIndex(i)
+		}
+
+		values["methods"] = t.getMethods(methods)
+
+	case ast.FuncType:
+		kind = "Function"
+		values["fields"] = t.getParams(type_)
+
+		if !ast.IsPrimitive(type_.Returns(), ast.Void) {
+			values["base"] = t.get(type_.Returns())
+		}
+
+	default:
+		panic("codegen.typeInfos.create() - Not implemented")
+	}
+
+	kinds := common.GetBuiltinType(t.c.root, "TypeKind").(*ast.Enum)
+
+	values["kind"] = &ir.IntConst{
+		Typ:   t.c.types.get(kinds.ActualType),
+		Value: ir.Signed(kinds.GetCase(kind).ActualValue),
+	}
+
+	return t.structConst(info, values)
+}
+
+func (t *typeInfos) getFields(s ast.StructType) ir.Value {
+	fields, offsets := abi.GetStructLayout(s.Underlying()).Fields(abi.GetTargetAbi(), s)
+	info := common.GetBuiltinType(t.c.root, "FieldInfo").(ast.StructType)
+
+	values := make([]ir.Value, s.FieldCount())
+
+	for i := range values {
+		field := s.FieldIndex(i)
+		offset := uint32(0)
+
+		for j, f := range fields {
+			if f.Name().String() == field.Name().String() {
+				offset = offsets[j]
+			}
+		}
+
+		values[i] = t.structConst(info, map[string]ir.Value{
+			"name":   t.c.module.Constant("", convertString(field.Name().String())),
+			"type":   t.get(field.Type()),
+			"offset": &ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(offset))},
+		})
+	}
+
+	return t.sliceConst(info, values)
+}
+
+func (t *typeInfos) getParams(function ast.FuncType) ir.Value {
+	info := common.GetBuiltinType(t.c.root, "FieldInfo").(ast.StructType)
+	values := make([]ir.Value, function.ParameterCount())
+
+	// Parameter names are not included since function types with different names are the same type
+	for i := range values {
+		values[i] = t.structConst(info, map[string]ir.Value{
+			"type": t.get(function.ParameterIndex(i).Type),
+		})
+	}
+
+	return t.sliceConst(info, values)
+}
+
+// getImpls returns the methods and implemented interfaces of a struct, both sorted so that they don't depend on the
+// order the files of a namespace are loaded in.
+func (t *typeInfos) getImpls(s ast.StructType) (ir.Value, ir.Value) {
+	var visitor implVisitor
+	visitor.struct_ = s.Underlying()
+
+	ast.GetParent[*ast.File](s.Underlying()).Resolver.GetSymbols(&visitor)
+
+	// Methods
+	var methods []ast.FuncType
+
+	for _, impl := range visitor.impls {
+		for _, method := range impl.Methods {
+			if method.IsStatic() {
+				continue
+			}
+
+			if spec, ok := s.(*ast.SpecializedStruct); ok {
+				methods = append(methods, spec.SpecializeMethod(method))
+			} else {
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	slices.SortStableFunc(methods, func(a, b ast.FuncType) int {
+		return strings.Compare(a.Underlying().Name.String(), b.Underlying().Name.String())
+	})
+
+	// Interfaces
+	var interfaces []ast.Type
+
+	for _, impl := range visitor.impls {
+		if impl.Implements == nil {
+			continue
+		}
+
+		inter := ast.Resolved(impl.Implements)
+
+		// Interfaces of generic structs can depend on the generic arguments, which are not known here
+		if _, ok := s.(*ast.SpecializedStruct); ok {
+			if inter, ok := inter.(*ast.Interface); !ok || len(inter.GenericParams) > 0 {
+				continue
+			}
+		}
+
+		interfaces = appendInterface(interfaces, inter.(ast.InterfaceType))
+	}
+
+	slices.SortStableFunc(interfaces, func(a, b ast.Type) int {
+		return strings.Compare(getTypeInfoName(a), getTypeInfoName(b))
+	})
+
+	info := common.GetBuiltinType(t.c.root, "InterfaceInfo").(ast.StructType)
+	values := make([]ir.Value, len(interfaces))
+
+	for i, inter := range interfaces {
+		values[i] = t.structConst(info, map[string]ir.Value{
+			"type":   t.get(inter),
+			"vtable": t.c.vtables.get(s, inter),
+		})
+	}
+
+	return t.getMethods(methods), t.sliceConst(info, values)
+}
+
+// appendInterface appends an interface and the interfaces it inherits, skipping interfaces which are already present.
+func appendInterface(interfaces []ast.Type, inter ast.InterfaceType) []ast.Type {
+	for _, other := range interfaces {
+		if other.Equals(inter) {
+			return interfaces
+		}
+	}
+
+	interfaces = append(interfaces, inter)
+
+	for _, parent := range inter.Parents() {
+		interfaces = appendInterface(interfaces, parent)
+	}
+
+	return interfaces
+}
+
+func (t *typeInfos) getMethods(methods []ast.FuncType) ir.Value {
+	info := common.GetBuiltinType(t.c.root, "MethodInfo").(ast.StructType)
+	values := make([]ir.Value, len(methods))
+
+	for i, method := range methods {
+		values[i] = t.structConst(info, map[string]ir.Value{
+			"name": t.c.module.Constant("", convertString(method.Underlying().Name.String())),
+			"type": t.get(method),
+		})
+	}
+
+	return t.sliceConst(info, values)
+}
+
+// structConst creates a constant of the struct with the given field values, missing fields are zero initialized.
+func (t *typeInfos) structConst(s ast.StructType, values map[string]ir.Value) ir.Value {
+	fields, _ := abi.GetStructLayout(s.Underlying()).Fields(abi.GetTargetAbi(), s)
+	typ := t.c.types.get(s).(*ir.StructType)
+
+	result := &ir.StructConst{
+		Typ:    typ,
+		Fields: make([]ir.Value, len(typ.Fields)),
+	}
+
+	for i, field := range typ.Fields {
+		result.Fields[i] = &ir.ZeroInitConst{Typ: field}
+	}
+
+	for i, field := range fields {
+		if value, ok := values[field.Name().String()]; ok {
+			result.Fields[t.c.types.getFieldIndex(s, i)] = value
+		}
+	}
+
+	return result
+}
+
+// sliceConst creates a slice constant pointing to a global array with the values.
+func (t *typeInfos) sliceConst(base ast.Type, values []ir.Value) ir.Value {
+	slice := ast.Slice{Base: base}
+	typ := t.c.types.get(&slice).(*ir.StructType)
+
+	if len(values) == 0 {
+		return &ir.ZeroInitConst{Typ: typ}
+	}
+
+	array := t.c.module.Constant("", &ir.ArrayConst{
+		Typ:    &ir.ArrayType{Count: uint32(len(values)), Base: t.c.types.get(base)},
+		Values: values,
+	})
+
+	return &ir.StructConst{
+		Typ: typ,
+		Fields: []ir.Value{
+			array,
+			&ir.IntConst{Typ: typ.Fields[1], Value: ir.Unsigned(uint64(len(values)))},
+		},
+	}
+}
+
+// getDynamicTypeInfo returns the descriptor of the type stored in an interface value, or nil for nil values.
+func (c *codegen) getDynamicTypeInfo(value ir.Value) ir.Value {
+	vtable := c.block.Add(&ir.ExtractValueInst{
+		Value:   value,
+		Indices: []uint32{0},
+	})
+
+	null := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  vtable,
+		Right: ir.Null,
+	})
+
+	start := c.block
+	load := c.function.Block("typeinfo.load")
+	end := c.function.Block("typeinfo.end")
+
+	c.block.Add(&ir.BrInst{Condition: null, True: end, False: load})
+
+	// The descriptor is stored at the start of the vtable
+	c.beginBlock(load)
+
+	typeInfo := c.block.Add(&ir.LoadInst{
+		Typ:     &ir.PointerType{},
+		Pointer: vtable,
+	})
+
+	c.block.Add(&ir.BrInst{True: end})
+
+	// End
+	c.beginBlock(end)
+
+	return c.block.Add(&ir.PhiInst{Incs: []ir.Incoming{
+		{
+			Value: typeInfo,
+			Label: load,
+		},
+		{
+			Value: ir.Null,
+			Label: start,
+		},
+	}})
+}
+
+// getInterfaceVtable returns the vtable of an interface for the type stored in an interface value, or nil if the type
+// doesn't implement it.
+func (c *codegen) getInterfaceVtable(value ir.Value, inter ast.Type) ir.Value {
+	function := common.GetBuiltinFunction(c.root, "getInterfaceVtable")
+
+	return c.block.Add(&ir.CallInst{
+		Typ:    c.types.getFunc(function),
+		Callee: c.getFunction(function).v,
+		Args:   []ir.Value{c.getDynamicTypeInfo(value), c.typeInfos.get(inter)},
+	})
+}
+
+type implVisitor struct {
+	struct_ *ast.Struct
+	impls   []*ast.Impl
+}
+
+func (i *implVisitor) VisitSymbol(node ast.Node) {
+	if impl, ok := node.(*ast.Impl); ok && impl.Type != nil && impl.Type.Equals(i.struct_) {
+		i.impls = append(i.impls, impl)
+	}
+}
+
+// getTypeInfoName returns the global name of the descriptor, which only depends on the type.
+func getTypeInfoName(type_ ast.Type) string {
+	sb := strings.Builder{}
+	sb.WriteString("__fb_typeinfo__")
+
+	writeTypeInfoName(&sb, type_)
+
+	return sb.String()
+}
+
+func writeTypeInfoName(sb *strings.Builder, type_ ast.Type) {
+	switch type_ := ast.Resolved(type_).(type) {
+	case *ast.Primitive:
+		sb.WriteString(type_.Kind.String())
+
+	case *ast.Pointer:
+		sb.WriteRune('*')
+		writeTypeInfoName(sb, type_.Pointee)
+
+	case *ast.Array:
+		sb.WriteString(fmt.Sprintf("[%d]", type_.Count))
+		writeTypeInfoName(sb, type_.Base)
+
+	case *ast.Slice:
+		sb.WriteString("[]")
+		writeTypeInfoName(sb, type_.Base)
+
+	case ast.StructType:
+		type_.MangledName(sb)
+
+	case *ast.Enum:
+		type_.MangledName(sb)
+
+	case ast.InterfaceType:
+		ast.GetParent[*ast.File](type_.Underlying()).Namespace.Name.WriteTo(sb)
+		sb.WriteRune('.')
+		sb.WriteString(ast.PrintType(type_))
+
+	case ast.FuncType:
+		sb.WriteString("fn(")
+
+		for i := 0; i < type_.ParameterCount(); i++ {
+			if i > 0 {
+				sb.WriteRune(',')
+			}
+
+			writeTypeInfoName(sb, type_.ParameterIndex(i).Type)
+		}
+
+		if type_.Underlying().IsVariadic() {
+			sb.WriteString(",...")
+		}
+
+		sb.WriteRune(')')
+		writeTypeInfoName(sb, type_.Returns())
+
+	default:
+		panic("codegen.writeTypeInfoName() - Not implemented")
+	}
+}
+
+func getTypeInfoDisplayName(type_ ast.Type) string {
+	if function, ok := type_.(ast.FuncType); ok {
+		return "fn " + ast.Signature(function, false)
+	}
+
+	return ast.PrintType(type_)
+}
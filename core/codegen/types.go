@@ -23,9 +23,12 @@ type types struct {
 	c *codegen
 
 	interfaceType ir.Type
+	sliceType     ir.Type
+	funcType      ir.Type
 
-	types   []cachedType
-	structs map[string]ir.Type
+	types        []cachedType
+	structs      map[string]ir.Type
+	fieldIndices map[string][]int
 
 	interfaceMetadata ir.MetaID
 	metadata          []cachedTypeMeta
@@ -74,6 +77,25 @@ func (t *types) get(type_ ast.Type) ir.Type {
 
 		return t.cacheType(type_, &ir.ArrayType{Count: type_.Count, Base: t.get(type_.Base)})
 
+	case *ast.Slice:
+		if t.sliceType == nil {
+			void := ast.Primitive{Kind: ast.Void}
+			ptr := ast.Pointer{Pointee: &void}
+
+			typ := &ir.StructType{
+				Name: "__slice",
+				Fields: []ir.Type{
+					t.get(&ptr),
+					ir.I64,
+				},
+			}
+
+			t.c.module.Struct(typ)
+			t.sliceType = typ
+		}
+
+		return t.sliceType
+
 	case ast.StructType:
 		var nameSb strings.Builder
 		type_.MangledName(&nameSb)
@@ -83,16 +105,42 @@ func (t *types) get(type_ ast.Type) ir.Type {
 			return typ
 		}
 
-		astFields, _ := abi.GetStructLayout(type_.Underlying()).Fields(abi.GetTargetAbi(), type_)
-		fields := make([]ir.Type, len(astFields))
+		// The type is cached before creating its fields because they can point to the struct itself
+		typ := &ir.StructType{Name: name}
+		t.structs[name] = typ
+
+		layout, indices := t.getStruct(type_)
+		typ.Fields = layout.Fields
+		typ.Packed = layout.Packed
+
+		t.c.module.Struct(typ)
+		t.fieldIndices[name] = indices
+
+		return typ
 
-		for i, field := range astFields {
-			fields[i] = t.get(field.Type())
+	case *ast.Enum:
+		if !type_.IsTagged() {
+			return t.get(type_.ActualType)
 		}
 
+		var nameSb strings.Builder
+		type_.MangledName(&nameSb)
+		name := nameSb.String()
+
+		if typ, ok := t.structs[name]; ok {
+			return typ
+		}
+
+		// The payload is stored as an array of integers so it keeps the alignment of its most aligned field
+		layout := abi.GetEnumLayout(abi.GetTargetAbi(), type_)
+		unit := &ir.IntType{BitSize: uint8(layout.PayloadAlign * 8)}
+
 		typ := &ir.StructType{
-			Name:   name,
-			Fields: fields,
+			Name: name,
+			Fields: []ir.Type{
+				t.get(type_.ActualType),
+				&ir.ArrayType{Count: (layout.Size - layout.PayloadOffset) / layout.PayloadAlign, Base: unit},
+			},
 		}
 
 		t.c.module.Struct(typ)
@@ -100,10 +148,7 @@ func (t *types) get(type_ ast.Type) ir.Type {
 
 		return typ
 
-	case *ast.Enum:
-		return t.get(type_.ActualType)
-
-	case *ast.Interface:
+	case ast.InterfaceType:
 		if t.interfaceType == nil {
 			void := ast.Primitive{Kind: ast.Void}
 			ptr := ast.Pointer{Pointee: &void}
@@ -123,18 +168,182 @@ func (t *types) get(type_ ast.Type) ir.Type {
 		return t.interfaceType
 
 	case ast.FuncType:
-		if typ := t.getCachedType(type_); typ != nil {
-			return typ
+		// Function values store a pointer to the code and a pointer to the environment of closures
+		if t.funcType == nil {
+			void := ast.Primitive{Kind: ast.Void}
+			ptr := ast.Pointer{Pointee: &void}
+
+			typ := &ir.StructType{
+				Name: "__fn",
+				Fields: []ir.Type{
+					t.get(&ptr),
+					t.get(&ptr),
+				},
+			}
+
+			t.c.module.Struct(typ)
+			t.funcType = typ
 		}
 
-		typ := t.createFuncType(type_)
-		return t.cacheType(type_, typ)
+		return t.funcType
 
 	default:
 		panic("codegen.types.get() - Not implemented")
 	}
 }
 
+func (t *types) getStruct(type_ ast.StructType) (*ir.StructType, []int) {
+	if type_.Underlying().IsUnion() {
+		return t.getUnion(type_)
+	}
+
+	fields, offsets := abi.GetStructLayout(type_.Underlying()).Fields(abi.GetTargetAbi(), type_)
+
+	typ := &ir.StructType{Fields: make([]ir.Type, len(fields))}
+	indices := make([]int, len(fields))
+
+	// Check if LLVM would place all fields at the same offsets by itself
+	packed := false
+	offset := uint32(0)
+	align := uint32(1)
+
+	for i, field := range fields {
+		typ.Fields[i] = t.get(field.Type())
+		indices[i] = i
+
+		fieldAlign := max(abi.GetTargetAbi().Align(field.Type()), 1)
+		align = max(align, fieldAlign)
+
+		if isPackedType(typ.Fields[i]) || alignOffset(offset, fieldAlign) != offsets[i] {
+			packed = true
+		}
+
+		offset = offsets[i] + abi.GetTargetAbi().Size(field.Type())
+	}
+
+	size := abi.GetTargetAbi().Size(type_)
+
+	if align != max(abi.GetTargetAbi().Align(type_), 1) || (len(fields) > 0 && alignOffset(offset, align) != size) {
+		packed = true
+	}
+
+	if !packed {
+		return typ, indices
+	}
+
+	// Packed and aligned fields are laid out with explicit padding
+	typ = &ir.StructType{Packed: true}
+	offset = 0
+
+	for i, field := range fields {
+		if offsets[i] > offset {
+			typ.Fields = append(typ.Fields, &ir.ArrayType{Count: offsets[i] - offset, Base: ir.I8})
+		}
+
+		indices[i] = len(typ.Fields)
+		typ.Fields = append(typ.Fields, t.get(field.Type()))
+
+		offset = offsets[i] + abi.GetTargetAbi().Size(field.Type())
+	}
+
+	if size > offset {
+		typ.Fields = append(typ.Fields, &ir.ArrayType{Count: size - offset, Base: ir.I8})
+	}
+
+	return typ, indices
+}
+
+// getUnion stores the most aligned field of the union followed by padding, all fields are accessed at index 0.
+func (t *types) getUnion(type_ ast.StructType) (*ir.StructType, []int) {
+	typ := &ir.StructType{}
+	indices := make([]int, type_.FieldCount())
+
+	var largest ast.Type
+	largestAlign := uint32(0)
+	largestSize := uint32(0)
+
+	for i := 0; i < type_.FieldCount(); i++ {
+		field := type_.FieldIndex(i).Type()
+		align := abi.GetTargetAbi().Align(field)
+		size := abi.GetTargetAbi().Size(field)
+
+		if largest == nil || align > largestAlign || (align == largestAlign && size > largestSize) {
+			largest = field
+			largestAlign = align
+			largestSize = size
+		}
+	}
+
+	offset := uint32(0)
+
+	if largest != nil {
+		typ.Fields = append(typ.Fields, t.get(largest))
+		offset = largestSize
+
+		if isPackedType(typ.Fields[0]) || max(largestAlign, 1) != max(abi.GetTargetAbi().Align(type_), 1) {
+			typ.Packed = true
+		}
+	}
+
+	if size := abi.GetTargetAbi().Size(type_); size > offset {
+		typ.Fields = append(typ.Fields, &ir.ArrayType{Count: size - offset, Base: ir.I8})
+	}
+
+	return typ, indices
+}
+
+func (t *types) getFieldIndex(type_ ast.StructType, index int) int {
+	t.get(type_)
+
+	var name strings.Builder
+	type_.MangledName(&name)
+
+	return t.fieldIndices[name.String()][index]
+}
+
+func (t *types) getFunc(f ast.FuncType) *ir.FuncType {
+	// Extern functions are not cached because their signature can differ from other functions with the same type
+	if f.Underlying().ExternName() != "" {
+		return t.createFuncType(f)
+	}
+
+	if typ := t.getCachedType(f); typ != nil {
+		return typ.(*ir.FuncType)
+	}
+
+	typ := t.createFuncType(f)
+	t.cacheType(f, typ)
+
+	return typ
+}
+
+// getFuncEnv returns the type of closure functions which take a pointer to their environment as the first parameter
+// after the return pointer.
+func (t *types) getFuncEnv(f ast.FuncType) *ir.FuncType {
+	typ := t.getFunc(f)
+
+	index := 0
+	if len(typ.Params) > 0 {
+		if pointer, ok := typ.Params[0].Typ.(*ir.PointerType); ok && pointer.SRet != nil {
+			index++
+		}
+	}
+
+	void := ast.Primitive{Kind: ast.Void}
+	ptr := ast.Pointer{Pointee: &void}
+
+	params := make([]*ir.Param, 0, len(typ.Params)+1)
+	params = append(params, typ.Params[:index]...)
+	params = append(params, &ir.Param{Typ: t.get(&ptr), Name_: "env"})
+	params = append(params, typ.Params[index:]...)
+
+	return &ir.FuncType{
+		Returns:  typ.Returns,
+		Params:   params,
+		Variadic: typ.Variadic,
+	}
+}
+
 func (t *types) createFuncType(f ast.FuncType) *ir.FuncType {
 	// Intrinsic
 	if f.Underlying().IntrinsicName() != "" {
@@ -152,13 +361,14 @@ func (t *types) createFuncType(f ast.FuncType) *ir.FuncType {
 	params := make([]*ir.Param, 0, parameterCount)
 
 	funcAbi := abi.GetFuncAbi(f.Underlying())
-	returnArgs := funcAbi.Classify(f.Returns(), nil)
+	returnType := getAbiType(f, f.Returns())
+	returnArgs := funcAbi.Classify(returnType, nil)
 
 	if len(returnArgs) == 1 && returnArgs[0].Class == abi.Memory {
 		params = append(params, &ir.Param{
 			Typ: &ir.PointerType{
 				Pointee: ir.Void,
-				SRet:    t.get(f.Returns()),
+				SRet:    t.get(returnType),
 			},
 			Name_: "__return",
 		})
@@ -177,14 +387,15 @@ func (t *types) createFuncType(f ast.FuncType) *ir.FuncType {
 
 	for i := 0; i < f.ParameterCount(); i++ {
 		param := f.ParameterIndex(i)
+		paramType := getAbiType(f, param.Type)
 
-		args := funcAbi.Classify(param.Type, nil)
+		args := funcAbi.Classify(paramType, nil)
 		if len(args) == 0 {
 			panic("codegen.types.createFuncType() - Failed to classify parameter type")
 		}
 
 		for i, arg := range args {
-			typ := t.getAbiArgType(arg, param.Type)
+			typ := t.getAbiArgType(arg, paramType)
 
 			if typ != nil {
 				name := param.Param.Name.String()
@@ -202,12 +413,23 @@ func (t *types) createFuncType(f ast.FuncType) *ir.FuncType {
 	}
 
 	return &ir.FuncType{
-		Returns:  t.createReturnType(returnArgs, f.Returns()),
+		Returns:  t.createReturnType(returnArgs, returnType),
 		Params:   params,
 		Variadic: f.Underlying().IsVariadic(),
 	}
 }
 
+// getAbiType returns the type that a function uses to pass or return values of the given type. Extern functions use
+// plain code pointers instead of function values so that they can be called from C.
+func getAbiType(f ast.FuncType, type_ ast.Type) ast.Type {
+	if _, ok := ast.As[ast.FuncType](type_); ok && f.Underlying().ExternName() != "" {
+		void := ast.Primitive{Kind: ast.Void}
+		return &ast.Pointer{Pointee: &void}
+	}
+
+	return type_
+}
+
 func (t *types) createReturnType(args []abi.Arg, type_ ast.Type) ir.Type {
 	// Void
 	if len(args) == 0 {
@@ -342,7 +564,7 @@ func (t *types) cacheType(type_ ast.Type, typ ir.Type) ir.Type {
 
 func (t *types) getMeta(type_ ast.Type) ir.MetaID {
 	// Interface
-	if _, ok := ast.As[*ast.Interface](type_); ok {
+	if _, ok := ast.As[ast.InterfaceType](type_); ok {
 		if !t.interfaceMetadata.Valid() {
 			void := ast.Primitive{Kind: ast.Void}
 			ptr := ast.Pointer{Pointee: &void}
@@ -459,12 +681,54 @@ func (t *types) getMeta(type_ ast.Type) ir.MetaID {
 
 		return t.cacheMeta(type_, typ)
 
+	case *ast.Slice:
+		ptr := ast.Pointer{Pointee: type_.Base}
+		len_ := ast.Primitive{Kind: ast.U64}
+
+		typ := &ir.CompositeTypeMeta{
+			Tag:   ir.StructureTypeTag,
+			Name:  ast.PrintType(type_),
+			Size:  abi.GetTargetAbi().Size(type_) * 8,
+			Align: abi.GetTargetAbi().Align(type_) * 8,
+			Elements: []ir.MetaID{
+				t.c.module.Meta(&ir.DerivedTypeMeta{
+					Tag:      ir.MemberTag,
+					Name:     "ptr",
+					BaseType: t.getMeta(&ptr),
+					Offset:   0,
+				}),
+				t.c.module.Meta(&ir.DerivedTypeMeta{
+					Tag:      ir.MemberTag,
+					Name:     "len",
+					BaseType: t.getMeta(&len_),
+					Offset:   abi.GetTargetAbi().Size(&ptr) * 8,
+				}),
+			},
+		}
+
+		return t.cacheMeta(type_, typ)
+
 	case ast.StructType:
+		var tag ir.CompositeTagKind = ir.StructureTypeTag
+		if type_.Underlying().IsUnion() {
+			tag = ir.UnionTypeTag
+		}
+
+		typ := &ir.CompositeTypeMeta{
+			Tag:   tag,
+			Name:  type_.Underlying().Name.String(),
+			Size:  abi.GetTargetAbi().Size(type_) * 8,
+			Align: abi.GetTargetAbi().Align(type_) * 8,
+		}
+
+		// Cache before creating the fields because structs can reference themselves through pointers
+		id := t.cacheMeta(type_, typ)
+
 		fields, offsets := abi.GetStructLayout(type_.Underlying()).Fields(abi.GetTargetAbi(), type_)
-		fieldsMeta := make([]ir.MetaID, len(fields))
+		typ.Elements = make([]ir.MetaID, len(fields))
 
 		for i, field := range fields {
-			fieldsMeta[i] = t.c.module.Meta(&ir.DerivedTypeMeta{
+			typ.Elements[i] = t.c.module.Meta(&ir.DerivedTypeMeta{
 				Tag:      ir.MemberTag,
 				Name:     field.Name().String(),
 				BaseType: t.getMeta(field.Type()),
@@ -472,15 +736,7 @@ func (t *types) getMeta(type_ ast.Type) ir.MetaID {
 			})
 		}
 
-		typ := &ir.CompositeTypeMeta{
-			Tag:      ir.StructureTypeTag,
-			Name:     type_.Underlying().Name.String(),
-			Size:     abi.GetTargetAbi().Size(type_) * 8,
-			Align:    abi.GetTargetAbi().Align(type_) * 8,
-			Elements: fieldsMeta,
-		}
-
-		return t.cacheMeta(type_, typ)
+		return id
 
 	case *ast.Enum:
 		cases := make([]ir.MetaID, len(type_.Cases))
@@ -495,43 +751,62 @@ func (t *types) getMeta(type_ ast.Type) ir.MetaID {
 		typ := &ir.CompositeTypeMeta{
 			Tag:      ir.EnumerationTypeTag,
 			Name:     type_.Name.String(),
-			Size:     abi.GetTargetAbi().Size(type_) * 8,
-			Align:    abi.GetTargetAbi().Align(type_) * 8,
+			Size:     abi.GetTargetAbi().Size(type_.ActualType) * 8,
+			Align:    abi.GetTargetAbi().Align(type_.ActualType) * 8,
 			BaseType: t.getMeta(type_.ActualType),
 			Elements: cases,
 		}
 
-		return t.cacheMeta(type_, typ)
-
-	case ast.FuncType:
-		receiver := type_.Receiver()
-
-		parameterCount := type_.ParameterCount()
-		if receiver != nil {
-			parameterCount++
+		if !type_.IsTagged() {
+			return t.cacheMeta(type_, typ)
 		}
 
-		params := make([]ir.MetaID, parameterCount)
-
-		if receiver != nil {
-			type_ := ast.Pointer{Pointee: receiver}
-			params[0] = t.getMeta(&type_)
-		}
+		// Tagged enums are described as a structure containing the tag
+		tag := t.c.module.Meta(&ir.DerivedTypeMeta{
+			Tag:      ir.MemberTag,
+			Name:     "tag",
+			BaseType: t.c.module.Meta(typ),
+			Offset:   0,
+		})
 
-		for index := 0; index < type_.ParameterCount(); index++ {
-			param := type_.ParameterIndex(index)
+		return t.cacheMeta(type_, &ir.CompositeTypeMeta{
+			Tag:      ir.StructureTypeTag,
+			Name:     type_.Name.String(),
+			Size:     abi.GetTargetAbi().Size(type_) * 8,
+			Align:    abi.GetTargetAbi().Align(type_) * 8,
+			Elements: []ir.MetaID{tag},
+		})
 
-			i := index
-			if receiver != nil {
-				i++
-			}
+	case ast.FuncType:
+		void := ast.Primitive{Kind: ast.Void}
+		ptr := ast.Pointer{Pointee: &void}
 
-			params[i] = t.getMeta(param.Type)
-		}
+		code := t.c.module.Meta(&ir.DerivedTypeMeta{
+			Tag:      ir.PointerTypeTag,
+			BaseType: t.getFuncMeta(type_),
+			Size:     abi.GetTargetAbi().Size(&ptr) * 8,
+			Align:    abi.GetTargetAbi().Align(&ptr) * 8,
+		})
 
-		typ := &ir.SubroutineTypeMeta{
-			Returns: t.getMeta(type_.Returns()),
-			Params:  params,
+		typ := &ir.CompositeTypeMeta{
+			Tag:   ir.StructureTypeTag,
+			Name:  ast.PrintType(type_),
+			Size:  abi.GetTargetAbi().Size(type_) * 8,
+			Align: abi.GetTargetAbi().Align(type_) * 8,
+			Elements: []ir.MetaID{
+				t.c.module.Meta(&ir.DerivedTypeMeta{
+					Tag:      ir.MemberTag,
+					Name:     "code",
+					BaseType: code,
+					Offset:   0,
+				}),
+				t.c.module.Meta(&ir.DerivedTypeMeta{
+					Tag:      ir.MemberTag,
+					Name:     "env",
+					BaseType: t.getMeta(&ptr),
+					Offset:   abi.GetTargetAbi().Size(&ptr) * 8,
+				}),
+			},
 		}
 
 		return t.cacheMeta(type_, typ)
@@ -541,6 +816,38 @@ func (t *types) getMeta(type_ ast.Type) ir.MetaID {
 	}
 }
 
+func (t *types) getFuncMeta(f ast.FuncType) ir.MetaID {
+	receiver := f.Receiver()
+
+	parameterCount := f.ParameterCount()
+	if receiver != nil {
+		parameterCount++
+	}
+
+	params := make([]ir.MetaID, parameterCount)
+
+	if receiver != nil {
+		type_ := ast.Pointer{Pointee: receiver}
+		params[0] = t.getMeta(&type_)
+	}
+
+	for index := 0; index < f.ParameterCount(); index++ {
+		param := f.ParameterIndex(index)
+
+		i := index
+		if receiver != nil {
+			i++
+		}
+
+		params[i] = t.getMeta(param.Type)
+	}
+
+	return t.c.module.Meta(&ir.SubroutineTypeMeta{
+		Returns: t.getMeta(f.Returns()),
+		Params:  params,
+	})
+}
+
 func (t *types) cacheMeta(type_ ast.Type, meta ir.Meta) ir.MetaID {
 	id := t.c.module.Meta(meta)
 
@@ -551,3 +858,23 @@ func (t *types) cacheMeta(type_ ast.Type, meta ir.Meta) ir.MetaID {
 
 	return id
 }
+
+func isPackedType(typ ir.Type) bool {
+	switch typ := typ.(type) {
+	case *ir.StructType:
+		return typ.Packed
+	case *ir.ArrayType:
+		return isPackedType(typ.Base)
+
+	default:
+		return false
+	}
+}
+
+func alignOffset(offset, align uint32) uint32 {
+	if offset%align != 0 {
+		offset += align - offset%align
+	}
+
+	return offset
+}
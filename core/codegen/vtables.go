@@ -1,113 +1,284 @@
 package codegen
 
 import (
+	"fireball/core/abi"
 	"fireball/core/ast"
 	"fireball/core/ir"
 	"strings"
 )
 
-type vtableInfo struct {
-	type_ ast.Type
-	inter ast.Type
-	value ir.Value
-}
-
 type vtables struct {
 	c *codegen
 
-	cache []vtableInfo
+	cache map[string]ir.Value
 }
 
 func (v *vtables) get(type_, inter ast.Type) ir.Value {
+	name := getVtableName(type_, inter)
+
 	// Check cache
-	for _, vtable := range v.cache {
-		if vtable.type_.Equals(type_) && vtable.inter.Equals(inter) {
-			return vtable.value
+	if value, ok := v.cache[name]; ok {
+		return value
+	}
+
+	// Create, the global is cached before its value is created because the type descriptor references the vtable
+	interType := inter.Resolved().(ast.InterfaceType)
+	typ := v.getType(interType)
+
+	// Vtables are referenced by type descriptors so they are merged between modules the same way
+	global := v.c.module.Constant(name, &ir.ZeroInitConst{Typ: typ})
+	global.Linkage = ir.LinkOnceODRLinkage
+
+	v.cache[name] = global
+
+	// Methods, the impl can be for an interface inheriting this one
+	impl := ast.GetParent[*ast.File](type_).Resolver.GetImpl(type_, interType)
+	methods := make([]ir.Value, interType.MethodCount())
+
+	for i := range methods {
+		method := interType.MethodIndex(i)
+
+		if implMethod := impl.GetMethod(method.Underlying().Name.String(), false); implMethod != nil {
+			methods[i] = v.c.getFunction(implMethod).v
+		} else {
+			methods[i] = v.getDefaultThunk(type_, method.Underlying())
 		}
 	}
 
-	// Create
-	impl := ast.GetParent[*ast.File](type_).Resolver.GetImpl(type_, inter.Resolved().(*ast.Interface))
-	methods := make([]ir.Value, len(impl.Methods))
+	// Parents
+	var parents ir.Value = &ir.ZeroInitConst{Typ: typ.Fields[2]}
 
-	for i, method := range impl.Methods {
-		methods[i] = v.c.getFunction(method).v
+	if parentTypes := interType.Parents(); len(parentTypes) > 0 {
+		values := make([]ir.Value, len(parentTypes))
+
+		for i, parent := range parentTypes {
+			values[i] = v.get(type_, parent)
+		}
+
+		parents = &ir.ArrayConst{
+			Typ:    typ.Fields[2],
+			Values: values,
+		}
 	}
 
-	typ := v.getType(inter.Resolved().(*ast.Interface))
-
-	value := v.c.module.Constant(
-		getVtableName(type_, inter),
-		&ir.StructConst{
-			Typ: typ,
-			Fields: []ir.Value{
-				&ir.IntConst{
-					Typ:   ir.I32,
-					Value: ir.Unsigned(uint64(v.c.ctx.GetTypeID(type_))),
-				},
-				&ir.ArrayConst{
-					Typ:    typ.Fields[1],
-					Values: methods,
-				},
+	global.Value = &ir.StructConst{
+		Typ: typ,
+		Fields: []ir.Value{
+			v.c.typeInfos.get(type_),
+			&ir.ArrayConst{
+				Typ:    typ.Fields[1],
+				Values: methods,
 			},
+			parents,
 		},
-	)
+	}
+
+	return global
+}
+
+// getDefaultThunk returns a function calling the default implementation of an interface method for a type, the default
+// implementation receives an interface value so the thunk creates one using the vtable of the declaring interface.
+func (v *vtables) getDefaultThunk(type_ ast.Type, method *ast.Func) ir.Value {
+	inter := method.Parent().(*ast.Interface)
+	defaultFunc := v.c.getFunction(method).v.(*ir.Func)
+
+	name := strings.Builder{}
+	name.WriteString("__fb_default__")
+
+	writeTypeInfoName(&name, type_)
+	name.WriteString("__")
+	method.MangledName(&name)
 
-	v.cache = append(v.cache, vtableInfo{
-		type_: type_,
-		inter: inter,
-		value: value,
+	// Thunks are cached together with the vtables since they are needed by the vtables of all inheriting interfaces
+	if thunk, ok := v.cache[name.String()]; ok {
+		return thunk
+	}
+
+	// Signature
+	params := make([]*ir.Param, len(defaultFunc.Typ.Params))
+
+	for i, param := range defaultFunc.Typ.Params {
+		params[i] = &ir.Param{Typ: param.Typ, Name_: param.Name_}
+	}
+
+	thunk := v.c.module.Define(name.String(), &ir.FuncType{Returns: defaultFunc.Typ.Returns, Params: params}, 0)
+	thunk.Linkage = ir.LinkOnceODRLinkage
+
+	v.cache[name.String()] = thunk
+
+	// Body
+	block := thunk.Block("entry")
+	interTyp := v.c.types.get(inter)
+
+	value := block.Add(&ir.AllocaInst{
+		Typ:   interTyp,
+		Align: abi.GetTargetAbi().Align(inter),
 	})
 
-	return value
+	args := make([]ir.Value, len(params))
+
+	for i, param := range params {
+		if param.Name_ != "this" {
+			args[i] = param
+			continue
+		}
+
+		// Interface value
+		result := block.Add(&ir.InsertValueInst{
+			Value:   &ir.ZeroInitConst{Typ: interTyp},
+			Element: v.get(type_, inter),
+			Indices: []uint32{0},
+		})
+
+		result = block.Add(&ir.InsertValueInst{
+			Value:   result,
+			Element: param,
+			Indices: []uint32{1},
+		})
+
+		block.Add(&ir.StoreInst{
+			Pointer: value,
+			Value:   result,
+			Align:   abi.GetTargetAbi().Align(inter),
+		})
+
+		args[i] = value
+	}
+
+	call := block.Add(&ir.CallInst{
+		Typ:    defaultFunc.Typ,
+		Callee: defaultFunc,
+		Args:   args,
+	})
+
+	if _, ok := defaultFunc.Typ.Returns.(*ir.VoidType); ok {
+		block.Add(&ir.RetInst{})
+	} else {
+		block.Add(&ir.RetInst{Value: call})
+	}
+
+	return thunk
 }
 
-func (v *vtables) getType(inter *ast.Interface) *ir.StructType {
+// getType returns the type of the vtable, containing the type descriptor, the methods and the vtables of the inherited
+// interfaces which are used to convert interface values to their parents.
+func (v *vtables) getType(inter ast.InterfaceType) *ir.StructType {
 	funcPtrArrayType := &ir.ArrayType{
-		Count: uint32(len(inter.Methods)),
+		Count: uint32(inter.MethodCount()),
+		Base:  &ir.PointerType{},
+	}
+
+	parentsArrayType := &ir.ArrayType{
+		Count: uint32(len(inter.Parents())),
 		Base:  &ir.PointerType{},
 	}
 
 	return &ir.StructType{
 		Name: "",
 		Fields: []ir.Type{
-			ir.I32,
+			&ir.PointerType{},
 			funcPtrArrayType,
+			parentsArrayType,
 		},
 	}
 }
 
-func getVtableName(type_, inter ast.Type) string {
-	sb := strings.Builder{}
-	sb.WriteString("__fb_vtable__")
+// getParentVtable loads the vtable of an inherited interface from a vtable, a nil vtable stays nil.
+func (c *codegen) getParentVtable(vtable ir.Value, inter ast.InterfaceType, index int) ir.Value {
+	null := c.block.Add(&ir.ICmpInst{
+		Kind:  ir.Eq,
+		Left:  vtable,
+		Right: ir.Null,
+	})
 
-	writeFullTypeName(&sb, type_)
-	sb.WriteString("__")
-	writeFullTypeName(&sb, inter)
+	start := c.block
+	load := c.function.Block("vtable.load")
+	end := c.function.Block("vtable.end")
 
-	return sb.String()
+	c.block.Add(&ir.BrInst{Condition: null, True: end, False: load})
+
+	// Load
+	c.beginBlock(load)
+
+	typ := c.vtables.getType(inter)
+
+	pointer := c.block.Add(&ir.GetElementPtrInst{
+		PointerTyp: &ir.PointerType{Pointee: typ},
+		Typ:        typ,
+		Pointer:    vtable,
+		Indices: []ir.Value{
+			&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(0)},
+			&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(2)},
+			&ir.IntConst{Typ: ir.I32, Value: ir.Unsigned(uint64(index))},
+		},
+		Inbounds: true,
+	})
+
+	parent := c.block.Add(&ir.LoadInst{
+		Typ:     &ir.PointerType{},
+		Pointer: pointer,
+	})
+
+	c.block.Add(&ir.BrInst{True: end})
+
+	// End
+	c.beginBlock(end)
+
+	return c.block.Add(&ir.PhiInst{Incs: []ir.Incoming{
+		{
+			Value: parent,
+			Label: load,
+		},
+		{
+			Value: ir.Null,
+			Label: start,
+		},
+	}})
 }
 
-func writeFullTypeName(sb *strings.Builder, type_ ast.Type) {
-	file := ast.GetParent[*ast.File](type_)
+// defaultMethodReceiver creates the interface value passed to a default method called on a pointer to a struct.
+func (c *codegen) defaultMethodReceiver(value exprValue, type_ ast.Type, inter *ast.Interface) exprValue {
+	if pointer, ok := ast.As[*ast.Pointer](type_); ok {
+		type_ = pointer.Pointee
+	}
+
+	result := c.block.Add(&ir.InsertValueInst{
+		Value:   &ir.ZeroInitConst{Typ: c.types.get(inter)},
+		Element: c.vtables.get(type_, inter),
+		Indices: []uint32{0},
+	})
+
+	result = c.block.Add(&ir.InsertValueInst{
+		Value:   result,
+		Element: value.v,
+		Indices: []uint32{1},
+	})
+
+	return c.toAddressable(exprValue{v: result}, inter)
+}
 
-	for i, part := range file.Namespace.Name.Parts {
-		if i > 0 {
-			sb.WriteRune('_')
+// getParentPath returns the indices of the inherited interfaces leading from an interface to one of its parents.
+func getParentPath(inter, parent ast.InterfaceType) []int {
+	for i, p := range inter.Parents() {
+		if p.Equals(parent) {
+			return []int{i}
 		}
 
-		sb.WriteString(part.String())
+		if ast.Inherits(p, parent) {
+			return append([]int{i}, getParentPath(p, parent)...)
+		}
 	}
 
-	sb.WriteRune('_')
+	return nil
+}
 
-	switch type_ := type_.Resolved().(type) {
-	case ast.StructType:
-		sb.WriteString(type_.Underlying().Name.String())
-	case *ast.Interface:
-		sb.WriteString(type_.Name.String())
+func getVtableName(type_, inter ast.Type) string {
+	sb := strings.Builder{}
+	sb.WriteString("__fb_vtable__")
 
-	default:
-		panic("codegen.vtables.getVtableName() - Not implemented")
-	}
+	writeTypeInfoName(&sb, type_)
+	sb.WriteString("__")
+	writeTypeInfoName(&sb, inter)
+
+	return sb.String()
 }
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"fireball/core/ast"
+	"fireball/core/builtin"
+	"fireball/core/scanner"
+)
+
+var builtinName = &ast.NamespaceName{
+	Parts: []*ast.Token{{Token_: scanner.Token{Kind: scanner.Identifier, Lexeme: builtin.Namespace}}},
+}
+
+// IsBuiltin returns true if the node is declared in the builtin namespace.
+func IsBuiltin(node ast.Node) bool {
+	file := ast.GetParent[*ast.File](node)
+	if file == nil || file.Namespace == nil || file.Namespace.Name == nil {
+		return false
+	}
+
+	parts := file.Namespace.Name.Parts
+	return len(parts) == 1 && parts[0].String() == builtin.Namespace
+}
+
+// GetBuiltinType returns a type declared in the builtin namespace.
+func GetBuiltinType(root ast.RootResolver, name string) ast.Type {
+	if resolver := root.GetResolver(builtinName); resolver != nil {
+		return resolver.GetType(name)
+	}
+
+	return nil
+}
+
+// GetBuiltinFunction returns a function declared in the builtin namespace.
+func GetBuiltinFunction(root ast.RootResolver, name string) *ast.Func {
+	if resolver := root.GetResolver(builtinName); resolver != nil {
+		return resolver.GetFunction(name)
+	}
+
+	return nil
+}
+
+// GetTypeInfo returns the type of type descriptors, a pointer to the builtin 'TypeInfo' struct.
+func GetTypeInfo(root ast.RootResolver) ast.Type {
+	if s := GetBuiltinType(root, "TypeInfo"); s != nil {
+		return &ast.Pointer{Pointee: s}
+	}
+
+	return &ast.Pointer{Pointee: &ast.Primitive{Kind: ast.Void}}
+}
@@ -17,6 +17,11 @@ const (
 	Float2Int
 
 	Pointer2Interface
+	Pointer2Func
+
+	Interface2Pointer
+	Interface2Interface
+	Interface2Parent
 )
 
 func GetCast(from, to ast.Type) (CastKind, bool) {
@@ -49,7 +54,7 @@ func GetCast(from, to ast.Type) (CastKind, bool) {
 
 		// Primitive (Integer) -> Enum
 		case *ast.Enum:
-			if ast.IsInteger(from.Kind) {
+			if ast.IsInteger(from.Kind) && !to.IsTagged() {
 				fromSize := abi.GetTargetAbi().Size(from)
 				toSize := abi.GetTargetAbi().Size(to)
 
@@ -66,18 +71,37 @@ func GetCast(from, to ast.Type) (CastKind, bool) {
 	// Pointer -> Interface, Pointer, Func
 	case *ast.Pointer:
 		switch to := to.Resolved().(type) {
-		case *ast.Interface:
+		case ast.InterfaceType:
 			if implements(from, to) {
 				return Pointer2Interface, true
 			}
 
-		case *ast.Pointer, *ast.Func:
+		case *ast.Pointer:
 			return None, true
+
+		case *ast.Func:
+			return Pointer2Func, true
+		}
+
+	// Interface -> Pointer, Interface
+	case ast.InterfaceType:
+		switch to := to.Resolved().(type) {
+		case *ast.Pointer:
+			if implements(to, from) {
+				return Interface2Pointer, true
+			}
+
+		case ast.InterfaceType:
+			if ast.Inherits(from, to) {
+				return Interface2Parent, true
+			}
+
+			return Interface2Interface, true
 		}
 
 	// Enum -> Primitive (Integer)
 	case *ast.Enum:
-		if to, ok := ast.As[*ast.Primitive](to); ok && ast.IsInteger(to.Kind) {
+		if to, ok := ast.As[*ast.Primitive](to); ok && ast.IsInteger(to.Kind) && !from.IsTagged() {
 			fromSize := abi.GetTargetAbi().Size(from)
 			toSize := abi.GetTargetAbi().Size(to)
 
@@ -120,7 +144,7 @@ func GetImplicitCast(from, to ast.Type) (CastKind, bool) {
 	// Pointer -> Interface, Pointer (*void)
 	case *ast.Pointer:
 		switch to := to.Resolved().(type) {
-		case *ast.Interface:
+		case ast.InterfaceType:
 			if implements(from, to) {
 				return Pointer2Interface, true
 			}
@@ -130,12 +154,18 @@ func GetImplicitCast(from, to ast.Type) (CastKind, bool) {
 				return None, true
 			}
 		}
+
+	// Interface -> Interface (inherited)
+	case ast.InterfaceType:
+		if to, ok := ast.As[ast.InterfaceType](to); ok && ast.Inherits(from, to) {
+			return Interface2Parent, true
+		}
 	}
 
 	return None, false
 }
 
-func implements(type_ ast.Type, inter *ast.Interface) bool {
+func implements(type_ ast.Type, inter ast.InterfaceType) bool {
 	// Check struct pointee
 	if pointer, ok := type_.(*ast.Pointer); ok {
 		if s, ok := ast.As[*ast.Struct](pointer.Pointee); ok {
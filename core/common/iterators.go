@@ -0,0 +1,32 @@
+package common
+
+import "fireball/core/ast"
+
+// GetIteratorMethod returns the 'next' method that a for-in loop calls to advance an iterator. The struct needs to
+// implement an interface named 'Iterator', or a specialization of it, that is visible from the file the struct is declared in.
+func GetIteratorMethod(type_ ast.Type) *ast.Func {
+	s, ok := ast.As[*ast.Struct](type_)
+	if !ok {
+		return nil
+	}
+
+	resolver := ast.GetParent[*ast.File](s).Resolver
+
+	inter, ok := ast.As[*ast.Interface](resolver.GetType("Iterator"))
+	if !ok {
+		return nil
+	}
+
+	impl := resolver.GetImpl(s, inter)
+
+	// Generic iterators are implemented through one of their specializations
+	for i := 0; impl == nil && i < len(inter.Specializations); i++ {
+		impl = resolver.GetImpl(s, inter.Specializations[i])
+	}
+
+	if impl == nil {
+		return nil
+	}
+
+	return impl.GetMethod("next", false)
+}
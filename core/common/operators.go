@@ -0,0 +1,41 @@
+package common
+
+import "fireball/core/scanner"
+
+// IndexMethod is the name of the method that structs declare to support the index operator.
+const IndexMethod = "index"
+
+// GetOperatorMethodName returns the name of the method that a struct needs to declare to support the operator.
+// Binary operator methods take the right operand as their only parameter, the comparison operators are all
+// implemented by a single 'compare' method returning a signed integer.
+func GetOperatorMethodName(operator scanner.TokenKind, unary bool) string {
+	if unary {
+		if operator == scanner.Minus {
+			return "neg"
+		}
+
+		return ""
+	}
+
+	switch operator {
+	case scanner.Plus, scanner.PlusEqual:
+		return "add"
+	case scanner.Minus, scanner.MinusEqual:
+		return "sub"
+	case scanner.Star, scanner.StarEqual:
+		return "mul"
+	case scanner.Slash, scanner.SlashEqual:
+		return "div"
+	case scanner.Percentage, scanner.PercentageEqual:
+		return "mod"
+
+	case scanner.EqualEqual, scanner.BangEqual:
+		return "eq"
+
+	case scanner.Less, scanner.LessEqual, scanner.Greater, scanner.GreaterEqual:
+		return "compare"
+
+	default:
+		return ""
+	}
+}
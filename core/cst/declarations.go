@@ -12,8 +12,10 @@ var canStartDecl = []scanner.TokenKind{
 	scanner.Interface,
 	scanner.Func,
 	scanner.Var,
+	scanner.Const,
 
 	scanner.Hashtag,
+	scanner.Pub,
 }
 
 func parseDecl(p *parser) Node {
@@ -26,7 +28,13 @@ func parseDecl(p *parser) Node {
 		}
 	}
 
-	switch p.peek() {
+	// The visibility is parsed by the declaration itself
+	kind := p.peek()
+	if kind == scanner.Pub {
+		kind = p.peek2()
+	}
+
+	switch kind {
 	case scanner.Namespace:
 		return parseNamespaceDecl(p, attributes)
 	case scanner.Using:
@@ -44,6 +52,8 @@ func parseDecl(p *parser) Node {
 		return parseFuncDecl(p, attributes)
 	case scanner.Var:
 		return parseVarDecl(p, attributes)
+	case scanner.Const:
+		return parseConstDecl(p, attributes)
 
 	default:
 		return p.error("Cannot start a declaration")
@@ -56,6 +66,7 @@ func parseNamespaceDecl(p *parser, attributes Node) Node {
 	p.begin(NamespaceDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Namespace) {
 		return p.end()
 	}
@@ -73,6 +84,7 @@ func parseUsingDecl(p *parser, attributes Node) Node {
 	p.begin(UsingDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Using) {
 		return p.end()
 	}
@@ -116,6 +128,7 @@ func parseStructDecl(p *parser, attributes Node) Node {
 	p.begin(StructDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Struct) {
 		return p.end()
 	}
@@ -135,7 +148,7 @@ func parseStructDecl(p *parser, attributes Node) Node {
 	if p.consume(scanner.LeftBrace) {
 		return p.end()
 	}
-	if p.repeatSync(parseStructField, scanner.RightBrace, scanner.Static, scanner.Identifier) {
+	if p.repeatSync(parseStructMember, scanner.RightBrace, scanner.Hashtag, scanner.Pub, scanner.Static, scanner.Const, scanner.Identifier) {
 		return p.end()
 	}
 	if p.consume(scanner.RightBrace) {
@@ -145,9 +158,28 @@ func parseStructDecl(p *parser, attributes Node) Node {
 	return p.end()
 }
 
-func parseStructField(p *parser) Node {
+func parseStructMember(p *parser) Node {
+	if p.peek() == scanner.Const || (p.peek() == scanner.Pub && p.peek2() == scanner.Const) {
+		return parseConstDecl(p, Node{})
+	}
+
+	var attributes Node
+
+	if p.peek() == scanner.Hashtag {
+		attributes = parseAttributes(p)
+		if p.recovering() {
+			return Node{}
+		}
+	}
+
+	return parseStructField(p, attributes)
+}
+
+func parseStructField(p *parser, attributes Node) Node {
 	p.begin(StructFieldNode)
 
+	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	p.optional(scanner.Static)
 	if p.consume(scanner.Identifier) {
 		return p.end()
@@ -155,6 +187,11 @@ func parseStructField(p *parser) Node {
 	if p.child(parseType) {
 		return p.end()
 	}
+	if p.optional(scanner.Equal) {
+		if p.child(parseExpr) {
+			return p.end()
+		}
+	}
 	if p.consume(scanner.Comma) {
 		return p.end()
 	}
@@ -168,6 +205,7 @@ func parseImplDecl(p *parser, attributes Node) Node {
 	p.begin(ImplDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Impl) {
 		return p.end()
 	}
@@ -182,7 +220,7 @@ func parseImplDecl(p *parser, attributes Node) Node {
 	if p.consume(scanner.LeftBrace) {
 		return p.end()
 	}
-	if p.repeatSync(parseFuncDeclWithAttributes, scanner.RightBrace, scanner.Hashtag, scanner.Static, scanner.Func) {
+	if p.repeatSync(parseFuncDeclWithAttributes, scanner.RightBrace, scanner.Hashtag, scanner.Pub, scanner.Static, scanner.Func) {
 		return p.end()
 	}
 	if p.consume(scanner.RightBrace) {
@@ -198,6 +236,7 @@ func parseEnumDecl(p *parser, attributes Node) Node {
 	p.begin(EnumDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Enum) {
 		return p.end()
 	}
@@ -228,8 +267,16 @@ func parseEnumCase(p *parser) Node {
 	if p.consume(scanner.Identifier) {
 		return p.end()
 	}
+	if p.optional(scanner.LeftParen) {
+		if p.repeatSeparated(parseEnumCaseField, canStartEnumCaseField, scanner.Comma) {
+			return p.end()
+		}
+		if p.consume(scanner.RightParen) {
+			return p.end()
+		}
+	}
 	if p.optional(scanner.Equal) {
-		if p.consume(scanner.Number, scanner.Hex, scanner.Binary) {
+		if p.child(parseExpr) {
 			return p.end()
 		}
 	}
@@ -240,22 +287,54 @@ func parseEnumCase(p *parser) Node {
 	return p.end()
 }
 
+var canStartEnumCaseField = []scanner.TokenKind{scanner.Identifier}
+
+func parseEnumCaseField(p *parser) Node {
+	p.begin(EnumCaseFieldNode)
+
+	if p.consume(scanner.Identifier) {
+		return p.end()
+	}
+	if p.child(parseType) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
 // Interface
 
 func parseInterfaceDecl(p *parser, attributes Node) Node {
 	p.begin(InterfaceDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Interface) {
 		return p.end()
 	}
 	if p.consume(scanner.Identifier) {
 		return p.end()
 	}
+
+	if p.optional(scanner.LeftBracket) {
+		if p.repeatSeparated(parseGenericParam, canStartGenericParam, scanner.Comma) {
+			return p.end()
+		}
+		if p.consume(scanner.RightBracket) {
+			return p.end()
+		}
+	}
+
+	if p.optional(scanner.Colon) {
+		if p.repeatSeparated(parseType, canStartType, scanner.Comma) {
+			return p.end()
+		}
+	}
+
 	if p.consume(scanner.LeftBrace) {
 		return p.end()
 	}
-	if p.repeatSync(parseFuncDeclWithAttributes, scanner.RightBrace, scanner.Hashtag, scanner.Func) {
+	if p.repeatSync(parseFuncDeclWithAttributes, scanner.RightBrace, scanner.Hashtag, scanner.Pub, scanner.Func) {
 		return p.end()
 	}
 	if p.consume(scanner.RightBrace) {
@@ -286,6 +365,7 @@ func parseFuncDecl(p *parser, attributes Node) Node {
 	p.begin(FuncDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	p.optional(scanner.Static)
 	if p.consume(scanner.Func) {
 		return p.end()
@@ -342,6 +422,11 @@ func parseFuncParam(p *parser) Node {
 	if p.child(parseType) {
 		return p.end()
 	}
+	if p.optional(scanner.Equal) {
+		if p.child(parseExpr) {
+			return p.end()
+		}
+	}
 
 	return p.end()
 }
@@ -352,6 +437,7 @@ func parseVarDecl(p *parser, attributes Node) Node {
 	p.begin(VarDeclNode)
 
 	p.childAdd(attributes)
+	p.optional(scanner.Pub)
 	if p.consume(scanner.Var) {
 		return p.end()
 	}
@@ -361,6 +447,40 @@ func parseVarDecl(p *parser, attributes Node) Node {
 	if p.child(parseType) {
 		return p.end()
 	}
+	if p.optional(scanner.Equal) {
+		if p.child(parseExpr) {
+			return p.end()
+		}
+	}
+	if p.consume(scanner.Semicolon) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+// Const
+
+func parseConstDecl(p *parser, attributes Node) Node {
+	p.begin(ConstDeclNode)
+
+	p.childAdd(attributes)
+	p.optional(scanner.Pub)
+	if p.consume(scanner.Const) {
+		return p.end()
+	}
+	if p.consume(scanner.Identifier) {
+		return p.end()
+	}
+	if p.child(parseType) {
+		return p.end()
+	}
+	if p.consume(scanner.Equal) {
+		return p.end()
+	}
+	if p.child(parseExpr) {
+		return p.end()
+	}
 	if p.consume(scanner.Semicolon) {
 		return p.end()
 	}
@@ -371,7 +491,7 @@ func parseVarDecl(p *parser, attributes Node) Node {
 // Attribute
 
 var canStartAttribute = []scanner.TokenKind{scanner.Identifier}
-var canStartAttributeArg = []scanner.TokenKind{scanner.String}
+var canStartAttributeArg = []scanner.TokenKind{scanner.String, scanner.Number}
 
 func parseAttributes(p *parser) Node {
 	p.begin(AttributesNode)
@@ -411,11 +531,11 @@ func parseAttribute(p *parser) Node {
 }
 
 func parseAttributeArg(p *parser) Node {
-	if p.peek() == scanner.String {
+	if p.peek() == scanner.String || p.peek() == scanner.Number {
 		return p.advanceGetLeaf()
 	}
 
-	return p.error("Attribute argument needs to be a string")
+	return p.error("Attribute argument needs to be a string or a number")
 }
 
 // Generics
@@ -425,7 +545,14 @@ var canStartGenericParam = []scanner.TokenKind{scanner.Identifier}
 func parseGenericParam(p *parser) Node {
 	p.begin(GenericParamNode)
 
-	p.consume(scanner.Identifier)
+	if p.consume(scanner.Identifier) {
+		return p.end()
+	}
+	if p.optional(scanner.Colon) {
+		if p.child(parseType) {
+			return p.end()
+		}
+	}
 
 	return p.end()
 }
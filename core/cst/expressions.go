@@ -17,6 +17,7 @@ var canStartExpr = []scanner.TokenKind{
 
 	scanner.LeftParen,
 	scanner.LeftBracket,
+	scanner.Fn,
 }
 
 var infixAndPostfixOperators []scanner.TokenKind
@@ -162,6 +163,9 @@ func parsePrefixExprPratt(p *parser) Node {
 
 		return p.end()
 
+	case scanner.Fn:
+		return parseClosureExpr(p)
+
 	default:
 		rightPower := prefixExprPower(p.peek())
 
@@ -178,6 +182,117 @@ func parsePrefixExprPratt(p *parser) Node {
 	}
 }
 
+var canStartClosureCapture = []scanner.TokenKind{scanner.Ampersand, scanner.Identifier}
+
+func parseClosureExpr(p *parser) Node {
+	p.begin(ClosureExprNode)
+
+	if p.consume(scanner.Fn) {
+		return p.end()
+	}
+
+	if p.optional(scanner.LeftBracket) {
+		if p.repeatSeparated(parseClosureCapture, canStartClosureCapture, scanner.Comma) {
+			return p.end()
+		}
+		if p.consume(scanner.RightBracket) {
+			return p.end()
+		}
+	}
+
+	if p.consume(scanner.LeftParen) {
+		return p.end()
+	}
+	if p.repeatSeparated(parseFuncParam, canStartParam, scanner.Comma) {
+		return p.end()
+	}
+	if p.consume(scanner.RightParen) {
+		return p.end()
+	}
+	if p.peekIs(canStartType) {
+		if p.child(parseType) {
+			return p.end()
+		}
+	}
+	if p.consume(scanner.LeftBrace) {
+		return p.end()
+	}
+	if p.repeatSync(parseStmt, scanner.RightBrace, canStartStmt...) {
+		return p.end()
+	}
+	if p.consume(scanner.RightBrace) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseClosureCapture(p *parser) Node {
+	p.begin(ClosureCaptureNode)
+
+	p.optional(scanner.Ampersand)
+	if p.consume(scanner.Identifier) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+var canStartAsmOperand = []scanner.TokenKind{scanner.String}
+
+// parseAsmExpr parses the template followed by up to three sections separated by colons: outputs, inputs and clobbers.
+func parseAsmExpr(p *parser, lhs Node) Node {
+	p.begin(AsmExprNode)
+
+	p.childAdd(lhs)
+	p.advanceAddChild()
+	if p.consume(scanner.String) {
+		return p.end()
+	}
+
+	for _, parseFn := range []func(p *parser) Node{parseAsmOperand, parseAsmOperand, parseAsmClobber} {
+		if !p.optional(scanner.Colon) {
+			break
+		}
+		if p.repeatSeparated(parseFn, canStartAsmOperand, scanner.Comma) {
+			return p.end()
+		}
+	}
+
+	if p.consume(scanner.RightParen) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseAsmOperand(p *parser) Node {
+	p.begin(AsmOperandNode)
+
+	if p.consume(scanner.String) {
+		return p.end()
+	}
+	if p.consume(scanner.LeftParen) {
+		return p.end()
+	}
+	if p.child(parseExpr) {
+		return p.end()
+	}
+	if p.consume(scanner.RightParen) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseAsmClobber(p *parser) Node {
+	if p.peek() == scanner.String {
+		return p.advanceGetLeaf()
+	}
+
+	return p.error("Clobber needs to be a string")
+}
+
 func parseStructFieldExpr(p *parser) Node {
 	p.begin(StructFieldExprNode)
 
@@ -194,6 +309,26 @@ func parseStructFieldExpr(p *parser) Node {
 	return p.end()
 }
 
+func parseCallArg(p *parser) Node {
+	if p.peek() != scanner.Identifier || p.peek2() != scanner.Colon {
+		return parseExpr(p)
+	}
+
+	p.begin(NamedArgExprNode)
+
+	if p.consume(scanner.Identifier) {
+		return p.end()
+	}
+	if p.consume(scanner.Colon) {
+		return p.end()
+	}
+	if p.child(parseExpr) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
 func parseInfixExprPratt(p *parser, op scanner.TokenKind, lhs Node, rightPower int) Node {
 	switch op {
 	case scanner.As, scanner.Is:
@@ -244,8 +379,17 @@ func parsePostfixExprPratt(p *parser, op scanner.TokenKind, lhs Node) Node {
 
 		p.childAdd(lhs)
 		p.advanceAddChild()
-		if p.childAdd(parseExprPratt(p, 0)) {
-			return p.end()
+		if p.peek() != scanner.Colon {
+			if p.childAdd(parseExprPratt(p, 0)) {
+				return p.end()
+			}
+		}
+		if p.optional(scanner.Colon) {
+			if p.peek() != scanner.RightBracket {
+				if p.childAdd(parseExprPratt(p, 0)) {
+					return p.end()
+				}
+			}
 		}
 		if p.consume(scanner.RightBracket) {
 			return p.end()
@@ -257,7 +401,7 @@ func parsePostfixExprPratt(p *parser, op scanner.TokenKind, lhs Node) Node {
 		lhsLexeme := identifierExprOrTokenLexeme(lhs)
 
 		// Type call
-		if lhsLexeme == "sizeof" || lhsLexeme == "alignof" {
+		if lhsLexeme == "sizeof" || lhsLexeme == "alignof" || lhsLexeme == "countof" || lhsLexeme == "casesof" {
 			p.begin(TypeCallExprNode)
 
 			p.childAdd(lhs)
@@ -273,7 +417,7 @@ func parsePostfixExprPratt(p *parser, op scanner.TokenKind, lhs Node) Node {
 		}
 
 		// Typeof
-		if lhsLexeme == "typeof" {
+		if lhsLexeme == "typeof" || lhsLexeme == "dyntypeof" || lhsLexeme == "nameof" {
 			p.begin(TypeofExprNode)
 
 			p.childAdd(lhs)
@@ -288,12 +432,17 @@ func parsePostfixExprPratt(p *parser, op scanner.TokenKind, lhs Node) Node {
 			return p.end()
 		}
 
+		// Inline assembly
+		if lhsLexeme == "asm" {
+			return parseAsmExpr(p, lhs)
+		}
+
 		// Call
 		p.begin(CallExprNode)
 
 		p.childAdd(lhs)
 		p.advanceAddChild()
-		if p.repeatSeparated(parseExpr, canStartExpr, scanner.Comma) {
+		if p.repeatSeparated(parseCallArg, canStartExpr, scanner.Comma) {
 			return p.end()
 		}
 		if p.consume(scanner.RightParen) {
@@ -423,8 +572,8 @@ func init() {
 	infix(false, scanner.Plus, scanner.Minus)
 	// *, /, %
 	infix(false, scanner.Star, scanner.Slash, scanner.Percentage)
-	// -x, !x, ++x, --x, &x, *x, => x
-	prefix(scanner.Minus, scanner.Bang, scanner.PlusPlus, scanner.MinusMinus, scanner.Ampersand, scanner.Star, scanner.FuncPtr)
+	// -x, !x, ~x, ++x, --x, &x, *x, => x
+	prefix(scanner.Minus, scanner.Bang, scanner.Tilde, scanner.PlusPlus, scanner.MinusMinus, scanner.Ampersand, scanner.Star, scanner.FuncPtr)
 	// x++, x--
 	postfix(scanner.PlusPlus, scanner.MinusMinus)
 	// x[], x(), x {}
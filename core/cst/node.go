@@ -71,6 +71,7 @@ const (
 	IdentifierTypeNode
 	PointerTypeNode
 	ArrayTypeNode
+	SliceTypeNode
 	FuncTypeNode
 	FuncTypeParamNode
 
@@ -83,10 +84,12 @@ const (
 	ImplDeclNode
 	EnumDeclNode
 	EnumCaseNode
+	EnumCaseFieldNode
 	InterfaceDeclNode
 	FuncDeclNode
 	FuncParamNode
 	VarDeclNode
+	ConstDeclNode
 
 	ExprStmtNode
 	BlockStmtNode
@@ -94,9 +97,14 @@ const (
 	IfStmtNode
 	WhileStmtNode
 	ForStmtNode
+	MatchStmtNode
+	DeferStmtNode
 	ReturnStmtNode
 	BreakStmtNode
 	ContinueStmtNode
+	MatchArmNode
+	ClosureCaptureNode
+	AsmOperandNode
 
 	ParenExprNode
 	IdentifierExprNode
@@ -104,12 +112,15 @@ const (
 	BinaryExprNode
 	IndexExprNode
 	CallExprNode
+	NamedArgExprNode
 	TypeCallExprNode
 	TypeofExprNode
+	AsmExprNode
 	StructExprNode
 	StructFieldExprNode
 	ArrayExprNode
 	AllocateArrayExprNode
+	ClosureExprNode
 	NilExprNode
 	BoolExprNode
 	NumberExprNode
@@ -159,7 +170,7 @@ func (n NodeKind) IsType() bool {
 
 func (n NodeKind) IsDecl() bool {
 	switch n {
-	case NamespaceDeclNode, UsingDeclNode, StructDeclNode, ImplDeclNode, EnumDeclNode, InterfaceDeclNode, FuncDeclNode, VarDeclNode:
+	case NamespaceDeclNode, UsingDeclNode, StructDeclNode, ImplDeclNode, EnumDeclNode, InterfaceDeclNode, FuncDeclNode, VarDeclNode, ConstDeclNode:
 		return true
 
 	default:
@@ -188,6 +199,8 @@ func (n NodeKind) String() string {
 		return "Pointer type"
 	case ArrayTypeNode:
 		return "Array type"
+	case SliceTypeNode:
+		return "Slice type"
 	case FuncTypeNode:
 		return "Function type"
 	case FuncTypeParamNode:
@@ -211,6 +224,8 @@ func (n NodeKind) String() string {
 		return "Enum"
 	case EnumCaseNode:
 		return "Enum case"
+	case EnumCaseFieldNode:
+		return "Enum case field"
 	case InterfaceDeclNode:
 		return "Interface"
 	case FuncDeclNode:
@@ -219,6 +234,8 @@ func (n NodeKind) String() string {
 		return "Func param"
 	case VarDeclNode:
 		return "Var"
+	case ConstDeclNode:
+		return "Const"
 
 	case ExprStmtNode:
 		return "Expression;"
@@ -232,12 +249,22 @@ func (n NodeKind) String() string {
 		return "if"
 	case ForStmtNode:
 		return "For"
+	case MatchStmtNode:
+		return "Match"
+	case DeferStmtNode:
+		return "Defer"
 	case ReturnStmtNode:
 		return "Return"
 	case BreakStmtNode:
 		return "Break"
 	case ContinueStmtNode:
 		return "Continue"
+	case MatchArmNode:
+		return "Match arm"
+	case ClosureCaptureNode:
+		return "Closure capture"
+	case AsmOperandNode:
+		return "Asm operand"
 
 	case ParenExprNode:
 		return "Paren"
@@ -251,10 +278,14 @@ func (n NodeKind) String() string {
 		return "Index"
 	case CallExprNode:
 		return "Call"
+	case NamedArgExprNode:
+		return "Named argument"
 	case TypeCallExprNode:
 		return "Type call"
 	case TypeofExprNode:
 		return "Typeof"
+	case AsmExprNode:
+		return "Asm"
 	case StructExprNode:
 		return "Struct"
 	case StructFieldExprNode:
@@ -263,6 +294,8 @@ func (n NodeKind) String() string {
 		return "Array"
 	case AllocateArrayExprNode:
 		return "Allocate array"
+	case ClosureExprNode:
+		return "Closure"
 	case NilExprNode:
 		return "Nil"
 	case BoolExprNode:
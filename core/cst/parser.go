@@ -238,6 +238,14 @@ func (p *parser) peek2Is(kinds []scanner.TokenKind) bool {
 	return slices.Contains(kinds, p.peek2())
 }
 
+func (p *parser) peek3() scanner.TokenKind {
+	p.comments()
+
+	// Scan the next token on a copy so the scanner state is left untouched
+	s := *p.scanner
+	return s.Next().Kind
+}
+
 func (p *parser) advance() {
 	p.previous = p.next
 	p.next = p.next2
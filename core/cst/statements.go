@@ -8,16 +8,33 @@ var canStartStmt = []scanner.TokenKind{
 	scanner.If,
 	scanner.While,
 	scanner.For,
+	scanner.Match,
+	scanner.Defer,
 	scanner.Return,
 	scanner.Break,
 	scanner.Continue,
 }
 
+var canStartMatchArm = []scanner.TokenKind{
+	scanner.Else,
+}
+
 func init() {
 	canStartStmt = append(canStartStmt, canStartExpr...)
+	canStartMatchArm = append(canStartMatchArm, canStartExpr...)
 }
 
 func parseStmt(p *parser) Node {
+	// Labeled loop
+	if p.peek() == scanner.Identifier && p.peek2() == scanner.Colon {
+		switch p.peek3() {
+		case scanner.While:
+			return parseWhileStmt(p)
+		case scanner.For:
+			return parseForStmt(p)
+		}
+	}
+
 	if p.peekIs(canStartExpr) {
 		return parseExprStmt(p)
 	}
@@ -33,6 +50,10 @@ func parseStmt(p *parser) Node {
 		return parseWhileStmt(p)
 	case scanner.For:
 		return parseForStmt(p)
+	case scanner.Match:
+		return parseMatchStmt(p)
+	case scanner.Defer:
+		return parseDeferStmt(p)
 	case scanner.Return:
 		return parseReturnStmt(p)
 	case scanner.Break:
@@ -130,6 +151,8 @@ func parseIfStmt(p *parser) Node {
 func parseWhileStmt(p *parser) Node {
 	p.begin(WhileStmtNode)
 
+	parseLabel(p)
+
 	if p.consume(scanner.While) {
 		return p.end()
 	}
@@ -152,6 +175,8 @@ func parseWhileStmt(p *parser) Node {
 func parseForStmt(p *parser) Node {
 	p.begin(ForStmtNode)
 
+	parseLabel(p)
+
 	if p.consume(scanner.For) {
 		return p.end()
 	}
@@ -159,6 +184,24 @@ func parseForStmt(p *parser) Node {
 		return p.end()
 	}
 
+	// For-in
+	if p.peek() == scanner.Identifier && p.peek2() == scanner.In {
+		p.advanceAddChild()
+		p.advanceAddChild()
+
+		if p.child(parseExpr) {
+			return p.end()
+		}
+		if p.consume(scanner.RightParen) {
+			return p.end()
+		}
+		if p.child(parseStmt) {
+			return p.end()
+		}
+
+		return p.end()
+	}
+
 	if p.peekIs(canStartStmt) {
 		if p.child(parseStmt) {
 			return p.end()
@@ -194,6 +237,79 @@ func parseForStmt(p *parser) Node {
 	return p.end()
 }
 
+func parseLabel(p *parser) {
+	if p.peek() == scanner.Identifier && p.peek2() == scanner.Colon {
+		p.advanceAddChild()
+		p.advanceAddChild()
+	}
+}
+
+func parseMatchStmt(p *parser) Node {
+	p.begin(MatchStmtNode)
+
+	if p.consume(scanner.Match) {
+		return p.end()
+	}
+	if p.consume(scanner.LeftParen) {
+		return p.end()
+	}
+	if p.child(parseExpr) {
+		return p.end()
+	}
+	if p.consume(scanner.RightParen) {
+		return p.end()
+	}
+	if p.consume(scanner.LeftBrace) {
+		return p.end()
+	}
+	if p.repeatSync(parseMatchArm, scanner.RightBrace, canStartMatchArm...) {
+		return p.end()
+	}
+	if p.consume(scanner.RightBrace) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseMatchArm(p *parser) Node {
+	p.begin(MatchArmNode)
+
+	if !p.optional(scanner.Else) {
+		if p.child(parseExpr) {
+			return p.end()
+		}
+
+		for p.optional(scanner.Comma) {
+			if p.child(parseExpr) {
+				return p.end()
+			}
+		}
+	}
+
+	if p.consume(scanner.FuncPtr) {
+		return p.end()
+	}
+	if p.child(parseStmt) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseDeferStmt(p *parser) Node {
+	p.begin(DeferStmtNode)
+
+	if p.consume(scanner.Defer) {
+		return p.end()
+	}
+	if p.child(parseStmt) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
 func parseReturnStmt(p *parser) Node {
 	p.begin(ReturnStmtNode)
 
@@ -218,6 +334,9 @@ func parseBreakStmt(p *parser) Node {
 	if p.consume(scanner.Break) {
 		return p.end()
 	}
+	if p.peek() == scanner.Identifier {
+		p.advanceAddChild()
+	}
 	if p.consume(scanner.Semicolon) {
 		return p.end()
 	}
@@ -231,6 +350,9 @@ func parseContinueStmt(p *parser) Node {
 	if p.consume(scanner.Continue) {
 		return p.end()
 	}
+	if p.peek() == scanner.Identifier {
+		p.advanceAddChild()
+	}
 	if p.consume(scanner.Semicolon) {
 		return p.end()
 	}
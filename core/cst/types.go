@@ -16,6 +16,10 @@ func parseType(p *parser) Node {
 	case scanner.Star:
 		return parsePointerType(p)
 	case scanner.LeftBracket:
+		if p.peek2() == scanner.RightBracket {
+			return parseSliceType(p)
+		}
+
 		return parseArrayType(p)
 	case scanner.Fn:
 		return parseFuncType(p)
@@ -71,7 +75,23 @@ func parseArrayType(p *parser) Node {
 	if p.consume(scanner.LeftBracket) {
 		return p.end()
 	}
-	if p.consume(scanner.Number) {
+	if p.child(parseExpr) {
+		return p.end()
+	}
+	if p.consume(scanner.RightBracket) {
+		return p.end()
+	}
+	if p.child(parseType) {
+		return p.end()
+	}
+
+	return p.end()
+}
+
+func parseSliceType(p *parser) Node {
+	p.begin(SliceTypeNode)
+
+	if p.consume(scanner.LeftBracket) {
 		return p.end()
 	}
 	if p.consume(scanner.RightBracket) {
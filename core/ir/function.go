@@ -11,8 +11,9 @@ type Func struct {
 	name string
 	Typ  *FuncType
 
-	Flags FuncFlags
-	meta  MetaID
+	Flags   FuncFlags
+	Linkage Linkage
+	meta    MetaID
 
 	Blocks []*Block
 }
@@ -1,5 +1,20 @@
 package ir
 
+type Linkage uint8
+
+const (
+	DefaultLinkage Linkage = iota
+
+	// LinkOnceODRLinkage merges globals with the same name from different modules into a single one
+	LinkOnceODRLinkage
+
+	// InternalLinkage makes the global only visible to the module defining it
+	InternalLinkage
+
+	// AppendingLinkage concatenates arrays with the same name from different modules, used by llvm.global_ctors
+	AppendingLinkage
+)
+
 type GlobalVar struct {
 	name    string
 	Typ     Type
@@ -7,6 +22,10 @@ type GlobalVar struct {
 
 	Value    Value
 	Constant bool
+	Linkage  Linkage
+
+	// ThreadLocal gives every thread its own copy of the variable
+	ThreadLocal bool
 
 	meta MetaID
 }
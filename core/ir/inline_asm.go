@@ -0,0 +1,23 @@
+package ir
+
+// InlineAsm is a block of assembly used as the callee of a call instruction. The outputs of the assembly are the
+// return value of its function type and the inputs are its parameters.
+type InlineAsm struct {
+	Typ *FuncType
+
+	Template    string
+	Constraints string
+
+	// SideEffect prevents the assembly from being removed or moved even if its outputs are not used
+	SideEffect bool
+}
+
+// Value
+
+func (i *InlineAsm) Type() Type {
+	return i.Typ
+}
+
+func (i *InlineAsm) Name() string {
+	return ""
+}
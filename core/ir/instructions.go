@@ -52,6 +52,35 @@ func (b *BrInst) Type() Type {
 	return nil
 }
 
+// Switch
+
+type SwitchCase struct {
+	Value Value
+	Label Value
+}
+
+type SwitchInst struct {
+	baseInst
+
+	Value   Value
+	Default Value
+	Cases   []SwitchCase
+}
+
+func (s *SwitchInst) Type() Type {
+	return nil
+}
+
+// Unreachable
+
+type UnreachableInst struct {
+	baseInst
+}
+
+func (u *UnreachableInst) Type() Type {
+	return nil
+}
+
 // FNeg
 
 type FNegInst struct {
@@ -271,6 +300,19 @@ func (a *AllocaInst) Type() Type {
 	return &a.TypPtr
 }
 
+// Atomic Ordering
+
+type AtomicOrdering uint8
+
+const (
+	NotAtomic AtomicOrdering = iota
+	Monotonic
+	Acquire
+	Release
+	AcquireRelease
+	SequentiallyConsistent
+)
+
 // Load
 
 type LoadInst struct {
@@ -279,6 +321,8 @@ type LoadInst struct {
 	Typ     Type
 	Pointer Value
 	Align   uint32
+
+	Ordering AtomicOrdering
 }
 
 func (l *LoadInst) Type() Type {
@@ -293,12 +337,79 @@ type StoreInst struct {
 	Pointer Value
 	Value   Value
 	Align   uint32
+
+	Ordering AtomicOrdering
 }
 
 func (s *StoreInst) Type() Type {
 	return nil
 }
 
+// Atomic RMW
+
+type AtomicRMWKind uint8
+
+const (
+	XchgRMW AtomicRMWKind = iota
+	AddRMW
+	SubRMW
+	AndRMW
+	OrRMW
+	XorRMW
+)
+
+type AtomicRMWInst struct {
+	baseInst
+
+	Kind AtomicRMWKind
+
+	Pointer Value
+	Value   Value
+	Align   uint32
+
+	Ordering AtomicOrdering
+}
+
+func (a *AtomicRMWInst) Type() Type {
+	return a.Value.Type()
+}
+
+// CmpXchg
+
+type CmpXchgInst struct {
+	baseInst
+
+	Pointer  Value
+	Expected Value
+	Desired  Value
+	Align    uint32
+
+	SuccessOrdering AtomicOrdering
+	FailureOrdering AtomicOrdering
+
+	typ StructType
+}
+
+func (c *CmpXchgInst) Type() Type {
+	if c.typ.Fields == nil {
+		c.typ.Fields = []Type{c.Expected.Type(), I1}
+	}
+
+	return &c.typ
+}
+
+// Fence
+
+type FenceInst struct {
+	baseInst
+
+	Ordering AtomicOrdering
+}
+
+func (f *FenceInst) Type() Type {
+	return nil
+}
+
 type GetElementPtrInst struct {
 	baseInst
 
@@ -106,13 +106,14 @@ func (a *ArrayType) Equals(other Type) bool {
 type StructType struct {
 	Name   string
 	Fields []Type
+	Packed bool
 }
 
 func (s *StructType) isType() {}
 
 func (s *StructType) Equals(other Type) bool {
 	if other, ok := other.(*StructType); ok {
-		return slices.EqualFunc(s.Fields, other.Fields, func(t Type, t2 Type) bool {
+		return s.Packed == other.Packed && slices.EqualFunc(s.Fields, other.Fields, func(t Type, t2 Type) bool {
 			return t.Equals(t2)
 		})
 	}
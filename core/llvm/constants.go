@@ -68,6 +68,12 @@ func (w *textWriter) writeConst(c ir.Const) {
 		w.writeString("zeroinitializer")
 
 	case *ir.ArrayConst:
+		// Empty arrays can't be written as a list
+		if len(c.Values) == 0 {
+			w.writeString("zeroinitializer")
+			return
+		}
+
 		w.writeString("[ ")
 
 		for i, value := range c.Values {
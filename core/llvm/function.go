@@ -9,6 +9,14 @@ func (w *textWriter) writeFunctions() {
 			w.resetLocalNames()
 
 			w.writeString("define ")
+
+			switch function.Linkage {
+			case ir.LinkOnceODRLinkage:
+				w.writeString("linkonce_odr ")
+			case ir.InternalLinkage:
+				w.writeString("internal ")
+			}
+
 			w.writeFunction(function)
 
 			if function.Meta().Valid() {
@@ -3,6 +3,15 @@ package llvm
 import "fireball/core/ir"
 
 func (w *textWriter) writeGlobals() {
+	// Unnamed globals need to be numbered in the order they are defined, not the order they are first referenced in
+	for _, constant := range []bool{true, false} {
+		for _, global := range w.m.Globals {
+			if global.Constant == constant {
+				w.cacheName(global, true)
+			}
+		}
+	}
+
 	// Constants
 	count := 0
 
@@ -34,7 +43,13 @@ func (w *textWriter) writeGlobals() {
 
 func (w *textWriter) writeGlobalConstant(global *ir.GlobalVar) {
 	w.writeName(global)
-	w.writeString(" = private unnamed_addr constant ")
+
+	if global.Linkage == ir.LinkOnceODRLinkage {
+		w.writeString(" = linkonce_odr constant ")
+	} else {
+		w.writeString(" = private unnamed_addr constant ")
+	}
+
 	w.writeType(global.Typ)
 	w.writeRune(' ')
 	w.writeConst(global.Value.(ir.Const))
@@ -46,9 +61,26 @@ func (w *textWriter) writeGlobalVar(global *ir.GlobalVar) {
 	w.writeString(" = ")
 
 	if global.Value == nil {
-		w.writeString("external global ")
+		w.writeString("external ")
+
+		if global.ThreadLocal {
+			w.writeString("thread_local ")
+		}
+
+		w.writeString("global ")
 		w.writeType(global.Typ)
 	} else {
+		switch global.Linkage {
+		case ir.InternalLinkage:
+			w.writeString("internal ")
+		case ir.AppendingLinkage:
+			w.writeString("appending ")
+		}
+
+		if global.ThreadLocal {
+			w.writeString("thread_local ")
+		}
+
 		w.writeString("global ")
 		w.writeType(global.Typ)
 		w.writeRune(' ')
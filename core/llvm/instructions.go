@@ -35,6 +35,25 @@ func (w *textWriter) writeInstruction(inst ir.Inst) {
 			w.writeValue(inst.False)
 		}
 
+	case *ir.SwitchInst:
+		w.writeString("switch ")
+		w.writeValue(inst.Value)
+		w.writeString(", ")
+		w.writeValue(inst.Default)
+		w.writeString(" [")
+
+		for _, case_ := range inst.Cases {
+			w.writeRune(' ')
+			w.writeValue(case_.Value)
+			w.writeString(", ")
+			w.writeValue(case_.Label)
+		}
+
+		w.writeString(" ]")
+
+	case *ir.UnreachableInst:
+		w.writeString("unreachable")
+
 	case *ir.FNegInst:
 		w.writeString("fneg ")
 		w.writeValue(inst.Value)
@@ -172,9 +191,15 @@ func (w *textWriter) writeInstruction(inst ir.Inst) {
 
 	case *ir.LoadInst:
 		w.writeString("load ")
+
+		if inst.Ordering != ir.NotAtomic {
+			w.writeString("atomic ")
+		}
+
 		w.writeType(inst.Typ)
 		w.writeString(", ")
 		w.writeValue(inst.Pointer)
+		w.writeOrdering(inst.Ordering)
 
 		if inst.Align != 0 {
 			w.writeString(", align ")
@@ -183,15 +208,68 @@ func (w *textWriter) writeInstruction(inst ir.Inst) {
 
 	case *ir.StoreInst:
 		w.writeString("store ")
+
+		if inst.Ordering != ir.NotAtomic {
+			w.writeString("atomic ")
+		}
+
 		w.writeValue(inst.Value)
 		w.writeString(", ")
 		w.writeValue(inst.Pointer)
+		w.writeOrdering(inst.Ordering)
 
 		if inst.Align != 0 {
 			w.writeString(", align ")
 			w.writeUint(uint64(inst.Align), 10)
 		}
 
+	case *ir.AtomicRMWInst:
+		w.writeString("atomicrmw ")
+
+		switch inst.Kind {
+		case ir.XchgRMW:
+			w.writeString("xchg ")
+		case ir.AddRMW:
+			w.writeString("add ")
+		case ir.SubRMW:
+			w.writeString("sub ")
+		case ir.AndRMW:
+			w.writeString("and ")
+		case ir.OrRMW:
+			w.writeString("or ")
+		case ir.XorRMW:
+			w.writeString("xor ")
+		}
+
+		w.writeValue(inst.Pointer)
+		w.writeString(", ")
+		w.writeValue(inst.Value)
+		w.writeOrdering(inst.Ordering)
+
+		if inst.Align != 0 {
+			w.writeString(", align ")
+			w.writeUint(uint64(inst.Align), 10)
+		}
+
+	case *ir.CmpXchgInst:
+		w.writeString("cmpxchg ")
+		w.writeValue(inst.Pointer)
+		w.writeString(", ")
+		w.writeValue(inst.Expected)
+		w.writeString(", ")
+		w.writeValue(inst.Desired)
+		w.writeOrdering(inst.SuccessOrdering)
+		w.writeOrdering(inst.FailureOrdering)
+
+		if inst.Align != 0 {
+			w.writeString(", align ")
+			w.writeUint(uint64(inst.Align), 10)
+		}
+
+	case *ir.FenceInst:
+		w.writeString("fence")
+		w.writeOrdering(inst.Ordering)
+
 	case *ir.GetElementPtrInst:
 		w.writeString("getelementptr ")
 
@@ -429,3 +507,18 @@ func (w *textWriter) writeInstruction(inst ir.Inst) {
 		w.writeMetaRef(inst.Meta())
 	}
 }
+
+func (w *textWriter) writeOrdering(ordering ir.AtomicOrdering) {
+	switch ordering {
+	case ir.Monotonic:
+		w.writeString(" monotonic")
+	case ir.Acquire:
+		w.writeString(" acquire")
+	case ir.Release:
+		w.writeString(" release")
+	case ir.AcquireRelease:
+		w.writeString(" acq_rel")
+	case ir.SequentiallyConsistent:
+		w.writeString(" seq_cst")
+	}
+}
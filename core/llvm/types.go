@@ -87,6 +87,10 @@ func (w *textWriter) writeType(t ir.Type) {
 }
 
 func (w *textWriter) writeStruct(s *ir.StructType) {
+	if s.Packed {
+		w.writeRune('<')
+	}
+
 	w.writeString("{ ")
 
 	for i, field := range s.Fields {
@@ -98,4 +102,8 @@ func (w *textWriter) writeStruct(s *ir.StructType) {
 	}
 
 	w.writeString(" }")
+
+	if s.Packed {
+		w.writeRune('>')
+	}
 }
@@ -44,9 +44,41 @@ func (w *textWriter) writeName(v ir.Value) {
 		w.writeName_(v, true)
 	case *ir.Param, *ir.Block, ir.Inst:
 		w.writeName_(v, false)
+	case *ir.InlineAsm:
+		w.writeInlineAsm(v)
 	}
 }
 
+func (w *textWriter) writeInlineAsm(v *ir.InlineAsm) {
+	w.writeString("asm ")
+
+	if v.SideEffect {
+		w.writeString("sideeffect ")
+	}
+
+	w.writeAsmString(v.Template)
+	w.writeString(", ")
+	w.writeAsmString(v.Constraints)
+}
+
+const hexDigits = "0123456789ABCDEF"
+
+func (w *textWriter) writeAsmString(s string) {
+	w.writeRune('"')
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' || s[i] < ' ' {
+			w.writeRune('\\')
+			w.writeByte(hexDigits[s[i]>>4])
+			w.writeByte(hexDigits[s[i]&0xF])
+		} else {
+			w.writeByte(s[i])
+		}
+	}
+
+	w.writeRune('"')
+}
+
 func (w *textWriter) writeName_(v ir.Value, global bool) {
 	w.cacheName(v, global)
 
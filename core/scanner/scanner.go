@@ -128,6 +128,8 @@ func (s *Scanner) Next() PositionedToken {
 		}
 
 		return s.make(Xor)
+	case '~':
+		return s.make(Tilde)
 	case '&':
 		if s.match('=') {
 			return s.make(AmpersandEqual)
@@ -162,7 +164,13 @@ func (s *Scanner) identifierKind() TokenKind {
 	case 'b':
 		return s.checkKeyword(1, "reak", Break)
 	case 'c':
+		if s.currentI-s.startI > 3 && s.text[s.startI+3] == 's' {
+			return s.checkKeyword(1, "onst", Const)
+		}
+
 		return s.checkKeyword(1, "ontinue", Continue)
+	case 'd':
+		return s.checkKeyword(1, "efer", Defer)
 	case 'e':
 		if s.currentI-s.startI > 1 {
 			switch s.text[s.startI+1] {
@@ -193,11 +201,17 @@ func (s *Scanner) identifierKind() TokenKind {
 			case 'm':
 				return s.checkKeyword(2, "pl", Impl)
 			case 'n':
+				if s.currentI-s.startI == 2 {
+					return In
+				}
+
 				return s.checkKeyword(2, "terface", Interface)
 			case 's':
 				return s.checkKeyword(2, "", Is)
 			}
 		}
+	case 'm':
+		return s.checkKeyword(1, "atch", Match)
 	case 'n':
 		if s.currentI-s.startI > 1 {
 			switch s.text[s.startI+1] {
@@ -207,6 +221,8 @@ func (s *Scanner) identifierKind() TokenKind {
 				return s.checkKeyword(2, "l", Nil)
 			}
 		}
+	case 'p':
+		return s.checkKeyword(1, "ub", Pub)
 	case 'r':
 		return s.checkKeyword(1, "eturn", Return)
 	case 's':
@@ -52,6 +52,7 @@ const (
 	AmpersandEqual
 	Xor
 	XorEqual
+	Tilde
 	LessLess
 	LessLessEqual
 	GreaterGreater
@@ -66,18 +67,23 @@ const (
 	True
 	False
 	Var
+	Const
 	If
 	Else
 	While
 	For
+	Match
 	As
 	Is
+	In
+	Pub
 	Static
 	Func
 	Fn
 	Continue
 	Break
 	Return
+	Defer
 	Namespace
 	Using
 	Struct
@@ -282,6 +288,8 @@ func TokenKindStr(kind TokenKind) string {
 		return "'^'"
 	case XorEqual:
 		return "'^='"
+	case Tilde:
+		return "'~'"
 	case LessLess:
 		return "'<<'"
 	case LessLessEqual:
@@ -307,6 +315,8 @@ func TokenKindStr(kind TokenKind) string {
 		return "'or'"
 	case Var:
 		return "'var'"
+	case Const:
+		return "'const'"
 	case If:
 		return "'if'"
 	case Else:
@@ -315,10 +325,16 @@ func TokenKindStr(kind TokenKind) string {
 		return "'while'"
 	case For:
 		return "'for'"
+	case Match:
+		return "'match'"
 	case As:
 		return "'as'"
 	case Is:
 		return "'is'"
+	case In:
+		return "'in'"
+	case Pub:
+		return "'pub'"
 	case Static:
 		return "'static'"
 	case Func:
@@ -331,6 +347,8 @@ func TokenKindStr(kind TokenKind) string {
 		return "'break'"
 	case Return:
 		return "'return'"
+	case Defer:
+		return "'defer'"
 	case Namespace:
 		return "'namespace'"
 	case Using:
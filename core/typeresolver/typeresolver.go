@@ -3,11 +3,8 @@ package typeresolver
 import (
 	"fireball/core"
 	"fireball/core/ast"
-	"fireball/core/scanner"
 	"fireball/core/utils"
 	"fmt"
-	"math"
-	"strconv"
 	"strings"
 )
 
@@ -51,81 +48,10 @@ func (t *typeResolver) visitStruct(decl *ast.Struct) {
 }
 
 func (t *typeResolver) visitEnum(decl *ast.Enum) {
-	// Set case values
-	lastValue := int64(-1)
-
-	for _, case_ := range decl.Cases {
-		if case_.Value == nil {
-			lastValue++
-			case_.ActualValue = lastValue
-		} else {
-			var value int64
-			var err error
-
-			switch case_.Value.Token().Kind {
-			case scanner.Number:
-				value, err = strconv.ParseInt(case_.Value.String(), 10, 64)
-			case scanner.Hex:
-				value, err = strconv.ParseInt(case_.Value.String()[2:], 16, 64)
-			case scanner.Binary:
-				value, err = strconv.ParseInt(case_.Value.String()[2:], 2, 64)
-
-			default:
-				panic("checker.VisitEnum() - Not implemented")
-			}
-
-			if err == nil {
-				lastValue = value
-				case_.ActualValue = value
-			} else {
-				errorNode(t.reporter, case_.Value, "Failed to parse number")
-
-				lastValue++
-				case_.ActualValue = lastValue
-			}
-		}
-	}
-
-	// Find type
-	if decl.Type == nil {
-		minValue := int64(math.MaxInt64)
-		maxValue := int64(math.MinInt64)
-
-		for _, case_ := range decl.Cases {
-			minValue = min(minValue, case_.ActualValue)
-			maxValue = max(maxValue, case_.ActualValue)
-		}
-
-		var kind ast.PrimitiveKind
-
-		if minValue >= 0 {
-			// Unsigned
-			if maxValue <= math.MaxUint8 {
-				kind = ast.U8
-			} else if maxValue <= math.MaxUint16 {
-				kind = ast.U16
-			} else if maxValue <= math.MaxUint32 {
-				kind = ast.U32
-			} else {
-				kind = ast.U64
-			}
-		} else {
-			// Signed
-			if minValue >= math.MinInt8 && maxValue <= math.MaxInt8 {
-				kind = ast.I8
-			} else if minValue >= math.MinInt16 && maxValue <= math.MaxInt16 {
-				kind = ast.I16
-			} else if minValue >= math.MinInt32 && maxValue <= math.MaxInt32 {
-				kind = ast.I32
-			} else {
-				kind = ast.I64
-			}
-		}
+	decl.AcceptChildren(t)
 
-		decl.ActualType = &ast.Primitive{Kind: kind}
-	} else {
-		decl.ActualType = decl.Type
-	}
+	// Case values and the type are computed by the constant evaluator
+	decl.State = ast.NotEvaluated
 }
 
 func (t *typeResolver) visitImpl(decl *ast.Impl) {
@@ -138,6 +64,10 @@ func (t *typeResolver) visitImpl(decl *ast.Impl) {
 		if s, ok := type_.(*ast.Struct); ok {
 			decl.Type = s
 
+			if !ast.IsAccessible(s, decl) {
+				errorNode(t.reporter, decl.Struct, "Struct '%s' is private to its namespace", decl.Struct)
+			}
+
 			if len(s.GenericParams) > 0 {
 				t.resolver = ast.NewGenericResolver(t.resolver, s.GenericParams)
 			}
@@ -160,6 +90,17 @@ func (t *typeResolver) visitImpl(decl *ast.Impl) {
 	t.resolver = prevResolver
 }
 
+func (t *typeResolver) visitInterface(decl *ast.Interface) {
+	prevResolver := t.resolver
+	if len(decl.GenericParams) != 0 {
+		t.resolver = ast.NewGenericResolver(t.resolver, decl.GenericParams)
+	}
+
+	decl.AcceptChildren(t)
+
+	t.resolver = prevResolver
+}
+
 func (t *typeResolver) visitFunc(decl *ast.Func) {
 	prevResolver := t.resolver
 	if len(decl.GenericParams) != 0 {
@@ -192,19 +133,13 @@ func (t *typeResolver) visitType(type_ ast.Type) {
 		if resolved != nil {
 			// Store resolved type
 			resolvable.Type = resolved
-		} else {
-			// Report an error
-			str := strings.Builder{}
 
-			for i, part := range resolvable.Parts {
-				if i > 0 {
-					str.WriteRune('.')
-				}
-
-				str.WriteString(part.String())
+			if decl, ok := resolved.(ast.Visible); ok && !ast.IsAccessible(decl, resolvable) {
+				errorNode(t.reporter, resolvable, "Type '%s' is private to its namespace", resolvableName(resolvable))
 			}
-
-			errorNode(t.reporter, resolvable, "Unknown type '%s'", str.String())
+		} else {
+			// Report an error
+			errorNode(t.reporter, resolvable, "Unknown type '%s'", resolvableName(resolvable))
 			resolvable.Type = &ast.Primitive{Kind: ast.Void}
 
 			if t.expr != nil {
@@ -213,6 +148,11 @@ func (t *typeResolver) visitType(type_ ast.Type) {
 		}
 	}
 
+	// Array sizes are computed by the constant evaluator
+	if array, ok := type_.(*ast.Array); ok && array.Size != nil {
+		array.State = ast.NotEvaluated
+	}
+
 	// Visit children
 	type_.AcceptChildren(t)
 }
@@ -230,9 +170,16 @@ func (t *typeResolver) VisitNode(node ast.Node) {
 	case *ast.Impl:
 		t.visitImpl(node)
 
+	case *ast.Interface:
+		t.visitInterface(node)
+
 	case *ast.Func:
 		t.visitFunc(node)
 
+	case *ast.Const:
+		node.AcceptChildren(t)
+		node.State = ast.NotEvaluated
+
 	case ast.Expr:
 		prevTypeExpr := t.expr
 		t.expr = node
@@ -251,6 +198,20 @@ func (t *typeResolver) VisitNode(node ast.Node) {
 
 // Utils
 
+func resolvableName(resolvable *ast.Resolvable) string {
+	str := strings.Builder{}
+
+	for i, part := range resolvable.Parts {
+		if i > 0 {
+			str.WriteRune('.')
+		}
+
+		str.WriteString(part.String())
+	}
+
+	return str.String()
+}
+
 func errorNode(reporter utils.Reporter, node ast.Node, format string, args ...any) {
 	if ast.IsNil(node) {
 		return
@@ -29,6 +29,18 @@ func (t *typeSpecializer) visitResolvable(resolvable *ast.Resolvable) {
 		if len(resolvable.GenericArgs) != 0 {
 			resolvable.Type = s.Specialize(resolvable.GenericArgs)
 		}
+	} else if inter, ok := ast.As[*ast.Interface](resolvable.Type); ok {
+		if len(resolvable.GenericArgs) != len(inter.GenericParams) {
+			if resolvable.GenericArgs != nil {
+				errorSlice(t.reporter, resolvable.GenericArgs, "Got '%d' generic arguments but interface takes '%d'", len(resolvable.GenericArgs), len(inter.GenericParams))
+			} else {
+				errorNode(t.reporter, resolvable.Parts[len(resolvable.Parts)-1], "Got '%d' generic arguments but interface takes '%d'", len(resolvable.GenericArgs), len(inter.GenericParams))
+			}
+		}
+
+		if len(resolvable.GenericArgs) != 0 {
+			resolvable.Type = inter.Specialize(resolvable.GenericArgs)
+		}
 	} else if len(resolvable.GenericArgs) != 0 {
 		errorSlice(t.reporter, resolvable.GenericArgs, "This type doesn't have any generic parameters")
 	}
@@ -13,6 +13,9 @@ type Config struct {
 	Namespace ConfigNamespace
 
 	LinkLibraries []string
+
+	// SafetyChecks overrides if runtime safety checks are emitted, by default they are only enabled at -O0
+	SafetyChecks *bool
 }
 
 type ConfigNamespace []string
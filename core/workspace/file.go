@@ -15,6 +15,9 @@ type File struct {
 	Project *Project
 	Path    string
 
+	// Builtin files are embedded in the compiler and are not part of the project namespace
+	Builtin bool
+
 	Text string
 
 	Cst cst.Node
@@ -65,6 +68,13 @@ func (f *File) SetText(text string, parse bool) {
 			}
 		}
 
+		// Evaluate constants
+		for _, file := range f.Project.Files {
+			if resolver := f.Project.getNamespace(file.Ast); resolver != nil {
+				checker.Evaluate(file, resolver, file.Ast)
+			}
+		}
+
 		// Specialize types
 		for _, file := range f.Project.Files {
 			typeresolver.Specialize(file, file.Ast)
@@ -75,7 +85,11 @@ func (f *File) SetText(text string, parse bool) {
 			if resolver := f.Project.getNamespace(file.Ast); resolver != nil {
 				checker.Check(file, resolver, file.Ast)
 			}
+		}
 
+		f.Project.checkInitializers()
+
+		for _, file := range f.Project.Files {
 			file.checkWaitGroup.Done()
 		}
 	}
@@ -143,6 +143,18 @@ func (n *namespace) GetVariable(name string) *ast.GlobalVar {
 	return nil
 }
 
+func (n *namespace) GetConstant(name string) *ast.Const {
+	for _, file := range n.files {
+		for _, decl := range file.Ast.Decls {
+			if constant, ok := decl.(*ast.Const); ok && constant.Name != nil && constant.Name.String() == name {
+				return constant
+			}
+		}
+	}
+
+	return nil
+}
+
 func (n *namespace) GetMethod(type_ ast.Type, name string, static bool) ast.FuncType {
 	guh := type_
 
@@ -191,7 +203,7 @@ func (n *namespace) GetMethods(type_ ast.Type, static bool) []*ast.Func {
 	return methods
 }
 
-func (n *namespace) GetImpl(type_ ast.Type, inter *ast.Interface) *ast.Impl {
+func (n *namespace) GetImpl(type_ ast.Type, inter ast.InterfaceType) *ast.Impl {
 	for _, file := range n.files {
 		for _, decl := range file.Ast.Decls {
 			if impl, ok := decl.(*ast.Impl); ok && type_.Equals(impl.Type) && inter.Equals(impl.Implements) {
@@ -200,6 +212,17 @@ func (n *namespace) GetImpl(type_ ast.Type, inter *ast.Interface) *ast.Impl {
 		}
 	}
 
+	// Implementing an interface also implements the interfaces it inherits
+	for _, file := range n.files {
+		for _, decl := range file.Ast.Decls {
+			if impl, ok := decl.(*ast.Impl); ok && type_.Equals(impl.Type) {
+				if implements, ok := ast.As[ast.InterfaceType](impl.Implements); ok && ast.Inherits(implements, inter) {
+					return impl
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -217,7 +240,7 @@ func (n *namespace) GetSymbols(visitor ast.SymbolVisitor) {
 	for _, file := range n.files {
 		for _, decl := range file.Ast.Decls {
 			switch decl.(type) {
-			case *ast.Struct, *ast.Impl, *ast.Enum, *ast.Interface, *ast.Func, *ast.GlobalVar:
+			case *ast.Struct, *ast.Impl, *ast.Enum, *ast.Interface, *ast.Func, *ast.GlobalVar, *ast.Const:
 				visitor.VisitSymbol(decl)
 			}
 		}
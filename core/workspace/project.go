@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fireball/core/ast"
 	"fireball/core/ast/cst2ast"
+	"fireball/core/builtin"
 	"fireball/core/checker"
 	"fireball/core/cst"
 	"fireball/core/typeresolver"
@@ -15,6 +16,9 @@ import (
 	"strings"
 )
 
+// Folder containing the builtin files, relative to the project path
+const builtinFolder = "__builtin"
+
 type Project struct {
 	Path   string
 	Config Config
@@ -79,10 +83,20 @@ func NewEmptyProject(path, name string) *Project {
 	}
 
 	p.namespace.project = p
+	p.addBuiltinFiles(true)
 
 	return p
 }
 
+func (p *Project) addBuiltinFiles(parse bool) {
+	for path, text := range builtin.Files {
+		file := p.GetOrCreateFile(filepath.Join(builtinFolder, path))
+		file.Builtin = true
+
+		file.SetText(text, parse)
+	}
+}
+
 // Namespaces
 
 func (p *Project) addFileToNamespace(file *File) {
@@ -94,10 +108,13 @@ func (p *Project) addFileToNamespace(file *File) {
 	parts := file.Ast.Namespace.Name.Parts
 	ok := true
 
-	for i, part := range p.Config.Namespace {
-		if i >= len(parts) || part != parts[i].String() {
-			ok = false
-			break
+	// Builtin files are not part of the project namespace
+	if !file.Builtin {
+		for i, part := range p.Config.Namespace {
+			if i >= len(parts) || part != parts[i].String() {
+				ok = false
+				break
+			}
 		}
 	}
 
@@ -137,6 +154,19 @@ func (p *Project) getNamespace(file *ast.File) *namespace {
 	return n
 }
 
+// checkInitializers checks the initializers of global variables and static fields across all files of the project.
+func (p *Project) checkInitializers() {
+	files := make(map[*ast.File]utils.Reporter, len(p.Files))
+
+	for _, file := range p.Files {
+		if p.getNamespace(file.Ast) != nil {
+			files[file.Ast] = file
+		}
+	}
+
+	checker.CheckInitializers(files)
+}
+
 func (p *Project) GetResolverFile(file *ast.File) ast.RootResolver {
 	if n := p.getNamespace(file); n != nil {
 		return n
@@ -186,6 +216,8 @@ func (p *Project) LoadFiles() error {
 		file.SetText(string(contents), false)
 	}
 
+	p.addBuiltinFiles(false)
+
 	// Parse
 	for _, file := range p.Files {
 		file.parseWaitGroup.Add(1)
@@ -213,6 +245,13 @@ func (p *Project) LoadFiles() error {
 		}
 	}
 
+	// Evaluate constants
+	for _, file := range p.Files {
+		if resolver := p.getNamespace(file.Ast); resolver != nil {
+			checker.Evaluate(file, resolver, file.Ast)
+		}
+	}
+
 	// Specialize types
 	for _, file := range p.Files {
 		typeresolver.Specialize(file, file.Ast)
@@ -223,7 +262,11 @@ func (p *Project) LoadFiles() error {
 		if resolver := p.getNamespace(file.Ast); resolver != nil {
 			checker.Check(file, resolver, file.Ast)
 		}
+	}
 
+	p.checkInitializers()
+
+	for _, file := range p.Files {
 		file.checkWaitGroup.Done()
 	}
 
@@ -273,6 +316,12 @@ func (p *Project) RemoveFile(path string) bool {
 			}
 		}
 
+		for _, file := range p.Files {
+			if resolver := p.getNamespace(file.Ast); resolver != nil {
+				checker.Evaluate(file, resolver, file.Ast)
+			}
+		}
+
 		for _, file := range p.Files {
 			typeresolver.Specialize(file, file.Ast)
 		}
@@ -280,6 +329,13 @@ func (p *Project) RemoveFile(path string) bool {
 		for _, file := range p.Files {
 			if resolver := p.getNamespace(file.Ast); resolver != nil {
 				checker.Check(file, resolver, file.Ast)
+			}
+		}
+
+		p.checkInitializers()
+
+		for _, file := range p.Files {
+			if p.getNamespace(file.Ast) != nil {
 				file.checkWaitGroup.Done()
 			}
 		}
@@ -11,6 +11,7 @@ var groups = []Group{
 var externalNodes = []string{
 	"SpecializedField",
 	"SpecializedStruct",
+	"SpecializedInterface",
 	"SpecializedFunc",
 	"PartiallySpecializedFunc",
 }
@@ -34,7 +35,13 @@ var types = Group{
 		node(
 			"Array",
 			field("base", type_("Type")),
-			field("count", type_("uint32")),
+			field("size", type_("Expr")),
+			field("Count", type_("uint32")),
+			field("State", type_("EvalState")),
+		),
+		node(
+			"Slice",
+			field("base", type_("Type")),
 		),
 		node(
 			"Resolvable",
@@ -45,6 +52,7 @@ var types = Group{
 		nodeSkipResolved(
 			"Generic",
 			field("name", type_("scanner.Token")),
+			field("bound", type_("Type")),
 			field("Type", type_("Type")),
 		),
 	},
@@ -79,16 +87,19 @@ var declarations = Group{
 			field("genericParams", array("Generic")),
 			field("fields", array("Field")),
 			field("staticFields", array("Field")),
+			field("constants", array("Const")),
 
 			field("Specializations", array("*SpecializedStruct")),
 			field("Type", type_("Type")),
 		),
 		node(
 			"Enum",
+			field("attributes", array("Attribute")),
 			field("name", type_("Token")),
 			field("type", type_("Type")),
 			field("ActualType", type_("Type")),
 			field("cases", array("EnumCase")),
+			field("State", type_("EvalState")),
 		),
 		node(
 			"Impl",
@@ -100,7 +111,11 @@ var declarations = Group{
 		node(
 			"Interface",
 			field("name", type_("Token")),
+			field("genericParams", array("Generic")),
+			field("inherits", array("Type")),
 			field("methods", array("Func")),
+
+			field("Specializations", array("*SpecializedInterface")),
 		),
 		node(
 			"Func",
@@ -113,11 +128,23 @@ var declarations = Group{
 			field("body", array("Stmt")),
 
 			field("Specializations", array("*SpecializedFunc")),
+			field("UsedOutsideFile", type_("bool")),
 		),
 		node(
 			"GlobalVar",
+			field("attributes", array("Attribute")),
 			field("name", type_("Token")),
 			field("type", type_("Type")),
+			field("value", type_("Expr")),
+			field("ActualValue", type_("Constant")),
+		),
+		node(
+			"Const",
+			field("name", type_("Token")),
+			field("type", type_("Type")),
+			field("value", type_("Expr")),
+			field("ActualValue", type_("Constant")),
+			field("State", type_("EvalState")),
 		),
 	},
 }
@@ -152,22 +179,47 @@ var statements = Group{
 		),
 		node(
 			"While",
+			field("label", type_("Token")),
 			field("condition", type_("Expr")),
 			field("body", type_("Stmt")),
 		),
 		node(
 			"For",
+			field("label", type_("Token")),
 			field("initializer", type_("Stmt")),
 			field("condition", type_("Expr")),
 			field("increment", type_("Expr")),
 			field("body", type_("Stmt")),
 		),
+		node(
+			"ForIn",
+			field("label", type_("Token")),
+			field("name", type_("Token")),
+			field("iterable", type_("Expr")),
+			field("body", type_("Stmt")),
+			field("ActualType", type_("Type")),
+		),
+		node(
+			"Match",
+			field("value", type_("Expr")),
+			field("arms", array("MatchArm")),
+		),
+		node(
+			"Defer",
+			field("stmt", type_("Stmt")),
+		),
 		nodeAllowEmpty(
 			"Return",
 			field("value", type_("Expr")),
 		),
-		node("Break"),
-		node("Continue"),
+		nodeAllowEmpty(
+			"Break",
+			field("label", type_("Token")),
+		),
+		nodeAllowEmpty(
+			"Continue",
+			field("label", type_("Token")),
+		),
 	},
 }
 
@@ -221,6 +273,12 @@ var expressions = Group{
 			field("value", type_("Expr")),
 			field("index", type_("Expr")),
 		),
+		node(
+			"Slicing",
+			field("value", type_("Expr")),
+			field("low", type_("Expr")),
+			field("high", type_("Expr")),
+		),
 		node(
 			"Cast",
 			field("value", type_("Expr")),
@@ -231,6 +289,13 @@ var expressions = Group{
 			"Call",
 			field("callee", type_("Expr")),
 			field("args", array("Expr")),
+			field("ActualArgs", array("Expr")),
+			field("MemoryOrder", type_("*EnumCase")),
+		),
+		node(
+			"NamedArg",
+			field("name", type_("Token")),
+			field("value", type_("Expr")),
 		),
 		node(
 			"TypeCall",
@@ -242,6 +307,13 @@ var expressions = Group{
 			field("callee", type_("Token")),
 			field("arg", type_("Expr")),
 		),
+		node(
+			"Asm",
+			field("template", type_("Token")),
+			field("outputs", array("AsmOperand")),
+			field("inputs", array("AsmOperand")),
+			field("clobbers", array("Token")),
+		),
 		node(
 			"StructInitializer",
 			field("new", type_("bool")),
@@ -257,6 +329,11 @@ var expressions = Group{
 			field("type", type_("Type")),
 			field("count", type_("Expr")),
 		),
+		node(
+			"Closure",
+			field("captures", array("Capture")),
+			field("function", type_("Func")),
+		),
 		node(
 			"Identifier",
 			field("name", type_("Token")),
@@ -278,6 +355,9 @@ var other = Group{
 			field("namespace", type_("Namespace")),
 			field("Resolver", type_("Resolver")),
 			field("decls", array("Decl")),
+
+			field("Initializers", array("Node")),
+			field("InitPriority", type_("uint16")),
 		),
 		node(
 			"NamespaceName",
@@ -285,24 +365,48 @@ var other = Group{
 		),
 		node(
 			"Field",
+			field("attributes", array("Attribute")),
 			field("name_", type_("Token")),
 			field("type_", type_("Type")),
+			field("value", type_("Expr")),
+			field("ActualValue", type_("Constant")),
 		),
 		node(
 			"InitField",
 			field("name", type_("Token")),
 			field("value", type_("Expr")),
 		),
+		node(
+			"MatchArm",
+			field("default", type_("bool")),
+			field("values", array("Expr")),
+			field("bindings", array("Token")),
+			field("body", type_("Stmt")),
+			field("ActualValues", array("int64")),
+		),
+		node(
+			"Capture",
+			field("reference", type_("bool")),
+			field("name", type_("Token")),
+			field("ActualType", type_("Type")),
+		),
 		node(
 			"EnumCase",
 			field("name", type_("Token")),
-			field("value", type_("Token")),
+			field("value", type_("Expr")),
+			field("fields", array("Field")),
 			field("ActualValue", type_("int64")),
 		),
 		node(
 			"Param",
 			field("name", type_("Token")),
 			field("type", type_("Type")),
+			field("value", type_("Expr")),
+		),
+		node(
+			"AsmOperand",
+			field("constraint", type_("Token")),
+			field("value", type_("Expr")),
 		),
 		node(
 			"Attribute",
@@ -453,7 +453,7 @@ func genIsNil(w *Writer) {
 
 func name(name string) string {
 	switch name {
-	case "struct", "type", "else", "func", "Token":
+	case "struct", "type", "else", "func", "default", "Token":
 		return name + "_"
 
 	default: